@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+const apiKeyUsage = "usage: tracker apikey create <name> read|read-write <user-email> | list | revoke <id|name>"
+
+// runAPIKey implements `tracker apikey create|list|revoke`. A key acts as the
+// user it is created for. The secret of a new key is printed once and cannot
+// be shown again.
+func runAPIKey(args []string, dbPath string) error {
+	if len(args) == 0 {
+		return errors.New(apiKeyUsage)
+	}
+
+	store, err := db.NewStore(dbPath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "create":
+		if len(args) != 4 || args[1] == "" {
+			return errors.New(apiKeyUsage)
+		}
+		if err := model.ValidateScope(args[2]); err != nil {
+			return err
+		}
+		user, err := findUser(ctx, store, args[3])
+		if err != nil {
+			return err
+		}
+		key, secret, err := store.CreateAPIKey(ctx, user.ID, args[1], args[2])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created %s key %q for %s (id %s)\n", key.Scope, key.Name, user.Email, key.ID)
+		fmt.Println(secret)
+		fmt.Println("store this key now; it cannot be shown again")
+
+	case "list":
+		keys, err := store.ListAPIKeys(ctx)
+		if err != nil {
+			return err
+		}
+		emails, err := userEmails(ctx, store)
+		if err != nil {
+			return err
+		}
+		for _, k := range keys {
+			lastUsed := k.LastUsedAt
+			if lastUsed == "" {
+				lastUsed = "never"
+			}
+			state := "last used " + lastUsed
+			if k.RevokedAt != "" {
+				state = "revoked " + k.RevokedAt
+			}
+			owner := emails[k.UserID]
+			if owner == "" {
+				owner = "(no user)"
+			}
+			fmt.Printf("%s  %-20s %-10s %-24s %s...  created %s, %s\n", k.ID, k.Name, k.Scope, owner, k.Prefix, k.CreatedAt, state)
+		}
+
+	case "revoke":
+		if len(args) != 2 {
+			return errors.New(apiKeyUsage)
+		}
+		revoked, err := store.RevokeAPIKey(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		if !revoked {
+			return fmt.Errorf("no live API key with id or name %q", args[1])
+		}
+		fmt.Printf("revoked %s\n", args[1])
+
+	default:
+		return errors.New(apiKeyUsage)
+	}
+	return nil
+}
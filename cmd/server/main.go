@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 
 	"github.com/shakilbd009/job-hunt-platform/internal/db"
 	"github.com/shakilbd009/job-hunt-platform/internal/handler"
+	"github.com/shakilbd009/job-hunt-platform/internal/jobs"
 )
 
 func main() {
@@ -30,6 +33,28 @@ func main() {
 		dbPath = "./data/tracker.db"
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(os.Args[2:], dbPath); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "apikey" {
+		if err := runAPIKey(os.Args[2:], dbPath); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "user" {
+		if err := runUser(os.Args[2:], dbPath); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	store, err := db.NewStore(dbPath)
 	if err != nil {
 		slog.Error("failed to open database", "error", err)
@@ -37,7 +62,74 @@ func main() {
 	}
 	defer store.Close()
 
+	if v := os.Getenv("FOLLOW_UP_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			slog.Error("invalid FOLLOW_UP_DAYS, expected a non-negative integer", "value", v)
+			os.Exit(1)
+		}
+		store.SetFollowUpDays(days)
+	}
+
+	ghostCfg, ghostEnabled, err := ghostConfigFromEnv()
+	if err != nil {
+		slog.Error("invalid ghost detector configuration", "error", err)
+		os.Exit(1)
+	}
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	var jobsWG sync.WaitGroup
+	if ghostEnabled {
+		detector, err := jobs.NewGhostDetector(store, ghostCfg)
+		if err != nil {
+			slog.Error("invalid ghost detector configuration", "error", err)
+			os.Exit(1)
+		}
+		jobsWG.Add(1)
+		go func() {
+			defer jobsWG.Done()
+			detector.Run(jobsCtx)
+		}()
+		slog.Info("ghost detector started", "after", ghostCfg.After.String(), "interval", ghostCfg.Interval.String(), "mode", ghostCfg.Mode)
+	}
+	trashCfg, trashEnabled, err := trashConfigFromEnv()
+	if err != nil {
+		slog.Error("invalid trash purger configuration", "error", err)
+		os.Exit(1)
+	}
+	if trashEnabled {
+		purger, err := jobs.NewTrashPurger(store, trashCfg)
+		if err != nil {
+			slog.Error("invalid trash purger configuration", "error", err)
+			os.Exit(1)
+		}
+		jobsWG.Add(1)
+		go func() {
+			defer jobsWG.Done()
+			purger.Run(jobsCtx)
+		}()
+		slog.Info("trash purger started", "retention", trashCfg.Retention.String(), "interval", trashCfg.Interval.String())
+	}
+
 	h := handler.New(store)
+	if v := os.Getenv("AUTH_DISABLED"); v != "" {
+		disabled, err := strconv.ParseBool(v)
+		if err != nil {
+			slog.Error("invalid AUTH_DISABLED, expected true or false", "value", v)
+			os.Exit(1)
+		}
+		if disabled {
+			slog.Warn("API key authentication is disabled; anyone who can reach the server has full access")
+		}
+		h.SetAuthRequired(!disabled)
+	}
+	if v := os.Getenv("IDEMPOTENCY_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl < 0 {
+			slog.Error("invalid IDEMPOTENCY_TTL, expected a duration like 24h, or 0 to disable", "value", v)
+			os.Exit(1)
+		}
+		h.SetIdempotencyTTL(ttl)
+	}
 
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
@@ -74,5 +166,58 @@ func main() {
 		slog.Error("forced shutdown", "error", err)
 		os.Exit(1)
 	}
+	stopJobs()
+	jobsWG.Wait()
 	slog.Info("server stopped")
 }
+
+// ghostConfigFromEnv reads GHOST_AFTER_DAYS (0 disables the detector),
+// GHOST_MODE and GHOST_CHECK_INTERVAL over the defaults.
+func ghostConfigFromEnv() (jobs.GhostConfig, bool, error) {
+	cfg := jobs.DefaultGhostConfig()
+	if v := os.Getenv("GHOST_AFTER_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			return cfg, false, fmt.Errorf("GHOST_AFTER_DAYS must be a non-negative integer, got %q", v)
+		}
+		if days == 0 {
+			return cfg, false, nil
+		}
+		cfg.After = time.Duration(days) * 24 * time.Hour
+	}
+	if v := os.Getenv("GHOST_MODE"); v != "" {
+		cfg.Mode = v
+	}
+	if v := os.Getenv("GHOST_CHECK_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return cfg, false, fmt.Errorf("GHOST_CHECK_INTERVAL must be a positive duration like 30m, got %q", v)
+		}
+		cfg.Interval = d
+	}
+	return cfg, true, nil
+}
+
+// trashConfigFromEnv reads TRASH_RETENTION_DAYS (0 keeps trashed applications
+// until purged by hand) and TRASH_CHECK_INTERVAL over the defaults.
+func trashConfigFromEnv() (jobs.TrashConfig, bool, error) {
+	cfg := jobs.DefaultTrashConfig()
+	if v := os.Getenv("TRASH_RETENTION_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			return cfg, false, fmt.Errorf("TRASH_RETENTION_DAYS must be a non-negative integer, got %q", v)
+		}
+		if days == 0 {
+			return cfg, false, nil
+		}
+		cfg.Retention = time.Duration(days) * 24 * time.Hour
+	}
+	if v := os.Getenv("TRASH_CHECK_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return cfg, false, fmt.Errorf("TRASH_CHECK_INTERVAL must be a positive duration like 30m, got %q", v)
+		}
+		cfg.Interval = d
+	}
+	return cfg, true, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+)
+
+const migrateUsage = "usage: tracker migrate status|up|down [steps]"
+
+// runMigrate implements `tracker migrate status|up|down [steps]`. It opens the
+// database without auto-migrating so status works even when the schema is
+// newer than this binary.
+func runMigrate(args []string, dbPath string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	store, err := db.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "status":
+		statuses, err := store.Migrations(ctx)
+		if err != nil {
+			return err
+		}
+		for _, m := range statuses {
+			state := "pending"
+			if m.Applied {
+				state = "applied " + m.AppliedAt
+			}
+			if m.Version > db.LatestSchemaVersion() {
+				state += " (unknown to this binary)"
+			}
+			fmt.Printf("%4d  %-32s %s\n", m.Version, m.Name, state)
+		}
+		return nil
+
+	case "up":
+		n, err := store.MigrateUp(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("applied %d migration(s)\n", n)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return errors.New("steps must be a positive integer")
+			}
+		}
+		n, err := store.MigrateDown(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("rolled back %d migration(s)\n", n)
+
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	version, err := store.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("schema version %d (latest %d)\n", version, db.LatestSchemaVersion())
+	return nil
+}
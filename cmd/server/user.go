@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+const userUsage = "usage: tracker user create <email> | list"
+
+// runUser implements `tracker user create|list`. create reads the password
+// from the first line of stdin so it never shows up in shell history or ps.
+func runUser(args []string, dbPath string) error {
+	if len(args) == 0 {
+		return errors.New(userUsage)
+	}
+
+	store, err := db.NewStore(dbPath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "create":
+		if len(args) != 2 {
+			return errors.New(userUsage)
+		}
+		email, err := model.NormalizeEmail(args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(os.Stderr, "password: ")
+		password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && password == "" {
+			return fmt.Errorf("reading password: %w", err)
+		}
+		password = strings.TrimRight(password, "\r\n")
+		if err := model.ValidatePassword(password); err != nil {
+			return err
+		}
+		existing, err := store.ListUsers(ctx)
+		if err != nil {
+			return err
+		}
+		user, err := store.CreateUser(ctx, email, password)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created user %s (id %s)\n", user.Email, user.ID)
+		if len(existing) == 0 {
+			fmt.Println("as the first user, it now owns all existing data and API keys")
+		}
+
+	case "list":
+		users, err := store.ListUsers(ctx)
+		if err != nil {
+			return err
+		}
+		for _, u := range users {
+			fmt.Printf("%s  %-32s created %s\n", u.ID, u.Email, u.CreatedAt)
+		}
+
+	default:
+		return errors.New(userUsage)
+	}
+	return nil
+}
+
+func findUser(ctx context.Context, store *db.Store, email string) (*model.User, error) {
+	email, err := model.NormalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	user, err := store.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("no user %s; create one with tracker user create", email)
+	}
+	return user, nil
+}
+
+// userEmails maps user IDs to addresses for listings.
+func userEmails(ctx context.Context, store *db.Store) (map[string]string, error) {
+	users, err := store.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	emails := make(map[string]string, len(users))
+	for _, u := range users {
+		emails[u.ID] = u.Email
+	}
+	return emails, nil
+}
@@ -0,0 +1,167 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+const (
+	apiKeyColumns = "id, user_id, name, prefix, scope, created_at, last_used_at, revoked_at"
+
+	// apiKeySecretPrefix marks a string as one of our keys, which makes them
+	// easy to spot in config files and secret scanners.
+	apiKeySecretPrefix = "jht_"
+
+	// apiKeyTouchInterval limits how often last_used_at is rewritten for a
+	// busy key.
+	apiKeyTouchInterval = time.Minute
+)
+
+// ErrAmbiguousKeyName is returned when revoking by a name that more than one
+// live key has; those keys must be revoked by ID.
+var ErrAmbiguousKeyName = errors.New("several live API keys have that name; revoke by id")
+
+func scanAPIKey(row scanner) (model.APIKey, error) {
+	var k model.APIKey
+	err := row.Scan(&k.ID, &k.UserID, &k.Name, &k.Prefix, &k.Scope, &k.CreatedAt, &k.LastUsedAt, &k.RevokedAt)
+	return k, err
+}
+
+// hashSecret is how API keys and session tokens are stored. Both are random
+// enough that a fast hash is safe.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIKey stores a new key for userID and returns it along with its
+// secret, which cannot be recovered later. scope must be valid and name
+// unused among userID's keys.
+func (s *Store) CreateAPIKey(ctx context.Context, userID, name, scope string) (*model.APIKey, string, error) {
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", fmt.Errorf("generating key: %w", err)
+	}
+	secret := apiKeySecretPrefix + hex.EncodeToString(raw)
+	key := model.APIKey{
+		ID:        generateID(),
+		UserID:    userID,
+		Name:      strings.TrimSpace(name),
+		Prefix:    secret[:len(apiKeySecretPrefix)+8],
+		Scope:     scope,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var taken int
+		if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM api_keys WHERE user_id = ? AND name = ?", key.UserID, key.Name).Scan(&taken); err != nil {
+			return err
+		}
+		if taken > 0 {
+			return fmt.Errorf("this user already has an API key named %q", key.Name)
+		}
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scope, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			key.ID, key.UserID, key.Name, key.Prefix, hashSecret(secret), key.Scope, key.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, model.AuditCreate, auditAPIKey, key.ID, nil, key)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return &key, secret, nil
+}
+
+// ListAPIKeys returns every key, revoked ones included, oldest first.
+func (s *Store) ListAPIKeys(ctx context.Context) ([]model.APIKey, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+apiKeyColumns+" FROM api_keys ORDER BY created_at, name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []model.APIKey{}
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey revokes the live key with the given ID or name. Returns false
+// if there is none, and ErrAmbiguousKeyName if several users have a live key
+// of that name.
+func (s *Store) RevokeAPIKey(ctx context.Context, idOrName string) (bool, error) {
+	revoked := false
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var matches int
+		err := tx.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM api_keys WHERE name = ? AND id <> ? AND revoked_at = ''", idOrName, idOrName,
+		).Scan(&matches)
+		if err != nil {
+			return err
+		}
+		if matches > 1 {
+			return ErrAmbiguousKeyName
+		}
+		before, err := scanAPIKey(tx.QueryRowContext(ctx,
+			"SELECT "+apiKeyColumns+" FROM api_keys WHERE (id = ? OR name = ?) AND revoked_at = '' ORDER BY id = ? DESC LIMIT 1",
+			idOrName, idOrName, idOrName,
+		))
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		after := before
+		after.RevokedAt = time.Now().UTC().Format(time.RFC3339)
+		if _, err := tx.ExecContext(ctx, "UPDATE api_keys SET revoked_at = ? WHERE id = ?", after.RevokedAt, before.ID); err != nil {
+			return err
+		}
+		revoked = true
+		return recordAudit(ctx, tx, model.AuditUpdate, auditAPIKey, before.ID, before, after)
+	})
+	return revoked, err
+}
+
+// AuthenticateAPIKey returns the live key whose secret this is, or nil, nil
+// if there is none, and records that it was used.
+func (s *Store) AuthenticateAPIKey(ctx context.Context, secret string) (*model.APIKey, error) {
+	if !strings.HasPrefix(secret, apiKeySecretPrefix) {
+		return nil, nil
+	}
+	k, err := scanAPIKey(s.db.QueryRowContext(ctx,
+		"SELECT "+apiKeyColumns+" FROM api_keys WHERE key_hash = ? AND revoked_at = ''", hashSecret(secret),
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if k.LastUsedAt < now.Add(-apiKeyTouchInterval).Format(time.RFC3339) {
+		k.LastUsedAt = now.Format(time.RFC3339)
+		if _, err := s.db.ExecContext(ctx, "UPDATE api_keys SET last_used_at = ? WHERE id = ?", k.LastUsedAt, k.ID); err != nil {
+			return nil, err
+		}
+	}
+	return &k, nil
+}
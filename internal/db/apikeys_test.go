@@ -0,0 +1,81 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestAPIKeyLifecycle(t *testing.T) {
+	store := setupFileStore(t)
+
+	key, secret, err := store.CreateAPIKey(ctx, "u1", "laptop", model.ScopeRead)
+	if err != nil {
+		t.Fatalf("CreateAPIKey failed: %v", err)
+	}
+	if !strings.HasPrefix(secret, "jht_") || !strings.HasPrefix(secret, key.Prefix) {
+		t.Fatalf("unexpected secret %q for prefix %q", secret, key.Prefix)
+	}
+	if _, _, err := store.CreateAPIKey(ctx, "u1", "laptop", model.ScopeReadWrite); err == nil {
+		t.Fatal("expected duplicate name to be rejected")
+	}
+
+	var stored string
+	store.db.QueryRow("SELECT key_hash FROM api_keys WHERE id = ?", key.ID).Scan(&stored)
+	if stored == "" || strings.Contains(stored, secret[4:]) {
+		t.Fatalf("expected only a hash of the secret stored, got %q", stored)
+	}
+
+	got, err := store.AuthenticateAPIKey(ctx, secret)
+	if err != nil || got == nil || got.Name != "laptop" || got.UserID != "u1" || got.LastUsedAt == "" {
+		t.Fatalf("expected key authenticated and marked used, got %+v (%v)", got, err)
+	}
+	for _, bad := range []string{"", "jht_nope", secret + "x", strings.TrimPrefix(secret, "jht_")} {
+		if got, _ := store.AuthenticateAPIKey(ctx, bad); got != nil {
+			t.Fatalf("expected %q rejected", bad)
+		}
+	}
+
+	if revoked, err := store.RevokeAPIKey(ctx, "laptop"); err != nil || !revoked {
+		t.Fatalf("expected revoke by name, got %v (%v)", revoked, err)
+	}
+	if revoked, _ := store.RevokeAPIKey(ctx, key.ID); revoked {
+		t.Fatal("expected revoking twice to report not found")
+	}
+	if got, _ := store.AuthenticateAPIKey(ctx, secret); got != nil {
+		t.Fatal("expected revoked key rejected")
+	}
+
+	keys, _ := store.ListAPIKeys(ctx)
+	if len(keys) != 1 || keys[0].RevokedAt == "" || keys[0].LastUsedAt == "" {
+		t.Fatalf("expected the revoked key listed, got %+v", keys)
+	}
+}
+
+func TestAPIKeyNamesPerUser(t *testing.T) {
+	store := setupFileStore(t)
+
+	k1, _, err := store.CreateAPIKey(ctx, "u1", "laptop", model.ScopeRead)
+	if err != nil {
+		t.Fatalf("CreateAPIKey failed: %v", err)
+	}
+	k2, _, err := store.CreateAPIKey(ctx, "u2", "laptop", model.ScopeRead)
+	if err != nil {
+		t.Fatalf("expected another user to reuse the name, got %v", err)
+	}
+	if _, err := store.db.Exec("UPDATE api_keys SET user_id = 'u1' WHERE id = ?", k2.ID); err == nil {
+		t.Fatal("expected the schema to reject a duplicate name for one user")
+	}
+
+	if _, err := store.RevokeAPIKey(ctx, "laptop"); !errors.Is(err, ErrAmbiguousKeyName) {
+		t.Fatalf("expected ErrAmbiguousKeyName, got %v", err)
+	}
+	if revoked, err := store.RevokeAPIKey(ctx, k1.ID); err != nil || !revoked {
+		t.Fatalf("expected revoke by id, got %v (%v)", revoked, err)
+	}
+	if revoked, err := store.RevokeAPIKey(ctx, "laptop"); err != nil || !revoked {
+		t.Fatalf("expected revoke by the now unique name, got %v (%v)", revoked, err)
+	}
+}
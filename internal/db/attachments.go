@@ -0,0 +1,191 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+const attachmentColumns = "id, application_id, kind, filename, content_type, size, sha256, created_at"
+
+func scanAttachment(row scanner, extra ...any) (model.Attachment, error) {
+	var a model.Attachment
+	dest := []any{&a.ID, &a.ApplicationID, &a.Kind, &a.Filename, &a.ContentType, &a.Size, &a.SHA256, &a.CreatedAt}
+	err := row.Scan(append(dest, extra...)...)
+	return a, err
+}
+
+// ListAttachments returns an application's attachments, newest first.
+func (s *Store) ListAttachments(ctx context.Context, appID string) ([]model.Attachment, error) {
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+attachmentColumns+" FROM attachments WHERE application_id = ?"+owned+" ORDER BY created_at DESC, id",
+		append([]interface{}{appID}, ownerArgs...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []model.Attachment{}
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, a)
+	}
+	return attachments, rows.Err()
+}
+
+// GetAttachment returns one attachment of an application. Returns nil, nil
+// if it does not exist or belongs to another application.
+func (s *Store) GetAttachment(ctx context.Context, appID, id string) (*model.Attachment, error) {
+	a, err := findAttachment(ctx, s.db, appID, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// findAttachment reads an attachment of appID visible in ctx. Returns
+// sql.ErrNoRows if there is none.
+func findAttachment(ctx context.Context, q rowQueryer, appID, id string) (model.Attachment, error) {
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	return scanAttachment(q.QueryRowContext(ctx,
+		"SELECT "+attachmentColumns+" FROM attachments WHERE application_id = ? AND id = ?"+owned,
+		append([]interface{}{appID, id}, ownerArgs...)...,
+	))
+}
+
+// AttachmentContent returns an attachment's metadata and file content.
+// Returns nil, nil, nil if it does not exist.
+func (s *Store) AttachmentContent(ctx context.Context, appID, id string) (*model.Attachment, []byte, error) {
+	var data []byte
+	owned, ownerArgs := ownedBy(ctx, "a.owner_id")
+	a, err := scanAttachment(s.db.QueryRowContext(ctx,
+		"SELECT "+prefixColumns("a", attachmentColumns)+", b.data"+
+			" FROM attachments a JOIN attachment_blobs b ON b.sha256 = a.sha256"+
+			" WHERE a.application_id = ? AND a.id = ?"+owned,
+		append([]interface{}{appID, id}, ownerArgs...)...,
+	), &data)
+	if err == sql.ErrNoRows {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return &a, data, nil
+}
+
+// CreateAttachment stores data as an attachment of att.ApplicationID, using
+// att's Kind, Filename and ContentType. The content is kept once per SHA256
+// across all applications. Uploading content the application already has
+// under the same kind returns the existing attachment with created false.
+func (s *Store) CreateAttachment(ctx context.Context, att model.Attachment, data []byte) (*model.Attachment, bool, error) {
+	sum := sha256.Sum256(data)
+	att.SHA256 = hex.EncodeToString(sum[:])
+	att.Size = int64(len(data))
+	att.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	existing, err := scanAttachment(tx.QueryRowContext(ctx,
+		"SELECT "+attachmentColumns+" FROM attachments WHERE application_id = ? AND sha256 = ? AND kind = ?",
+		att.ApplicationID, att.SHA256, att.Kind,
+	))
+	if err == nil {
+		return &existing, false, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, false, err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT OR IGNORE INTO attachment_blobs (sha256, size, data, created_at) VALUES (?, ?, ?, ?)",
+		att.SHA256, att.Size, data, att.CreatedAt,
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("storing content: %w", err)
+	}
+
+	att.ID = generateID()
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO attachments ("+attachmentColumns+", owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, "+appOwner+")",
+		att.ID, att.ApplicationID, att.Kind, att.Filename, att.ContentType, att.Size, att.SHA256, att.CreatedAt, att.ApplicationID,
+	)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := recordAudit(ctx, tx, model.AuditCreate, auditAttachment, att.ID, nil, att); err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("committing transaction: %w", err)
+	}
+	return &att, true, nil
+}
+
+// DeleteAttachment removes an attachment. The attachments_ad trigger drops
+// the stored content once no attachment refers to it, which also covers
+// attachments removed by deleting their application.
+func (s *Store) DeleteAttachment(ctx context.Context, appID, id string) (bool, error) {
+	deleted := false
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		existing, err := findAttachment(ctx, tx, appID, id)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM attachments WHERE id = ?", id); err != nil {
+			return err
+		}
+		deleted = true
+		return recordAudit(ctx, tx, model.AuditDelete, auditAttachment, id, existing, nil)
+	})
+	return deleted, err
+}
+
+// AttachmentUses returns every attachment with the given content hash and the
+// live application it belongs to, oldest first. Content shared with another
+// owner is stored once but never shows up in their results.
+func (s *Store) AttachmentUses(ctx context.Context, sha string) ([]model.AttachmentUse, error) {
+	owned, ownerArgs := ownedBy(ctx, "t.owner_id")
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+prefixColumns("t", attachmentColumns)+", a.company, a.role, a.status"+
+			" FROM attachments t JOIN applications a ON a.id = t.application_id"+
+			" WHERE t.sha256 = ? AND a.deleted_at = ''"+owned+
+			" ORDER BY t.created_at, t.id",
+		append([]interface{}{sha}, ownerArgs...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	uses := []model.AttachmentUse{}
+	for rows.Next() {
+		var u model.AttachmentUse
+		u.Attachment, err = scanAttachment(rows, &u.Company, &u.Role, &u.Status)
+		if err != nil {
+			return nil, err
+		}
+		uses = append(uses, u)
+	}
+	return uses, rows.Err()
+}
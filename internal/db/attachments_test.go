@@ -0,0 +1,72 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func countBlobs(t *testing.T, store *Store) int {
+	t.Helper()
+	var n int
+	if err := store.db.QueryRow("SELECT COUNT(*) FROM attachment_blobs").Scan(&n); err != nil {
+		t.Fatalf("counting blobs: %v", err)
+	}
+	return n
+}
+
+func TestAttachmentDedupeAndCleanup(t *testing.T) {
+	store := setupFileStore(t)
+	first := createTestApp(t, store)
+	second := createTestApp(t, store)
+	resume := []byte("%PDF-1.7 tailored resume")
+
+	att, created, err := store.CreateAttachment(ctx, model.Attachment{
+		ApplicationID: first.ID, Kind: "resume", Filename: "resume-v3.pdf", ContentType: "application/pdf",
+	}, resume)
+	if err != nil || !created {
+		t.Fatalf("CreateAttachment failed: %v (created=%v)", err, created)
+	}
+	if len(att.SHA256) != 64 || att.Size != int64(len(resume)) {
+		t.Fatalf("expected hash and size set, got %+v", att)
+	}
+
+	again, created, err := store.CreateAttachment(ctx, model.Attachment{
+		ApplicationID: first.ID, Kind: "resume", Filename: "renamed.pdf", ContentType: "application/pdf",
+	}, resume)
+	if err != nil || created || again.ID != att.ID {
+		t.Fatalf("expected existing attachment for duplicate upload, got %+v created=%v err=%v", again, created, err)
+	}
+	if _, _, err := store.CreateAttachment(ctx, model.Attachment{
+		ApplicationID: second.ID, Kind: "resume", Filename: "resume-v3.pdf", ContentType: "application/pdf",
+	}, resume); err != nil {
+		t.Fatalf("CreateAttachment failed: %v", err)
+	}
+	if n := countBlobs(t, store); n != 1 {
+		t.Fatalf("expected content stored once, got %d blobs", n)
+	}
+
+	uses, err := store.AttachmentUses(ctx, att.SHA256)
+	if err != nil || len(uses) != 2 || uses[0].ApplicationID == uses[1].ApplicationID || uses[0].Company != "TestCo" {
+		t.Fatalf("expected both applications listed, got %+v (%v)", uses, err)
+	}
+
+	got, data, err := store.AttachmentContent(ctx, first.ID, att.ID)
+	if err != nil || got == nil || string(data) != string(resume) {
+		t.Fatalf("unexpected content: %+v %q %v", got, data, err)
+	}
+	if got, _, _ := store.AttachmentContent(ctx, second.ID, att.ID); got != nil {
+		t.Fatalf("expected attachment scoped to its application")
+	}
+
+	store.Delete(ctx, first.ID)
+	store.Purge(ctx, first.ID)
+	if n := countBlobs(t, store); n != 1 {
+		t.Fatalf("expected shared content kept while still referenced, got %d blobs", n)
+	}
+	store.Delete(ctx, second.ID)
+	store.Purge(ctx, second.ID)
+	if n := countBlobs(t, store); n != 0 {
+		t.Fatalf("expected content removed with the last application, got %d blobs", n)
+	}
+}
@@ -0,0 +1,214 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// Entity types recorded in the audit log.
+const (
+	auditApplication = "application"
+	auditStage       = "stage"
+	auditContact     = "contact"
+	auditInterview   = "interview"
+	auditReminder    = "reminder"
+	auditTag         = "tag"
+	auditAttachment  = "attachment"
+	auditAPIKey      = "api_key"
+	auditShare       = "share"
+)
+
+// SystemActor is recorded for changes made without AuditInfo in the context,
+// such as those from background jobs.
+const SystemActor = "system"
+
+// AuditInfo identifies who made a change and the request it came from.
+type AuditInfo struct {
+	Actor     string
+	RequestID string
+}
+
+type auditInfoKey struct{}
+
+// WithAuditInfo returns a context whose store mutations are attributed to
+// info.
+func WithAuditInfo(ctx context.Context, info AuditInfo) context.Context {
+	return context.WithValue(ctx, auditInfoKey{}, info)
+}
+
+// AuditInfoFrom returns the AuditInfo set on ctx, attributing changes to
+// SystemActor if there is none.
+func AuditInfoFrom(ctx context.Context) AuditInfo {
+	info, _ := ctx.Value(auditInfoKey{}).(AuditInfo)
+	if info.Actor == "" {
+		info.Actor = SystemActor
+	}
+	return info
+}
+
+// auditIgnoredFields change on every write and would only add noise to diffs.
+var auditIgnoredFields = map[string]bool{"updated_at": true, "version": true, "snippet": true}
+
+// recordAudit appends an entry for a change to one entity inside tx. before is
+// nil for a create and after is nil for a delete; otherwise only the fields
+// that differ are kept, and nothing is recorded if none do.
+func recordAudit(ctx context.Context, tx *sql.Tx, action, entityType, entityID string, before, after any) error {
+	b, err := auditFields(before)
+	if err != nil {
+		return err
+	}
+	a, err := auditFields(after)
+	if err != nil {
+		return err
+	}
+	if b != nil && a != nil {
+		b, a = auditDiff(b, a)
+		if len(b) == 0 && len(a) == 0 {
+			return nil
+		}
+	}
+
+	beforeJSON, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	info := AuditInfoFrom(ctx)
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO audit_log (created_at, owner_id, actor, request_id, action, entity_type, entity_id, before, after) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		time.Now().UTC().Format(time.RFC3339), ownerOf(ctx), info.Actor, info.RequestID, action, entityType, entityID, string(beforeJSON), string(afterJSON),
+	)
+	if err != nil {
+		return fmt.Errorf("recording audit entry: %w", err)
+	}
+	return nil
+}
+
+// auditFields turns an entity into its JSON fields.
+func auditFields(v any) (map[string]any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	if m, ok := v.(map[string]any); ok {
+		return m, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// auditDiff keeps the fields whose values differ between before and after.
+func auditDiff(before, after map[string]any) (map[string]any, map[string]any) {
+	b, a := map[string]any{}, map[string]any{}
+	for k, v := range before {
+		if auditIgnoredFields[k] {
+			continue
+		}
+		if av, ok := after[k]; !ok || !reflect.DeepEqual(v, av) {
+			b[k] = v
+		}
+	}
+	for k, v := range after {
+		if auditIgnoredFields[k] {
+			continue
+		}
+		if bv, ok := before[k]; !ok || !reflect.DeepEqual(v, bv) {
+			a[k] = v
+		}
+	}
+	return b, a
+}
+
+// AuditFilter narrows AuditLog. Since is an RFC3339 UTC lower bound on the
+// entry time; empty fields do not filter.
+type AuditFilter struct {
+	EntityID   string
+	EntityType string
+	Actor      string
+	Since      string
+	Limit      int
+}
+
+// AuditLog returns matching entries, newest first. A scoped context sees
+// only changes to its owner's data.
+func (s *Store) AuditLog(ctx context.Context, f AuditFilter) ([]model.AuditEntry, error) {
+	query := "SELECT id, created_at, actor, request_id, action, entity_type, entity_id, before, after FROM audit_log"
+	var conditions []string
+	var args []interface{}
+	if owner, ok := ownerFrom(ctx); ok {
+		conditions = append(conditions, "owner_id = ?")
+		args = append(args, owner)
+	}
+	if f.EntityID != "" {
+		conditions = append(conditions, "entity_id = ?")
+		args = append(args, f.EntityID)
+	}
+	if f.EntityType != "" {
+		conditions = append(conditions, "entity_type = ?")
+		args = append(args, f.EntityType)
+	}
+	if f.Actor != "" {
+		conditions = append(conditions, "actor = ?")
+		args = append(args, f.Actor)
+	}
+	if f.Since != "" {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, f.Since)
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, f.Limit)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []model.AuditEntry{}
+	for rows.Next() {
+		var e model.AuditEntry
+		var before, after string
+		if err := rows.Scan(&e.ID, &e.At, &e.Actor, &e.RequestID, &e.Action, &e.EntityType, &e.EntityID, &before, &after); err != nil {
+			return nil, err
+		}
+		e.Before, e.After = json.RawMessage(before), json.RawMessage(after)
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// inTx runs fn in a transaction and commits if it returns nil.
+func (s *Store) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func decodeFields(t *testing.T, raw json.RawMessage) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal(raw, &m); err != nil {
+		t.Fatalf("decoding %s: %v", raw, err)
+	}
+	return m
+}
+
+func TestAuditLogRecordsDiffs(t *testing.T) {
+	store := setupFileStore(t)
+	actx := WithAuditInfo(ctx, AuditInfo{Actor: "alice", RequestID: "req-1"})
+
+	app, err := store.Create(actx, model.CreateRequest{Company: "Acme", Role: "Engineer", Status: "applied"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := store.Update(actx, app.ID, map[string]interface{}{"notes": "called"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := store.Update(actx, app.ID, map[string]interface{}{"notes": "called"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := store.Delete(actx, app.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	entries, err := store.AuditLog(ctx, AuditFilter{EntityID: app.ID, Limit: 10})
+	if err != nil {
+		t.Fatalf("AuditLog failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected create, update and delete (no-op update skipped), got %+v", entries)
+	}
+	del, upd, create := entries[0], entries[1], entries[2]
+	if create.Action != model.AuditCreate || create.Actor != "alice" || create.RequestID != "req-1" || create.EntityType != "application" {
+		t.Fatalf("unexpected create entry %+v", create)
+	}
+	if string(create.Before) != "null" || decodeFields(t, create.After)["company"] != "Acme" {
+		t.Fatalf("expected create to carry only the new record, got %s / %s", create.Before, create.After)
+	}
+
+	before, after := decodeFields(t, upd.Before), decodeFields(t, upd.After)
+	if upd.Action != model.AuditUpdate || len(before) != 1 || before["notes"] != "" || after["notes"] != "called" {
+		t.Fatalf("expected update diff of notes only, got %s / %s", upd.Before, upd.After)
+	}
+
+	if del.Action != model.AuditDelete || len(decodeFields(t, del.Before)) != 0 || decodeFields(t, del.After)["deleted_at"] == nil {
+		t.Fatalf("expected soft delete recorded as a deleted_at change, got %s / %s", del.Before, del.After)
+	}
+}
+
+func TestAuditLogFiltersAndSystemActor(t *testing.T) {
+	store := setupFileStore(t)
+	app := createTestApp(t, store)
+	contact, err := store.CreateContact(WithAuditInfo(ctx, AuditInfo{Actor: "bob"}), model.ContactRequest{Name: strPtr("Dana")})
+	if err != nil {
+		t.Fatalf("CreateContact failed: %v", err)
+	}
+
+	all, _ := store.AuditLog(ctx, AuditFilter{Limit: 10})
+	if len(all) != 2 || all[1].Actor != SystemActor {
+		t.Fatalf("expected changes without audit info attributed to %q, got %+v", SystemActor, all)
+	}
+	if got, _ := store.AuditLog(ctx, AuditFilter{Actor: "bob", Limit: 10}); len(got) != 1 || got[0].EntityID != contact.ID {
+		t.Fatalf("expected actor filter to match the contact, got %+v", got)
+	}
+	if got, _ := store.AuditLog(ctx, AuditFilter{EntityType: "application", Limit: 10}); len(got) != 1 || got[0].EntityID != app.ID {
+		t.Fatalf("expected entity type filter to match the application, got %+v", got)
+	}
+	if got, _ := store.AuditLog(ctx, AuditFilter{Since: "2100-01-01T00:00:00Z", Limit: 10}); len(got) != 0 {
+		t.Fatalf("expected no entries in the future, got %+v", got)
+	}
+	if got, _ := store.AuditLog(ctx, AuditFilter{Limit: 1}); len(got) != 1 || got[0].EntityID != contact.ID {
+		t.Fatalf("expected limit to keep the newest entry, got %+v", got)
+	}
+}
+
+func TestAuditLogIsAppendOnly(t *testing.T) {
+	store := setupFileStore(t)
+	createTestApp(t, store)
+
+	if _, err := store.db.Exec("UPDATE audit_log SET actor = 'mallory'"); err == nil {
+		t.Fatal("expected audit log update to be rejected")
+	}
+	if _, err := store.db.Exec("DELETE FROM audit_log"); err == nil {
+		t.Fatal("expected audit log delete to be rejected")
+	}
+	if entries, _ := store.AuditLog(ctx, AuditFilter{Limit: 10}); len(entries) != 1 || entries[0].Actor != SystemActor {
+		t.Fatalf("expected the entry untouched, got %+v", entries)
+	}
+}
@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// Outcomes of a bulk action on one application.
+const (
+	BulkUpdated   = "updated"
+	BulkDeleted   = "deleted"
+	BulkUnchanged = "unchanged"
+	BulkNotFound  = "not_found"
+	BulkFailed    = "failed"
+)
+
+// ErrTooManyItems is returned by Bulk when a query matches more than
+// model.MaxBulkItems applications.
+var ErrTooManyItems = fmt.Errorf("bulk actions are limited to %d applications; narrow the query", model.MaxBulkItems)
+
+// BulkAction is what Bulk does to each application.
+type BulkAction struct {
+	// Kind is one of the model.Bulk* actions.
+	Kind string
+	// Fields are the columns set by set_status and set_field.
+	Fields map[string]interface{}
+	// Tags, already normalized, are added by add_tags.
+	Tags []string
+	// Options carries the status note and transition override for
+	// set_status. IfVersions is ignored.
+	Options UpdateOptions
+}
+
+// BulkResult is the outcome for one application.
+type BulkResult struct {
+	ID      string
+	Outcome string
+	// Error says why the outcome is BulkFailed.
+	Error string
+}
+
+// bulkItemError fails one application without failing the batch.
+type bulkItemError struct{ message string }
+
+func (e *bulkItemError) Error() string { return e.message }
+
+// errDryRun rolls back a dry run once its results are in.
+var errDryRun = errors.New("dry run")
+
+// Bulk applies action to the applications with the given IDs, in order, or
+// when ids is nil to those matching filter (ignoring its limit, offset and
+// sort), oldest first. Everything runs in one transaction. An application
+// that cannot take the action, such as one whose status may not move to the
+// new one, is reported as BulkFailed and left as it was while the rest go
+// ahead. With dryRun the transaction is rolled back, so the results say what
+// would happen.
+func (s *Store) Bulk(ctx context.Context, ids []string, filter model.ListOptions, action BulkAction, dryRun bool) ([]BulkResult, error) {
+	action.Options.IfVersions = nil
+	var results []BulkResult
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		if ids == nil {
+			var err error
+			if ids, err = matchingIDs(ctx, tx, filter); err != nil {
+				return err
+			}
+		}
+
+		results = make([]BulkResult, 0, len(ids))
+		for _, id := range ids {
+			// A savepoint per application undoes whatever a failed one
+			// wrote before failing.
+			if _, err := tx.ExecContext(ctx, "SAVEPOINT bulk_item"); err != nil {
+				return err
+			}
+			outcome, err := s.bulkApply(ctx, tx, id, action)
+			var itemErr *bulkItemError
+			var transitionErr *model.TransitionError
+			if errors.As(err, &itemErr) || errors.As(err, &transitionErr) {
+				if _, err := tx.ExecContext(ctx, "ROLLBACK TO bulk_item"); err != nil {
+					return err
+				}
+				results = append(results, BulkResult{ID: id, Outcome: BulkFailed, Error: err.Error()})
+			} else if err != nil {
+				return err
+			} else {
+				results = append(results, BulkResult{ID: id, Outcome: outcome})
+			}
+			if _, err := tx.ExecContext(ctx, "RELEASE bulk_item"); err != nil {
+				return err
+			}
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && err != errDryRun {
+		return nil, err
+	}
+	return results, nil
+}
+
+// matchingIDs returns the IDs of live applications matching filter, oldest
+// first, or ErrTooManyItems.
+func matchingIDs(ctx context.Context, tx *sql.Tx, filter model.ListOptions) ([]string, error) {
+	if err := checkSearch(ctx, tx, filter); err != nil {
+		return nil, err
+	}
+	where, args := buildWhere(ctx, filter)
+	rows, err := tx.QueryContext(ctx,
+		"SELECT id FROM applications "+where+" ORDER BY created_at, rowid LIMIT ?",
+		append(args, model.MaxBulkItems+1)...,
+	)
+	if err != nil {
+		return nil, searchError(filter, err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) > model.MaxBulkItems {
+		return nil, ErrTooManyItems
+	}
+	return ids, nil
+}
+
+func (s *Store) bulkApply(ctx context.Context, tx *sql.Tx, id string, action BulkAction) (string, error) {
+	switch action.Kind {
+	case model.BulkDelete:
+		deleted, err := deleteTx(ctx, tx, id)
+		if err != nil || !deleted {
+			return BulkNotFound, err
+		}
+		return BulkDeleted, nil
+
+	case model.BulkAddTags:
+		existing, err := liveApplication(ctx, tx, id)
+		if err == sql.ErrNoRows {
+			return BulkNotFound, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if !slices.ContainsFunc(action.Tags, func(tag string) bool { return !slices.Contains(existing.Tags, tag) }) {
+			return BulkUnchanged, nil
+		}
+		_, err = changeTagsTx(ctx, tx, id, func(tx *sql.Tx) error {
+			return addTags(ctx, tx, id, action.Tags, time.Now().UTC().Format(time.RFC3339))
+		})
+		return BulkUpdated, err
+
+	case model.BulkSetStatus, model.BulkSetField:
+		var before int
+		updated, err := s.updateTx(ctx, tx, id, action.Options, func(existing model.Application) (map[string]interface{}, []string, error) {
+			before = existing.Version
+			return changedFields(existing, action.Fields)
+		})
+		if err != nil || updated == nil {
+			return BulkNotFound, err
+		}
+		if updated.Version == before {
+			return BulkUnchanged, nil
+		}
+		return BulkUpdated, nil
+	}
+	return "", fmt.Errorf("unknown bulk action %q", action.Kind)
+}
+
+// changedFields drops the fields app already has, so re-applying a bulk
+// action leaves it alone, and checks the salary range a salary change would
+// leave.
+func changedFields(app model.Application, fields map[string]interface{}) (map[string]interface{}, []string, error) {
+	current := map[string]interface{}{
+		"company":    app.Company,
+		"role":       app.Role,
+		"url":        app.URL,
+		"salary_min": app.SalaryMin,
+		"salary_max": app.SalaryMax,
+		"location":   app.Location,
+		"status":     app.Status,
+		"notes":      app.Notes,
+		"applied_at": app.AppliedAt,
+	}
+	changed := map[string]interface{}{}
+	for col, value := range fields {
+		if current[col] != value {
+			changed[col] = value
+			current[col] = value
+		}
+	}
+	_, setsMin := changed["salary_min"]
+	_, setsMax := changed["salary_max"]
+	salaryMin, salaryMax := current["salary_min"].(int), current["salary_max"].(int)
+	if (setsMin || setsMax) && salaryMin != 0 && salaryMax != 0 && salaryMin > salaryMax {
+		return nil, nil, &bulkItemError{"salary_min cannot be greater than salary_max"}
+	}
+	return changed, nil, nil
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func outcomes(results []BulkResult) string {
+	var out []string
+	for _, r := range results {
+		out = append(out, r.Outcome)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestBulkSetStatus(t *testing.T) {
+	store := setupTestStore(t)
+	applied, _ := store.Create(ctx, model.CreateRequest{Company: "A", Role: "Eng", Status: "applied"})
+	rejected, _ := store.Create(ctx, model.CreateRequest{Company: "B", Role: "Eng", Status: "rejected"})
+	withdrawn, _ := store.Create(ctx, model.CreateRequest{Company: "C", Role: "Eng", Status: "withdrawn"})
+
+	action := BulkAction{Kind: model.BulkSetStatus, Fields: map[string]interface{}{"status": "withdrawn"}, Options: UpdateOptions{StatusNote: "hiring freeze"}}
+	ids := []string{applied.ID, rejected.ID, withdrawn.ID, "deadbeef"}
+
+	results, err := store.Bulk(ctx, ids, model.ListOptions{}, action, true)
+	if err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if got := outcomes(results); got != "updated,failed,unchanged,not_found" {
+		t.Fatalf("unexpected dry run outcomes %s", got)
+	}
+	if !strings.Contains(results[1].Error, "rejected") {
+		t.Fatalf("expected the transition error, got %q", results[1].Error)
+	}
+	if got, _ := store.Get(ctx, applied.ID); got.Status != "applied" {
+		t.Fatalf("expected a dry run to change nothing, got %s", got.Status)
+	}
+
+	results, err = store.Bulk(ctx, ids, model.ListOptions{}, action, false)
+	if err != nil || outcomes(results) != "updated,failed,unchanged,not_found" {
+		t.Fatalf("unexpected outcomes %s (%v)", outcomes(results), err)
+	}
+	if got, _ := store.Get(ctx, applied.ID); got.Status != "withdrawn" || got.Version != 2 {
+		t.Fatalf("expected applied to be withdrawn, got %+v", got)
+	}
+	history, _ := store.History(ctx, applied.ID)
+	if last := history[len(history)-1]; last.ToStatus != "withdrawn" || last.Note != "hiring freeze" {
+		t.Fatalf("expected the change in the history, got %+v", last)
+	}
+	if got, _ := store.Get(ctx, rejected.ID); got.Status != "rejected" || got.Version != 1 {
+		t.Fatalf("expected the failed application untouched, got %+v", got)
+	}
+}
+
+func TestBulkByFilter(t *testing.T) {
+	store := setupTestStore(t)
+	var ids []string
+	for _, company := range []string{"Acme", "Acme Labs", "Globex"} {
+		app, _ := store.Create(ctx, model.CreateRequest{Company: company, Role: "Eng", SalaryMax: intPtr(100)})
+		ids = append(ids, app.ID)
+	}
+
+	results, err := store.Bulk(ctx, nil, model.ListOptions{Company: "acme"}, BulkAction{Kind: model.BulkAddTags, Tags: []string{"frozen"}}, false)
+	if err != nil || outcomes(results) != "updated,updated" || results[0].ID != ids[0] {
+		t.Fatalf("expected both Acme applications tagged in creation order, got %+v (%v)", results, err)
+	}
+	results, _ = store.Bulk(ctx, nil, model.ListOptions{Company: "acme"}, BulkAction{Kind: model.BulkAddTags, Tags: []string{"frozen"}}, false)
+	if outcomes(results) != "unchanged,unchanged" {
+		t.Fatalf("expected re-tagging to change nothing, got %s", outcomes(results))
+	}
+
+	// A salary_min above an application's salary_max fails just that one.
+	store.Update(ctx, ids[1], map[string]interface{}{"salary_max": 0})
+	results, _ = store.Bulk(ctx, nil, model.ListOptions{Tags: []string{"frozen"}}, BulkAction{Kind: model.BulkSetField, Fields: map[string]interface{}{"salary_min": 150}}, false)
+	if outcomes(results) != "failed,updated" {
+		t.Fatalf("expected the salary range checked per application, got %+v", results)
+	}
+
+	results, err = store.Bulk(ctx, nil, model.ListOptions{Tags: []string{"frozen"}}, BulkAction{Kind: model.BulkDelete}, false)
+	if err != nil || outcomes(results) != "deleted,deleted" {
+		t.Fatalf("expected both deleted, got %s (%v)", outcomes(results), err)
+	}
+	if count, _ := store.Count(ctx, model.ListOptions{}); count != 1 {
+		t.Fatalf("expected one live application, got %d", count)
+	}
+	if results, _ := store.Bulk(ctx, ids[:1], model.ListOptions{}, BulkAction{Kind: model.BulkDelete}, false); outcomes(results) != "not_found" {
+		t.Fatalf("expected a trashed application not found, got %s", outcomes(results))
+	}
+}
+
+func TestBulkOwnerScoped(t *testing.T) {
+	store := setupTestStore(t)
+	ann, _ := store.CreateUser(ctx, "ann@example.com", "correct horse")
+	bob, _ := store.CreateUser(ctx, "bob@example.com", "correct horse")
+	annCtx, bobCtx := WithOwner(ctx, ann.ID), WithOwner(ctx, bob.ID)
+	app, _ := store.Create(annCtx, model.CreateRequest{Company: "Acme", Role: "Eng"})
+
+	results, err := store.Bulk(bobCtx, []string{app.ID}, model.ListOptions{}, BulkAction{Kind: model.BulkDelete}, false)
+	if err != nil || outcomes(results) != "not_found" {
+		t.Fatalf("expected another user's application not found, got %s (%v)", outcomes(results), err)
+	}
+	if results, _ := store.Bulk(bobCtx, nil, model.ListOptions{}, BulkAction{Kind: model.BulkDelete}, false); len(results) != 0 {
+		t.Fatalf("expected a filter to match none of another user's applications, got %+v", results)
+	}
+}
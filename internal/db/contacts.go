@@ -0,0 +1,336 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+const contactColumns = "id, name, email, phone, linkedin_url, company, role, created_at, updated_at"
+
+func scanContact(row scanner, extra ...any) (model.Contact, error) {
+	var c model.Contact
+	dest := []any{&c.ID, &c.Name, &c.Email, &c.Phone, &c.LinkedInURL, &c.Company, &c.Role, &c.CreatedAt, &c.UpdatedAt}
+	err := row.Scan(append(dest, extra...)...)
+	return c, err
+}
+
+// ContactFilter narrows ListContacts. Company matches a contact's own company
+// or the company of any application it is linked to.
+type ContactFilter struct {
+	Name    string
+	Company string
+}
+
+// ListContacts returns contacts ordered by name.
+func (s *Store) ListContacts(ctx context.Context, f ContactFilter) ([]model.Contact, error) {
+	query := "SELECT " + contactColumns + " FROM contacts"
+	var conditions []string
+	var args []interface{}
+
+	if owner, ok := ownerFrom(ctx); ok {
+		conditions = append(conditions, "owner_id = ?")
+		args = append(args, owner)
+	}
+	if f.Name != "" {
+		conditions = append(conditions, "name LIKE ? COLLATE NOCASE")
+		args = append(args, "%"+f.Name+"%")
+	}
+	if f.Company != "" {
+		conditions = append(conditions, `(company LIKE ? COLLATE NOCASE OR id IN (
+			SELECT ac.contact_id FROM application_contacts ac
+			JOIN applications a ON a.id = ac.application_id
+			WHERE a.deleted_at = '' AND a.company LIKE ? COLLATE NOCASE))`)
+		args = append(args, "%"+f.Company+"%", "%"+f.Company+"%")
+	}
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY name COLLATE NOCASE, id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contacts := []model.Contact{}
+	for rows.Next() {
+		c, err := scanContact(rows)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, c)
+	}
+	return contacts, rows.Err()
+}
+
+func (s *Store) GetContact(ctx context.Context, id string) (*model.Contact, error) {
+	c, err := findContact(ctx, s.db, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// findContact reads a contact visible in ctx. Returns sql.ErrNoRows if there
+// is none.
+func findContact(ctx context.Context, q rowQueryer, id string) (model.Contact, error) {
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	return scanContact(q.QueryRowContext(ctx,
+		"SELECT "+contactColumns+" FROM contacts WHERE id = ?"+owned,
+		append([]interface{}{id}, ownerArgs...)...,
+	))
+}
+
+func (s *Store) CreateContact(ctx context.Context, req model.ContactRequest) (*model.Contact, error) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	id := generateID()
+
+	var contact model.Contact
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO contacts ("+contactColumns+", owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			id, strings.TrimSpace(*req.Name), deref(req.Email), deref(req.Phone), deref(req.LinkedInURL), deref(req.Company), deref(req.Role), now, now, ownerOf(ctx),
+		)
+		if err != nil {
+			return err
+		}
+		contact, err = scanContact(tx.QueryRowContext(ctx, "SELECT "+contactColumns+" FROM contacts WHERE id = ?", id))
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, model.AuditCreate, auditContact, id, nil, contact)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+// UpdateContact applies the non-nil fields of req. Returns nil, nil if the
+// contact does not exist.
+func (s *Store) UpdateContact(ctx context.Context, id string, req model.ContactRequest) (*model.Contact, error) {
+	var setClauses []string
+	var args []interface{}
+	for col, val := range map[string]*string{
+		"name":         req.Name,
+		"email":        req.Email,
+		"phone":        req.Phone,
+		"linkedin_url": req.LinkedInURL,
+		"company":      req.Company,
+		"role":         req.Role,
+	} {
+		if val != nil {
+			setClauses = append(setClauses, col+" = ?")
+			args = append(args, strings.TrimSpace(*val))
+		}
+	}
+	if len(setClauses) == 0 {
+		return s.GetContact(ctx, id)
+	}
+
+	setClauses = append(setClauses, "updated_at = ?")
+	args = append(args, time.Now().UTC().Format(time.RFC3339), id)
+
+	var updated *model.Contact
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		existing, err := findContact(ctx, tx, id)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("UPDATE contacts SET %s WHERE id = ?", strings.Join(setClauses, ", ")), args...); err != nil {
+			return err
+		}
+		c, err := scanContact(tx.QueryRowContext(ctx, "SELECT "+contactColumns+" FROM contacts WHERE id = ?", id))
+		if err != nil {
+			return err
+		}
+		updated = &c
+		return recordAudit(ctx, tx, model.AuditUpdate, auditContact, id, existing, c)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteContact removes a contact and all of its application links.
+func (s *Store) DeleteContact(ctx context.Context, id string) (bool, error) {
+	deleted := false
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		existing, err := findContact(ctx, tx, id)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM contacts WHERE id = ?", id); err != nil {
+			return err
+		}
+		deleted = true
+		return recordAudit(ctx, tx, model.AuditDelete, auditContact, id, existing, nil)
+	})
+	return deleted, err
+}
+
+// LinkContact links a contact to an application with the given relationship.
+// created is false if that exact link already existed. Both records must
+// exist; callers check first so they can report which one is missing.
+func (s *Store) LinkContact(ctx context.Context, appID, contactID, relationship string) (created bool, err error) {
+	err = s.inTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			"INSERT OR IGNORE INTO application_contacts (application_id, contact_id, relationship, created_at, owner_id) VALUES (?, ?, ?, ?, "+appOwner+")",
+			appID, contactID, relationship, time.Now().UTC().Format(time.RFC3339), appID,
+		)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil || n == 0 {
+			return err
+		}
+		created = true
+		return recordAudit(ctx, tx, model.AuditLink, auditApplication, appID, nil, contactLink(contactID, relationship))
+	})
+	return created, err
+}
+
+// contactLink is how a contact link appears in the audit log of its
+// application.
+func contactLink(contactID, relationship string) map[string]any {
+	return map[string]any{"contact_id": contactID, "relationship": relationship}
+}
+
+// UnlinkContact removes a contact's link to an application. An empty
+// relationship removes every link between the two.
+func (s *Store) UnlinkContact(ctx context.Context, appID, contactID, relationship string) (bool, error) {
+	where := " WHERE application_id = ? AND contact_id = ?"
+	args := []interface{}{appID, contactID}
+	if relationship != "" {
+		where += " AND relationship = ?"
+		args = append(args, relationship)
+	}
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	where += owned
+	args = append(args, ownerArgs...)
+
+	removed := false
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, "SELECT relationship FROM application_contacts"+where+" ORDER BY relationship", args...)
+		if err != nil {
+			return err
+		}
+		var relationships []string
+		for rows.Next() {
+			var rel string
+			if err := rows.Scan(&rel); err != nil {
+				rows.Close()
+				return err
+			}
+			relationships = append(relationships, rel)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM application_contacts"+where, args...); err != nil {
+			return err
+		}
+		for _, rel := range relationships {
+			if err := recordAudit(ctx, tx, model.AuditUnlink, auditApplication, appID, contactLink(contactID, rel), nil); err != nil {
+				return err
+			}
+		}
+		removed = len(relationships) > 0
+		return nil
+	})
+	return removed, err
+}
+
+// ApplicationContacts returns the contacts linked to an application, one
+// entry per relationship, ordered by name.
+func (s *Store) ApplicationContacts(ctx context.Context, appID string) ([]model.ApplicationContact, error) {
+	owned, ownerArgs := ownedBy(ctx, "ac.owner_id")
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+prefixColumns("c", contactColumns)+`, ac.relationship, ac.created_at
+		FROM application_contacts ac
+		JOIN contacts c ON c.id = ac.contact_id
+		WHERE ac.application_id = ?`+owned+`
+		ORDER BY c.name COLLATE NOCASE, c.id, ac.relationship`,
+		append([]interface{}{appID}, ownerArgs...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contacts := []model.ApplicationContact{}
+	for rows.Next() {
+		var ac model.ApplicationContact
+		ac.Contact, err = scanContact(rows, &ac.Relationship, &ac.LinkedAt)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, ac)
+	}
+	return contacts, rows.Err()
+}
+
+// ContactApplications returns the live applications a contact is linked to,
+// most recently updated first.
+func (s *Store) ContactApplications(ctx context.Context, contactID string) ([]model.ContactApplication, error) {
+	owned, ownerArgs := ownedBy(ctx, "ac.owner_id")
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+applicationColumns+`, ac.relationship, ac.created_at
+		FROM application_contacts ac
+		JOIN applications ON applications.id = ac.application_id
+		WHERE ac.contact_id = ? AND applications.deleted_at = ''`+owned+`
+		ORDER BY applications.updated_at DESC, applications.id, ac.relationship`,
+		append([]interface{}{contactID}, ownerArgs...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	apps := []model.ContactApplication{}
+	for rows.Next() {
+		var ca model.ContactApplication
+		ca.Application, err = scanApplication(rows, &ca.Relationship, &ca.LinkedAt)
+		if err != nil {
+			return nil, err
+		}
+		apps = append(apps, ca)
+	}
+	return apps, rows.Err()
+}
+
+// prefixColumns qualifies each name in a comma-separated column list with
+// table, e.g. "id, name" -> "a.id, a.name".
+func prefixColumns(table, columns string) string {
+	cols := strings.Split(columns, ", ")
+	for i, c := range cols {
+		cols[i] = table + "." + c
+	}
+	return strings.Join(cols, ", ")
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return strings.TrimSpace(*s)
+}
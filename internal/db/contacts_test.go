@@ -0,0 +1,52 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestContactLinksCascade(t *testing.T) {
+	store := setupFileStore(t)
+	app := createTestApp(t, store)
+	contact, err := store.CreateContact(ctx, model.ContactRequest{Name: strPtr("Jane"), Email: strPtr(" jane@example.com ")})
+	if err != nil {
+		t.Fatalf("CreateContact failed: %v", err)
+	}
+	if contact.Email != "jane@example.com" {
+		t.Errorf("expected trimmed email, got %q", contact.Email)
+	}
+
+	for _, rel := range []string{"recruiter", "referrer", "recruiter"} {
+		if _, err := store.LinkContact(ctx, app.ID, contact.ID, rel); err != nil {
+			t.Fatalf("LinkContact failed: %v", err)
+		}
+	}
+	links, err := store.ApplicationContacts(ctx, app.ID)
+	if err != nil {
+		t.Fatalf("ApplicationContacts failed: %v", err)
+	}
+	if len(links) != 2 {
+		t.Fatalf("expected 2 distinct links, got %+v", links)
+	}
+
+	if _, err := store.LinkContact(ctx, app.ID, "deadbeef", "recruiter"); err == nil {
+		t.Error("expected foreign key error linking a missing contact")
+	}
+
+	if _, err := store.DeleteContact(ctx, contact.ID); err != nil {
+		t.Fatalf("DeleteContact failed: %v", err)
+	}
+	links, _ = store.ApplicationContacts(ctx, app.ID)
+	if len(links) != 0 {
+		t.Fatalf("expected links removed with contact, got %+v", links)
+	}
+}
+
+func TestUpdateContactMissing(t *testing.T) {
+	store := setupTestStore(t)
+	c, err := store.UpdateContact(ctx, "deadbeef", model.ContactRequest{Name: strPtr("X")})
+	if err != nil || c != nil {
+		t.Fatalf("expected nil, nil for missing contact, got %+v, %v", c, err)
+	}
+}
@@ -3,35 +3,75 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	_ "modernc.org/sqlite"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 
 	"github.com/shakilbd009/job-hunt-platform/internal/model"
 )
 
-const applicationColumns = "id, company, role, url, salary_min, salary_max, location, status, notes, applied_at, created_at, updated_at"
+// applicationColumns selects an application row including its tags as a JSON
+// array. The tags subquery is correlated on applications.id, so the table
+// must not be aliased in queries that use it.
+var applicationColumns = prefixColumns("applications", "id, company, role, url, salary_min, salary_max, location, status, notes, applied_at, created_at, updated_at, version, stale_at, deleted_at") +
+	", (SELECT json_group_array(tag ORDER BY tag) FROM application_tags WHERE application_id = applications.id)"
 
 type scanner interface {
 	Scan(dest ...any) error
 }
 
+// rowQueryer is satisfied by both *sql.DB and *sql.Tx.
+type rowQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 type Store struct {
-	db *sql.DB
+	db           *sql.DB
+	followUpDays int
 }
 
-func scanApplication(row scanner) (model.Application, error) {
+// scanApplication scans applicationColumns followed by any extra
+// destinations selected after them.
+func scanApplication(row scanner, extra ...any) (model.Application, error) {
 	var a model.Application
-	err := row.Scan(&a.ID, &a.Company, &a.Role, &a.URL, &a.SalaryMin, &a.SalaryMax, &a.Location, &a.Status, &a.Notes, &a.AppliedAt, &a.CreatedAt, &a.UpdatedAt)
-	return a, err
+	var tags string
+	dest := []any{&a.ID, &a.Company, &a.Role, &a.URL, &a.SalaryMin, &a.SalaryMax, &a.Location, &a.Status, &a.Notes, &a.AppliedAt, &a.CreatedAt, &a.UpdatedAt, &a.Version, &a.StaleAt, &a.DeletedAt, &tags}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return a, err
+	}
+	if err := json.Unmarshal([]byte(tags), &a.Tags); err != nil {
+		return a, fmt.Errorf("decoding tags: %w", err)
+	}
+	return a, nil
 }
 
+// NewStore opens the database at dbPath and applies any pending migrations.
 func NewStore(dbPath string) (*Store, error) {
+	s, err := Open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.MigrateUp(context.Background()); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("running migrations: %w", err)
+	}
+
+	return s, nil
+}
+
+// Open opens the database at dbPath without touching its schema. Use NewStore
+// unless you are inspecting or migrating the schema directly.
+func Open(dbPath string) (*Store, error) {
 	if dbPath != ":memory:" {
 		dir := filepath.Dir(dbPath)
 		if err := os.MkdirAll(dir, 0755); err != nil {
@@ -39,7 +79,7 @@ func NewStore(dbPath string) (*Store, error) {
 		}
 	}
 
-	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_journal_mode=WAL&_busy_timeout=5000", dbPath))
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_journal_mode=WAL&_busy_timeout=5000&_pragma=foreign_keys(1)", dbPath))
 	if err != nil {
 		return nil, fmt.Errorf("opening database: %w", err)
 	}
@@ -48,12 +88,7 @@ func NewStore(dbPath string) (*Store, error) {
 	db.SetMaxIdleConns(5)
 	db.SetConnMaxLifetime(5 * time.Minute)
 
-	if err := migrate(db); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("running migrations: %w", err)
-	}
-
-	return &Store{db: db}, nil
+	return &Store{db: db, followUpDays: DefaultFollowUpDays}, nil
 }
 
 func (s *Store) Close() error {
@@ -64,32 +99,27 @@ func (s *Store) Ping(ctx context.Context) error {
 	return s.db.PingContext(ctx)
 }
 
-func migrate(db *sql.DB) error {
-	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS applications (
-		id         TEXT PRIMARY KEY,
-		company    TEXT NOT NULL,
-		role       TEXT NOT NULL,
-		url        TEXT DEFAULT '',
-		salary_min INTEGER DEFAULT 0,
-		salary_max INTEGER DEFAULT 0,
-		location   TEXT DEFAULT '',
-		status     TEXT NOT NULL DEFAULT 'wishlist',
-		notes      TEXT DEFAULT '',
-		applied_at TEXT DEFAULT '',
-		created_at TEXT NOT NULL,
-		updated_at TEXT NOT NULL
-	)`)
-	return err
-}
-
 func generateID() string {
 	return uuid.New().String()[:8]
 }
 
-func buildWhere(opts model.ListOptions) (string, []interface{}) {
+func buildWhere(ctx context.Context, opts model.ListOptions) (string, []interface{}) {
 	var conditions []string
 	var args []interface{}
 
+	if owner, ok := ownerFrom(ctx); ok {
+		conditions = append(conditions, "applications.owner_id = ?")
+		args = append(args, owner)
+	}
+	if opts.Trashed {
+		conditions = append(conditions, "applications.deleted_at <> ''")
+	} else {
+		conditions = append(conditions, "applications.deleted_at = ''")
+	}
+	if opts.Query != "" {
+		conditions = append(conditions, "applications.search_rowid IN (SELECT rowid FROM applications_fts WHERE applications_fts MATCH ?)")
+		args = append(args, opts.Query)
+	}
 	if opts.Status != "" {
 		conditions = append(conditions, "status = ?")
 		args = append(args, opts.Status)
@@ -122,6 +152,45 @@ func buildWhere(opts model.ListOptions) (string, []interface{}) {
 		conditions = append(conditions, "salary_max <= ? AND salary_max > 0")
 		args = append(args, opts.SalaryMaxLTE)
 	}
+	// application_tags rows always share their application's owner, so the
+	// tag filters need no owner condition of their own.
+	for _, tag := range opts.Tags {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM application_tags WHERE application_id = applications.id AND tag = ?)")
+		args = append(args, tag)
+	}
+	if len(opts.TagsAny) > 0 {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM application_tags WHERE application_id = applications.id AND tag IN ("+placeholders(len(opts.TagsAny))+"))")
+		for _, tag := range opts.TagsAny {
+			args = append(args, tag)
+		}
+	}
+	if len(opts.TagsNone) > 0 {
+		conditions = append(conditions, "NOT EXISTS (SELECT 1 FROM application_tags WHERE application_id = applications.id AND tag IN ("+placeholders(len(opts.TagsNone))+"))")
+		for _, tag := range opts.TagsNone {
+			args = append(args, tag)
+		}
+	}
+	if opts.HasStale {
+		if opts.Stale {
+			conditions = append(conditions, "stale_at != ''")
+		} else {
+			conditions = append(conditions, "stale_at = ''")
+		}
+	}
+	// A keyset position keeps the rows after it in the sort order. The column
+	// is spliced into the SQL, so one outside the allowlist matches nothing.
+	if c := opts.After; c != nil {
+		if !model.ValidSortColumns[c.SortBy] {
+			conditions = append(conditions, "0")
+		} else {
+			op := "<"
+			if c.SortOrder == "asc" {
+				op = ">"
+			}
+			conditions = append(conditions, "(applications."+c.SortBy+", applications.id) "+op+" (?, ?)")
+			args = append(args, c.Value, c.ID)
+		}
+	}
 
 	if len(conditions) == 0 {
 		return "", nil
@@ -129,9 +198,49 @@ func buildWhere(opts model.ListOptions) (string, []interface{}) {
 	return "WHERE " + strings.Join(conditions, " AND "), args
 }
 
+// ErrInvalidSearch is returned by List and Count when ListOptions.Query is not
+// a valid FTS5 match expression.
+var ErrInvalidSearch = errors.New("invalid search query")
+
+// searchError maps the SQLITE_ERROR that FTS5 raises for a malformed
+// user-supplied query to ErrInvalidSearch. The surrounding SQL is fixed, so a
+// plain SQL logic error during a search can only come from the MATCH text.
+func searchError(opts model.ListOptions, err error) error {
+	if err == nil || opts.Query == "" {
+		return err
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_ERROR {
+		return fmt.Errorf("%w: %v", ErrInvalidSearch, err)
+	}
+	return err
+}
+
+// checkSearch runs opts.Query against the index on its own. Inside a larger
+// query the planner may never evaluate the MATCH when no other condition lets
+// a row through, and a malformed query would then pass unnoticed.
+func checkSearch(ctx context.Context, q queryer, opts model.ListOptions) error {
+	if opts.Query == "" {
+		return nil
+	}
+	rows, err := q.QueryContext(ctx, "SELECT rowid FROM applications_fts WHERE applications_fts MATCH ? LIMIT 1", opts.Query)
+	if err != nil {
+		return searchError(opts, err)
+	}
+	defer rows.Close()
+	rows.Next()
+	return searchError(opts, rows.Err())
+}
+
+// Count returns how many applications match opts, ignoring any keyset
+// position so that every page reports the same total.
 func (s *Store) Count(ctx context.Context, opts model.ListOptions) (int, error) {
+	opts.After = nil
+	if err := checkSearch(ctx, s.db, opts); err != nil {
+		return 0, err
+	}
 	query := "SELECT COUNT(*) FROM applications"
-	whereClause, args := buildWhere(opts)
+	whereClause, args := buildWhere(ctx, opts)
 	if whereClause != "" {
 		query += " " + whereClause
 	}
@@ -139,16 +248,30 @@ func (s *Store) Count(ctx context.Context, opts model.ListOptions) (int, error)
 	var count int
 	err := s.db.QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
-		return 0, err
+		return 0, searchError(opts, err)
 	}
 	return count, nil
 }
 
-func (s *Store) List(ctx context.Context, opts model.ListOptions) ([]model.Application, error) {
+// selectQuery builds the filtered, ordered SELECT shared by List and Each,
+// without LIMIT/OFFSET.
+func selectQuery(ctx context.Context, opts model.ListOptions) (string, []interface{}) {
 	query := "SELECT " + applicationColumns + " FROM applications"
-	whereClause, args := buildWhere(opts)
+	var args []interface{}
+
+	// A search joins the FTS index again to select the snippet and bm25 rank;
+	// buildWhere's MATCH condition still drives the filter so Count agrees.
+	if opts.Query != "" {
+		query = "SELECT " + applicationColumns + ", fts.snippet FROM applications" +
+			" JOIN (SELECT rowid AS fts_rowid, snippet(applications_fts, -1, '<mark>', '</mark>', '…', 12) AS snippet, bm25(applications_fts) AS rank" +
+			" FROM applications_fts WHERE applications_fts MATCH ?) AS fts ON fts.fts_rowid = applications.search_rowid"
+		args = append(args, opts.Query)
+	}
+
+	whereClause, whereArgs := buildWhere(ctx, opts)
 	if whereClause != "" {
 		query += " " + whereClause
+		args = append(args, whereArgs...)
 	}
 
 	// Build ORDER BY
@@ -160,20 +283,48 @@ func (s *Store) List(ctx context.Context, opts model.ListOptions) ([]model.Appli
 	if sortOrder == "" {
 		sortOrder = "DESC"
 	}
-	query += " ORDER BY " + sortBy + " " + sortOrder
+	if sortBy == model.SortByRelevance && opts.Query != "" {
+		// bm25 is lower for better matches, so "desc" relevance is rank ASC.
+		if strings.EqualFold(sortOrder, "desc") {
+			query += " ORDER BY fts.rank ASC"
+		} else {
+			query += " ORDER BY fts.rank DESC"
+		}
+	} else {
+		// id breaks ties so that pages, and cursors, see one fixed order.
+		query += " ORDER BY " + sortBy + " " + sortOrder + ", applications.id " + sortOrder
+	}
+
+	return query, args
+}
+
+func scanListRow(rows *sql.Rows, opts model.ListOptions) (model.Application, error) {
+	if opts.Query == "" {
+		return scanApplication(rows)
+	}
+	var snippet string
+	a, err := scanApplication(rows, &snippet)
+	a.Snippet = snippet
+	return a, err
+}
 
+func (s *Store) List(ctx context.Context, opts model.ListOptions) ([]model.Application, error) {
+	if err := checkSearch(ctx, s.db, opts); err != nil {
+		return nil, err
+	}
+	query, args := selectQuery(ctx, opts)
 	query += " LIMIT ? OFFSET ?"
 	args = append(args, opts.Limit, opts.Offset)
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, searchError(opts, err)
 	}
 	defer rows.Close()
 
 	var apps []model.Application
 	for rows.Next() {
-		a, err := scanApplication(rows)
+		a, err := scanListRow(rows, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -182,11 +333,44 @@ func (s *Store) List(ctx context.Context, opts model.ListOptions) ([]model.Appli
 	if apps == nil {
 		apps = []model.Application{}
 	}
-	return apps, rows.Err()
+	return apps, searchError(opts, rows.Err())
+}
+
+// Each streams every application matching opts' filters and sort to fn, one
+// row at a time, ignoring Limit and Offset. Iteration stops at the first error
+// from fn, which is returned.
+func (s *Store) Each(ctx context.Context, opts model.ListOptions, fn func(model.Application) error) error {
+	if err := checkSearch(ctx, s.db, opts); err != nil {
+		return err
+	}
+	query, args := selectQuery(ctx, opts)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return searchError(opts, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		a, err := scanListRow(rows, opts)
+		if err != nil {
+			return err
+		}
+		if err := fn(a); err != nil {
+			return err
+		}
+	}
+	return searchError(opts, rows.Err())
 }
 
+// Get returns a live application. Trashed applications, and in a scoped
+// context other owners' applications, are not found.
 func (s *Store) Get(ctx context.Context, id string) (*model.Application, error) {
-	a, err := scanApplication(s.db.QueryRowContext(ctx, "SELECT "+applicationColumns+" FROM applications WHERE id = ?", id))
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	a, err := scanApplication(s.db.QueryRowContext(ctx,
+		"SELECT "+applicationColumns+" FROM applications WHERE id = ? AND deleted_at = ''"+owned,
+		append([]interface{}{id}, ownerArgs...)...,
+	))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -197,13 +381,53 @@ func (s *Store) Get(ctx context.Context, id string) (*model.Application, error)
 }
 
 func (s *Store) Create(ctx context.Context, req model.CreateRequest) (*model.Application, error) {
-	now := time.Now().UTC().Format(time.RFC3339)
-	id := generateID()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-	status := req.Status
-	if status == "" {
-		status = "wishlist"
+	id, err := s.insertApplication(ctx, tx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return s.Get(ctx, id)
+}
+
+// CreateBatch inserts every request in a single transaction and returns the
+// new IDs in order. Either all rows are created or none are.
+func (s *Store) CreateBatch(ctx context.Context, reqs []model.CreateRequest) ([]string, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	ids := make([]string, 0, len(reqs))
+	for i, req := range reqs {
+		id, err := s.insertApplication(ctx, tx, req)
+		if err != nil {
+			return nil, fmt.Errorf("inserting application %d: %w", i+1, err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
 	}
+	return ids, nil
+}
+
+// insertApplication writes a new application and its initial history entry.
+// An empty status falls back to the pipeline's default stage.
+func (s *Store) insertApplication(ctx context.Context, tx *sql.Tx, req model.CreateRequest) (string, error) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	id := generateID()
 
 	salaryMin := 0
 	if req.SalaryMin != nil {
@@ -214,31 +438,184 @@ func (s *Store) Create(ctx context.Context, req model.CreateRequest) (*model.App
 		salaryMax = *req.SalaryMax
 	}
 
-	_, err := s.db.ExecContext(ctx,
-		"INSERT INTO applications (id, company, role, url, salary_min, salary_max, location, status, notes, applied_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
-		id, req.Company, req.Role, req.URL, salaryMin, salaryMax, req.Location, status, req.Notes, req.AppliedAt, now, now,
+	pipeline, err := loadPipeline(ctx, tx)
+	if err != nil {
+		return "", err
+	}
+	status := req.Status
+	if status == "" {
+		status = pipeline.Default()
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO applications (id, owner_id, company, role, url, salary_min, salary_max, location, status, notes, applied_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		id, ownerOf(ctx), req.Company, req.Role, req.URL, salaryMin, salaryMax, req.Location, status, req.Notes, req.AppliedAt, now, now,
 	)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return s.Get(ctx, id)
+	if err := recordStatusChange(ctx, tx, id, "", status, "", now); err != nil {
+		return "", fmt.Errorf("recording status history: %w", err)
+	}
+	tags, err := model.NormalizeTags(req.Tags)
+	if err != nil {
+		return "", err
+	}
+	if err := addTags(ctx, tx, id, tags, now); err != nil {
+		return "", fmt.Errorf("adding tags: %w", err)
+	}
+	stage, _ := pipeline.Stage(status)
+	app := model.Application{ID: id, Company: req.Company, Role: req.Role, Status: status}
+	if err := s.onStatusEntered(ctx, tx, app, stage.Terminal, now); err != nil {
+		return "", fmt.Errorf("scheduling follow-up: %w", err)
+	}
+
+	created, err := scanApplication(tx.QueryRowContext(ctx, "SELECT "+applicationColumns+" FROM applications WHERE id = ?", id))
+	if err != nil {
+		return "", err
+	}
+	if err := recordAudit(ctx, tx, model.AuditCreate, auditApplication, id, nil, created); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// liveApplication reads a live application visible in ctx inside tx, for
+// mutations that need its current state. Returns sql.ErrNoRows if there is
+// none.
+func liveApplication(ctx context.Context, tx *sql.Tx, id string) (model.Application, error) {
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	return scanApplication(tx.QueryRowContext(ctx,
+		"SELECT "+applicationColumns+" FROM applications WHERE id = ? AND deleted_at = ''"+owned,
+		append([]interface{}{id}, ownerArgs...)...,
+	))
+}
+
+// ErrVersionMismatch is returned by UpdateWithOptions when the application's
+// version is not one of UpdateOptions.IfVersions.
+var ErrVersionMismatch = errors.New("application has changed")
+
+// UpdateOptions carries per-call settings for UpdateWithOptions that are not
+// application columns.
+type UpdateOptions struct {
+	// IfVersions, when not nil, makes the update conditional on the stored
+	// version being one of these. An empty, non-nil slice never matches.
+	IfVersions []int
+	// StatusNote is stored with the history entry when the status changes.
+	StatusNote string
+	// OverrideTransition skips the pipeline transition check, for correcting
+	// mistakes. The history entry is still recorded.
+	OverrideTransition bool
 }
 
 func (s *Store) Update(ctx context.Context, id string, fields map[string]interface{}) (*model.Application, error) {
-	tx, err := s.db.BeginTx(ctx, nil)
+	return s.UpdateWithOptions(ctx, id, fields, UpdateOptions{})
+}
+
+// UpdateWithOptions applies fields like Update, bumping the version. It
+// returns ErrVersionMismatch if opts.IfVersions excludes the stored version,
+// checked in the same transaction as the write. A status change is checked
+// against the stored status with Pipeline.ValidateTransition, returning a
+// *model.TransitionError unless opts.OverrideTransition is set, and is
+// recorded in status_history within the same transaction.
+func (s *Store) UpdateWithOptions(ctx context.Context, id string, fields map[string]interface{}, opts UpdateOptions) (*model.Application, error) {
+	return s.update(ctx, id, opts, func(model.Application) (map[string]interface{}, []string, error) {
+		return fields, nil, nil
+	})
+}
+
+// PatchWithOptions is UpdateWithOptions for a read-modify-write: patch gets
+// the stored application inside the transaction and returns it as it should
+// be, or an error, which is returned as is. Only the editable columns and the
+// tags (normalized here) are written, so patch must refuse changes to other
+// fields itself.
+func (s *Store) PatchWithOptions(ctx context.Context, id string, patch func(model.Application) (model.Application, error), opts UpdateOptions) (*model.Application, error) {
+	return s.update(ctx, id, opts, func(existing model.Application) (map[string]interface{}, []string, error) {
+		patched, err := patch(existing)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields := map[string]interface{}{}
+		for col, values := range map[string][2]interface{}{
+			"company":    {existing.Company, patched.Company},
+			"role":       {existing.Role, patched.Role},
+			"url":        {existing.URL, patched.URL},
+			"salary_min": {existing.SalaryMin, patched.SalaryMin},
+			"salary_max": {existing.SalaryMax, patched.SalaryMax},
+			"location":   {existing.Location, patched.Location},
+			"status":     {existing.Status, patched.Status},
+			"notes":      {existing.Notes, patched.Notes},
+			"applied_at": {existing.AppliedAt, patched.AppliedAt},
+		} {
+			if values[0] != values[1] {
+				fields[col] = values[1]
+			}
+		}
+		tags, err := model.NormalizeTags(patched.Tags)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Stored tags come back sorted, so order alone is not a change.
+		slices.Sort(tags)
+		if slices.Equal(tags, existing.Tags) {
+			return fields, nil, nil
+		}
+		return fields, tags, nil
+	})
+}
+
+// update runs the read-modify-write shared by UpdateWithOptions and
+// PatchWithOptions. change returns the columns to set and, unless nil, the
+// application's new tags.
+func (s *Store) update(ctx context.Context, id string, opts UpdateOptions, change func(model.Application) (map[string]interface{}, []string, error)) (*model.Application, error) {
+	var updated *model.Application
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		updated, err = s.updateTx(ctx, tx, id, opts, change)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("beginning transaction: %w", err)
+		return nil, err
 	}
-	defer tx.Rollback()
+	return updated, nil
+}
 
-	existing, err := scanApplication(tx.QueryRowContext(ctx, "SELECT "+applicationColumns+" FROM applications WHERE id = ?", id))
+// updateTx is update within tx. Returns nil, nil if the application does not
+// exist.
+func (s *Store) updateTx(ctx context.Context, tx *sql.Tx, id string, opts UpdateOptions, change func(model.Application) (map[string]interface{}, []string, error)) (*model.Application, error) {
+	existing, err := liveApplication(ctx, tx, id)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
+	if opts.IfVersions != nil && !slices.Contains(opts.IfVersions, existing.Version) {
+		return nil, ErrVersionMismatch
+	}
+	fields, tags, err := change(existing)
+	if err != nil {
+		return nil, err
+	}
+
+	newStatus, statusChanged := fields["status"].(string)
+	statusChanged = statusChanged && newStatus != existing.Status
+	var pipeline model.Pipeline
+	if statusChanged {
+		pipeline, err = loadPipeline(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		if !pipeline.Has(newStatus) {
+			return nil, pipeline.ValidateStatus(newStatus)
+		}
+		if !opts.OverrideTransition {
+			if err := pipeline.ValidateTransition(existing.Status, newStatus); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	allowed := map[string]string{
 		"company":    "company",
@@ -262,30 +639,57 @@ func (s *Store) Update(ctx context.Context, id string, fields map[string]interfa
 		}
 	}
 
-	if len(setClauses) == 0 {
+	if len(setClauses) == 0 && tags == nil {
 		return &existing, nil
 	}
 
-	setClauses = append(setClauses, "updated_at = ?")
 	now := time.Now().UTC().Format(time.RFC3339)
-	args = append(args, now)
-	args = append(args, id)
+	if tags != nil {
+		if err := replaceTags(ctx, tx, id, existing.Tags, tags, now); err != nil {
+			return nil, fmt.Errorf("updating tags: %w", err)
+		}
+	}
+	// Any edit is activity, so it clears a stale flag. Tag changes alone
+	// leave updated_at alone, as AddTags does.
+	if len(setClauses) > 0 {
+		setClauses = append(setClauses, "stale_at = ''", "updated_at = ?")
+		args = append(args, now)
+	}
+	setClauses = append(setClauses, "version = version + 1")
+	args = append(args, id, existing.Version)
 
-	query := fmt.Sprintf("UPDATE applications SET %s WHERE id = ?", strings.Join(setClauses, ", "))
-	_, err = tx.ExecContext(ctx, query, args...)
+	// Matching the version read above means a write that raced in between
+	// makes this one fail rather than be silently overwritten.
+	query := fmt.Sprintf("UPDATE applications SET %s WHERE id = ? AND version = ?", strings.Join(setClauses, ", "))
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	n, err := res.RowsAffected()
 	if err != nil {
 		return nil, err
 	}
+	if n == 0 {
+		return nil, ErrVersionMismatch
+	}
 
 	updated, err := scanApplication(tx.QueryRowContext(ctx, "SELECT "+applicationColumns+" FROM applications WHERE id = ?", id))
 	if err != nil {
 		return nil, err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("committing transaction: %w", err)
+	if statusChanged {
+		if err := recordStatusChange(ctx, tx, id, existing.Status, newStatus, opts.StatusNote, now); err != nil {
+			return nil, fmt.Errorf("recording status history: %w", err)
+		}
+		stage, _ := pipeline.Stage(newStatus)
+		if err := s.onStatusEntered(ctx, tx, updated, stage.Terminal, now); err != nil {
+			return nil, fmt.Errorf("scheduling follow-up: %w", err)
+		}
+	}
+	if err := recordAudit(ctx, tx, model.AuditUpdate, auditApplication, id, existing, updated); err != nil {
+		return nil, err
 	}
-
 	return &updated, nil
 }
 
@@ -294,13 +698,19 @@ func (s *Store) Stats(ctx context.Context) (*model.StatsResponse, error) {
 		ByStatus: make(map[string]int),
 	}
 
-	// Pre-populate all statuses with 0
-	for status := range model.ValidStatuses {
-		resp.ByStatus[status] = 0
+	// Pre-populate all stages with 0
+	pipeline, err := s.Pipeline(ctx)
+	if err != nil {
+		return nil, err
 	}
+	for _, stage := range pipeline {
+		resp.ByStatus[stage.Name] = 0
+	}
+
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
 
 	// Query 1: Status counts
-	rows, err := s.db.QueryContext(ctx, "SELECT status, COUNT(*) as count FROM applications GROUP BY status")
+	rows, err := s.db.QueryContext(ctx, "SELECT status, COUNT(*) as count FROM applications WHERE deleted_at = ''"+owned+" GROUP BY status", ownerArgs...)
 	if err != nil {
 		return nil, fmt.Errorf("querying status counts: %w", err)
 	}
@@ -321,7 +731,8 @@ func (s *Store) Stats(ctx context.Context) (*model.StatsResponse, error) {
 
 	// Query 2: Salary aggregate
 	err = s.db.QueryRowContext(ctx,
-		"SELECT COALESCE(MIN(salary_min), 0), COALESCE(MAX(salary_max), 0), COALESCE(CAST(AVG(salary_min) AS INTEGER), 0) FROM applications WHERE salary_min > 0",
+		"SELECT COALESCE(MIN(salary_min), 0), COALESCE(MAX(salary_max), 0), COALESCE(CAST(AVG(salary_min) AS INTEGER), 0) FROM applications WHERE deleted_at = '' AND salary_min > 0"+owned,
+		ownerArgs...,
 	).Scan(&resp.SalaryRange.Min, &resp.SalaryRange.Max, &resp.SalaryRange.Avg)
 	if err != nil {
 		return nil, fmt.Errorf("querying salary aggregate: %w", err)
@@ -333,30 +744,60 @@ func (s *Store) Stats(ctx context.Context) (*model.StatsResponse, error) {
 	thirtyDaysAgo := now.AddDate(0, 0, -30).Format(time.RFC3339)
 
 	err = s.db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM applications WHERE created_at >= ?", sevenDaysAgo,
+		"SELECT COUNT(*) FROM applications WHERE deleted_at = '' AND created_at >= ?"+owned,
+		append([]interface{}{sevenDaysAgo}, ownerArgs...)...,
 	).Scan(&resp.RecentActivity.Last7Days)
 	if err != nil {
 		return nil, fmt.Errorf("querying 7-day activity: %w", err)
 	}
 
 	err = s.db.QueryRowContext(ctx,
-		"SELECT COUNT(*) FROM applications WHERE created_at >= ?", thirtyDaysAgo,
+		"SELECT COUNT(*) FROM applications WHERE deleted_at = '' AND created_at >= ?"+owned,
+		append([]interface{}{thirtyDaysAgo}, ownerArgs...)...,
 	).Scan(&resp.RecentActivity.Last30Days)
 	if err != nil {
 		return nil, fmt.Errorf("querying 30-day activity: %w", err)
 	}
 
+	// Query 4: Tag usage
+	tags, err := s.ListTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("querying tag counts: %w", err)
+	}
+	resp.ByTag = make(map[string]int, len(tags))
+	for _, t := range tags {
+		resp.ByTag[t.Name] = t.Count
+	}
+
 	return resp, nil
 }
 
+// Delete moves a live application to the trash. Its interviews, reminders,
+// contacts and attachments are kept until it is purged.
 func (s *Store) Delete(ctx context.Context, id string) (bool, error) {
-	res, err := s.db.ExecContext(ctx, "DELETE FROM applications WHERE id = ?", id)
+	deleted := false
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		deleted, err = deleteTx(ctx, tx, id)
+		return err
+	})
+	return deleted, err
+}
+
+// deleteTx is Delete within tx.
+func deleteTx(ctx context.Context, tx *sql.Tx, id string) (bool, error) {
+	existing, err := liveApplication(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
 	if err != nil {
 		return false, err
 	}
-	n, err := res.RowsAffected()
-	if err != nil {
+	trashed := existing
+	trashed.DeletedAt = time.Now().UTC().Format(time.RFC3339)
+	trashed.Version++
+	if _, err := tx.ExecContext(ctx, "UPDATE applications SET deleted_at = ?, version = version + 1 WHERE id = ?", trashed.DeletedAt, id); err != nil {
 		return false, err
 	}
-	return n > 0, nil
+	return true, recordAudit(ctx, tx, model.AuditDelete, auditApplication, id, existing, trashed)
 }
@@ -2,8 +2,10 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -291,6 +293,89 @@ func TestUpdateSalaryFields(t *testing.T) {
 	}
 }
 
+func TestUpdateVersions(t *testing.T) {
+	store := setupTestStore(t)
+	created := createTestApp(t, store)
+	if created.Version != 1 {
+		t.Fatalf("expected version 1, got %d", created.Version)
+	}
+
+	updated, err := store.UpdateWithOptions(ctx, created.ID, map[string]interface{}{"notes": "a"}, UpdateOptions{IfVersions: []int{1}})
+	if err != nil || updated.Version != 2 {
+		t.Fatalf("expected version 2, got %+v (%v)", updated, err)
+	}
+	// A writer still holding version 1 loses instead of overwriting.
+	for _, stale := range [][]int{{1}, {}} {
+		if _, err := store.UpdateWithOptions(ctx, created.ID, map[string]interface{}{"notes": "b"}, UpdateOptions{IfVersions: stale}); err != ErrVersionMismatch {
+			t.Fatalf("expected ErrVersionMismatch for %v, got %v", stale, err)
+		}
+	}
+	if got, _ := store.Get(ctx, created.ID); got.Notes != "a" || got.Version != 2 {
+		t.Fatalf("expected the stale write refused, got %+v", got)
+	}
+	if got, _ := store.Update(ctx, created.ID, map[string]interface{}{}); got.Version != 2 {
+		t.Fatalf("expected an empty update to keep the version, got %d", got.Version)
+	}
+
+	// Tag changes bump the version; a no-op tag change does not.
+	store.AddTags(ctx, created.ID, []string{"remote"})
+	store.AddTags(ctx, created.ID, []string{"remote"})
+	if got, _ := store.Get(ctx, created.ID); got.Version != 3 {
+		t.Fatalf("expected version 3 after one tag change, got %d", got.Version)
+	}
+	store.DeleteTag(ctx, "remote")
+	store.Delete(ctx, created.ID)
+	restored, _ := store.Restore(ctx, created.ID)
+	if got, _ := store.Get(ctx, created.ID); got.Version != 6 || restored.Version != 6 {
+		t.Fatalf("expected deleting a tag, trashing and restoring to bump the version, got %d and %d", got.Version, restored.Version)
+	}
+}
+
+func TestPatchWithOptions(t *testing.T) {
+	store := setupTestStore(t)
+	created := createTestApp(t, store)
+	store.AddTags(ctx, created.ID, []string{"go", "remote"})
+
+	patched, err := store.PatchWithOptions(ctx, created.ID, func(app model.Application) (model.Application, error) {
+		app.Tags = []string{"Remote", "backend"}
+		return app, nil
+	}, UpdateOptions{})
+	if err != nil {
+		t.Fatalf("PatchWithOptions failed: %v", err)
+	}
+	if strings.Join(patched.Tags, ",") != "backend,remote" || patched.Version != 3 || patched.UpdatedAt != created.UpdatedAt {
+		t.Fatalf("expected only the tags replaced, got %+v", patched)
+	}
+
+	// Reordering tags is not a change.
+	same, _ := store.PatchWithOptions(ctx, created.ID, func(app model.Application) (model.Application, error) {
+		app.Tags = []string{"remote", "backend"}
+		return app, nil
+	}, UpdateOptions{})
+	if same.Version != 3 {
+		t.Fatalf("expected no new version, got %d", same.Version)
+	}
+
+	// A failing patch writes nothing, even after changing its copy.
+	refused := errors.New("refused")
+	_, err = store.PatchWithOptions(ctx, created.ID, func(app model.Application) (model.Application, error) {
+		app.Notes = "changed"
+		return app, refused
+	}, UpdateOptions{})
+	if err != refused {
+		t.Fatalf("expected the patch's error, got %v", err)
+	}
+	if _, err := store.PatchWithOptions(ctx, created.ID, func(app model.Application) (model.Application, error) {
+		app.Status = "offer"
+		return app, nil
+	}, UpdateOptions{IfVersions: []int{2}}); err != ErrVersionMismatch {
+		t.Fatalf("expected ErrVersionMismatch, got %v", err)
+	}
+	if got, _ := store.Get(ctx, created.ID); got.Notes != created.Notes || got.Status != created.Status || got.Version != 3 {
+		t.Fatalf("expected refused patches to change nothing, got %+v", got)
+	}
+}
+
 func TestCountAll(t *testing.T) {
 	store := setupTestStore(t)
 	createTestApp(t, store)
@@ -440,9 +525,9 @@ func TestStatsEmpty(t *testing.T) {
 		t.Fatalf("expected 0 recent activity, got 7d=%d 30d=%d",
 			stats.RecentActivity.Last7Days, stats.RecentActivity.Last30Days)
 	}
-	// All 9 statuses should be present with 0
-	if len(stats.ByStatus) != len(model.ValidStatuses) {
-		t.Fatalf("expected %d statuses, got %d", len(model.ValidStatuses), len(stats.ByStatus))
+	// All 9 seeded stages should be present with 0
+	if len(stats.ByStatus) != 9 {
+		t.Fatalf("expected 9 statuses, got %d", len(stats.ByStatus))
 	}
 }
 
@@ -539,6 +624,60 @@ func TestListSortBySalaryMinDesc(t *testing.T) {
 	}
 }
 
+// Keyset pages walk every row once, ties on the sort column broken by id
+func TestListAfterCursor(t *testing.T) {
+	store := setupTestStore(t)
+
+	for _, company := range []string{"Beta", "Alpha", "Beta", "Beta", "Gamma"} {
+		store.Create(ctx, model.CreateRequest{Company: company, Role: "R"})
+	}
+	all, _ := store.List(ctx, model.ListOptions{SortBy: "company", SortOrder: "asc", Limit: 10})
+
+	for _, order := range []string{"asc", "desc"} {
+		opts := model.ListOptions{SortBy: "company", SortOrder: order, Limit: 2}
+		var ids []string
+		for page := 0; page < 5; page++ {
+			apps, err := store.List(ctx, opts)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			if len(apps) == 0 {
+				break
+			}
+			for _, a := range apps {
+				ids = append(ids, a.ID)
+			}
+			c := model.NewCursor(apps[len(apps)-1], opts.SortBy, opts.SortOrder)
+			opts.After = &c
+		}
+		if len(ids) != len(all) {
+			t.Fatalf("%s: expected %d apps, got %d", order, len(all), len(ids))
+		}
+		for i, a := range all {
+			j := i
+			if order == "desc" {
+				j = len(all) - 1 - i
+			}
+			if ids[j] != a.ID {
+				t.Fatalf("%s: expected %s at %d, got %v", order, a.ID, j, ids)
+			}
+		}
+	}
+
+	// The total ignores the cursor.
+	c := model.NewCursor(all[0], "company", "asc")
+	if n, _ := store.Count(ctx, model.ListOptions{After: &c}); n != 5 {
+		t.Fatalf("expected a count of 5, got %d", n)
+	}
+
+	// A sort column outside the allowlist never reaches the SQL.
+	bad := model.Cursor{SortBy: "company) OR (1", SortOrder: "asc", Value: "", ID: ""}
+	apps, err := store.List(ctx, model.ListOptions{After: &bad, Limit: 10})
+	if err != nil || len(apps) != 0 {
+		t.Fatalf("expected no rows for an unknown cursor column, got %d (%v)", len(apps), err)
+	}
+}
+
 // Test filter by company substring (case-insensitive)
 func TestListFilterByCompany(t *testing.T) {
 	store := setupTestStore(t)
@@ -880,3 +1019,85 @@ func TestNewStoreInvalidPath(t *testing.T) {
 		t.Fatal("expected error for invalid path, got nil")
 	}
 }
+
+func TestCreateBatch(t *testing.T) {
+	store := setupTestStore(t)
+
+	ids, err := store.CreateBatch(ctx, []model.CreateRequest{
+		{Company: "A", Role: "R", Status: "applied"},
+		{Company: "B", Role: "R"},
+	})
+	if err != nil {
+		t.Fatalf("CreateBatch failed: %v", err)
+	}
+	if len(ids) != 2 || ids[0] == ids[1] {
+		t.Fatalf("expected 2 distinct IDs, got %v", ids)
+	}
+	b, _ := store.Get(ctx, ids[1])
+	if b == nil || b.Status != "wishlist" {
+		t.Fatalf("expected default status on batch insert, got %+v", b)
+	}
+	history, _ := store.History(ctx, ids[0])
+	if len(history) != 1 || history[0].ToStatus != "applied" {
+		t.Fatalf("expected initial history entry, got %+v", history)
+	}
+}
+
+func TestCreateBatchAllOrNothing(t *testing.T) {
+	store := setupTestStore(t)
+
+	// Force the third insert to fail so the transaction must roll back.
+	_, err := store.db.Exec(`CREATE TRIGGER fail_c BEFORE INSERT ON applications
+		WHEN new.company = 'C' BEGIN SELECT RAISE(ABORT, 'boom'); END`)
+	if err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+
+	_, err = store.CreateBatch(ctx, []model.CreateRequest{
+		{Company: "A", Role: "R"},
+		{Company: "B", Role: "R"},
+		{Company: "C", Role: "R"},
+	})
+	if err == nil {
+		t.Fatal("expected CreateBatch to fail")
+	}
+
+	count, err := store.Count(ctx, model.ListOptions{})
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("expected no rows after failed batch, got %d", count)
+	}
+}
+
+func TestEachIgnoresLimitAndStopsOnError(t *testing.T) {
+	store := setupFileStore(t)
+	for _, c := range []string{"Bravo", "Alpha", "Charlie"} {
+		if _, err := store.Create(ctx, model.CreateRequest{Company: c, Role: "Engineer"}); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	var got []model.Application
+	err := store.Each(ctx, model.ListOptions{SortBy: "company", SortOrder: "ASC", Limit: 1}, func(a model.Application) error {
+		got = append(got, a)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Each failed: %v", err)
+	}
+	if companies(got) != "Alpha,Bravo,Charlie" {
+		t.Fatalf("expected all rows in company order, got %s", companies(got))
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = store.Each(ctx, model.ListOptions{}, func(model.Application) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Fatalf("expected Each to stop with fn's error after one call, got %v after %d", err, calls)
+	}
+}
@@ -0,0 +1,273 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// maxDuplicates caps how many likely duplicates FindDuplicates reports.
+const maxDuplicates = 10
+
+// FindDuplicates returns the live applications visible in ctx that look like
+// the same posting as app, by URL or by company and role (see
+// model.DuplicateReason). Same-URL matches come first, then the rest oldest
+// first. app itself is skipped if it has an ID.
+func (s *Store) FindDuplicates(ctx context.Context, app model.Application) ([]model.Duplicate, error) {
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT id, company, role, url, status FROM applications WHERE deleted_at = '' AND id <> ?"+owned+" ORDER BY created_at, rowid",
+		append([]interface{}{app.ID}, ownerArgs...)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("querying applications: %w", err)
+	}
+	defer rows.Close()
+
+	var sameURL, similar []model.Duplicate
+	for rows.Next() {
+		var other model.Application
+		if err := rows.Scan(&other.ID, &other.Company, &other.Role, &other.URL, &other.Status); err != nil {
+			return nil, fmt.Errorf("scanning application: %w", err)
+		}
+		reason, ok := model.DuplicateReason(app, other)
+		if !ok {
+			continue
+		}
+		d := model.Duplicate{ID: other.ID, Company: other.Company, Role: other.Role, URL: other.URL, Status: other.Status, Reason: reason}
+		if reason == model.DuplicateSameURL {
+			sameURL = append(sameURL, d)
+		} else {
+			similar = append(similar, d)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating applications: %w", err)
+	}
+	dups := append(sameURL, similar...)
+	if len(dups) > maxDuplicates {
+		dups = dups[:maxDuplicates]
+	}
+	return dups, nil
+}
+
+// Merge folds the application sourceID into targetID and moves the source to
+// the trash. The source's notes are appended to the target's, its tags are
+// added, and its URL, location, salary and applied date fill in any the
+// target lacks; its status history, interviews, reminders, attachments and
+// contact links move to the target. If both had an open follow-up, only the
+// one due first stays open. Each moved row is audited. opts.IfVersions
+// applies to the target. Returns nil, nil if either application does not
+// exist.
+func (s *Store) Merge(ctx context.Context, targetID, sourceID string, opts UpdateOptions) (*model.Application, error) {
+	var merged *model.Application
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		source, err := liveApplication(ctx, tx, sourceID)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var gained []string
+		merged, err = s.updateTx(ctx, tx, targetID, opts, func(target model.Application) (map[string]interface{}, []string, error) {
+			tags := mergeTags(target.Tags, source.Tags)
+			for _, tag := range tags {
+				if !slices.Contains(target.Tags, tag) {
+					gained = append(gained, tag)
+				}
+			}
+			return mergeFields(target, source), tags, nil
+		})
+		if err != nil || merged == nil {
+			return err
+		}
+		for _, tag := range gained {
+			if err := recordAudit(ctx, tx, model.AuditLink, auditApplication, targetID, nil, tagLink(tag, sourceID)); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.ExecContext(ctx, "UPDATE status_history SET application_id = ? WHERE application_id = ?", targetID, sourceID); err != nil {
+			return fmt.Errorf("moving status_history: %w", err)
+		}
+		for _, m := range []struct{ table, entity string }{
+			{"interviews", auditInterview}, {"reminders", auditReminder}, {"attachments", auditAttachment},
+		} {
+			if err := moveRows(ctx, tx, m.table, m.entity, targetID, sourceID); err != nil {
+				return fmt.Errorf("moving %s: %w", m.table, err)
+			}
+		}
+		if err := moveContacts(ctx, tx, targetID, sourceID); err != nil {
+			return fmt.Errorf("moving contacts: %w", err)
+		}
+
+		// The follow-ups left open are all the target's now.
+		now := time.Now().UTC().Format(time.RFC3339)
+		closed, err := queryIDs(ctx, tx,
+			`SELECT id FROM reminders
+			WHERE application_id = ? AND kind = ? AND done = 0 AND id <> (
+				SELECT id FROM reminders WHERE application_id = ? AND kind = ? AND done = 0 ORDER BY due_at, id LIMIT 1)`,
+			targetID, model.ReminderFollowUp, targetID, model.ReminderFollowUp,
+		)
+		if err != nil {
+			return fmt.Errorf("completing follow-ups: %w", err)
+		}
+		for _, id := range closed {
+			if _, err := tx.ExecContext(ctx, "UPDATE reminders SET done = 1, completed_at = ?, updated_at = ? WHERE id = ?", now, now, id); err != nil {
+				return fmt.Errorf("completing follow-ups: %w", err)
+			}
+			if err := recordAudit(ctx, tx, model.AuditUpdate, auditReminder, id, map[string]any{"done": false}, map[string]any{"done": true, "completed_at": now}); err != nil {
+				return err
+			}
+		}
+
+		_, err = deleteTx(ctx, tx, sourceID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// moveRows points the source's rows in table at the target, recording the
+// move as an update of each row's entity.
+func moveRows(ctx context.Context, tx *sql.Tx, table, entity, targetID, sourceID string) error {
+	ids, err := queryIDs(ctx, tx, "SELECT id FROM "+table+" WHERE application_id = ? ORDER BY id", sourceID)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE "+table+" SET application_id = ? WHERE application_id = ?", targetID, sourceID); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		err := recordAudit(ctx, tx, model.AuditUpdate, entity, id, map[string]any{"application_id": sourceID}, map[string]any{"application_id": targetID})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveContacts relinks the source's contacts to the target, which may
+// already have some of the same links, recording an unlink from the source
+// and, for each new link, a link to the target.
+func moveContacts(ctx context.Context, tx *sql.Tx, targetID, sourceID string) error {
+	rows, err := tx.QueryContext(ctx,
+		"SELECT contact_id, relationship, created_at, owner_id FROM application_contacts WHERE application_id = ? ORDER BY contact_id, relationship",
+		sourceID,
+	)
+	if err != nil {
+		return err
+	}
+	type link struct{ contactID, relationship, createdAt, owner string }
+	var links []link
+	for rows.Next() {
+		var l link
+		if err := rows.Scan(&l.contactID, &l.relationship, &l.createdAt, &l.owner); err != nil {
+			rows.Close()
+			return err
+		}
+		links = append(links, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, l := range links {
+		res, err := tx.ExecContext(ctx,
+			"INSERT OR IGNORE INTO application_contacts (application_id, contact_id, relationship, created_at, owner_id) VALUES (?, ?, ?, ?, ?)",
+			targetID, l.contactID, l.relationship, l.createdAt, l.owner,
+		)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			if err := recordAudit(ctx, tx, model.AuditLink, auditApplication, targetID, nil, contactLink(l.contactID, l.relationship)); err != nil {
+				return err
+			}
+		}
+		if err := recordAudit(ctx, tx, model.AuditUnlink, auditApplication, sourceID, contactLink(l.contactID, l.relationship), nil); err != nil {
+			return err
+		}
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM application_contacts WHERE application_id = ?", sourceID)
+	return err
+}
+
+// tagLink is how a tag gained in a merge appears in the audit log of the
+// target.
+func tagLink(tag, fromID string) map[string]any {
+	return map[string]any{"tag": tag, "merged_from": fromID}
+}
+
+// queryIDs returns the first column of every row of query.
+func queryIDs(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// mergeFields returns the target columns Merge changes.
+func mergeFields(target, source model.Application) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if notes := strings.TrimSpace(source.Notes); notes != "" && !strings.Contains(target.Notes, notes) {
+		if strings.TrimSpace(target.Notes) == "" {
+			fields["notes"] = notes
+		} else {
+			fields["notes"] = strings.TrimRight(target.Notes, "\n") + "\n\n" + notes
+		}
+	}
+	if target.URL == "" && source.URL != "" {
+		fields["url"] = source.URL
+	}
+	if target.Location == "" && source.Location != "" {
+		fields["location"] = source.Location
+	}
+	if target.SalaryMin == 0 && target.SalaryMax == 0 && (source.SalaryMin != 0 || source.SalaryMax != 0) {
+		fields["salary_min"] = source.SalaryMin
+		fields["salary_max"] = source.SalaryMax
+	}
+	if target.AppliedAt == "" && source.AppliedAt != "" {
+		fields["applied_at"] = source.AppliedAt
+	}
+	return fields
+}
+
+// mergeTags returns the union of both tag lists, sorted, or nil if source
+// adds nothing to target.
+func mergeTags(target, source []string) []string {
+	tags := slices.Clone(target)
+	for _, tag := range source {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == len(target) {
+		return nil
+	}
+	slices.Sort(tags)
+	return tags
+}
@@ -0,0 +1,139 @@
+package db
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	store := setupTestStore(t)
+	similar, _ := store.Create(ctx, model.CreateRequest{Company: "Acme Inc.", Role: "Sr. Backend Eng"})
+	sameURL, _ := store.Create(ctx, model.CreateRequest{Company: "Globex", Role: "Designer", URL: "https://jobs.example.com/42/"})
+	store.Create(ctx, model.CreateRequest{Company: "Initech", Role: "Senior Backend Engineer"})
+	trashed, _ := store.Create(ctx, model.CreateRequest{Company: "Acme", Role: "Senior Backend Engineer"})
+	store.Delete(ctx, trashed.ID)
+
+	dups, err := store.FindDuplicates(ctx, model.Application{Company: "ACME", Role: "Senior Backend Engineer", URL: "https://jobs.example.com/42"})
+	if err != nil {
+		t.Fatalf("FindDuplicates failed: %v", err)
+	}
+	if len(dups) != 2 || dups[0].ID != sameURL.ID || dups[0].Reason != model.DuplicateSameURL ||
+		dups[1].ID != similar.ID || dups[1].Reason != model.DuplicateSimilar {
+		t.Fatalf("unexpected duplicates %+v", dups)
+	}
+
+	// An application is not its own duplicate.
+	dups, _ = store.FindDuplicates(ctx, *similar)
+	if len(dups) != 0 {
+		t.Fatalf("expected no duplicates, got %+v", dups)
+	}
+}
+
+// Company names that differ in punctuation or by a typo share no substring
+// the store could filter on, so every live row must be compared.
+func TestFindDuplicatesVariants(t *testing.T) {
+	store := setupTestStore(t)
+	jpm, _ := store.Create(ctx, model.CreateRequest{Company: "J.P. Morgan", Role: "Quant Developer"})
+	google, _ := store.Create(ctx, model.CreateRequest{Company: "Google", Role: "SRE"})
+
+	for _, tc := range []struct {
+		company, role, want string
+	}{
+		{"JPMorgan", "Quant Dev", jpm.ID},
+		{"Gogle", "SRE", google.ID},
+	} {
+		dups, err := store.FindDuplicates(ctx, model.Application{Company: tc.company, Role: tc.role})
+		if err != nil {
+			t.Fatalf("FindDuplicates failed: %v", err)
+		}
+		if len(dups) != 1 || dups[0].ID != tc.want || dups[0].Reason != model.DuplicateSimilar {
+			t.Errorf("%s: expected %s, got %+v", tc.company, tc.want, dups)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	store := setupTestStore(t)
+	target, _ := store.Create(ctx, model.CreateRequest{Company: "Acme", Role: "Eng", Status: "applied", Notes: "Referred by Sam.", Tags: []string{"remote"}})
+	source, _ := store.Create(ctx, model.CreateRequest{
+		Company: "Acme Inc", Role: "Engineer", Status: "applied", URL: "https://acme.example/jobs/1",
+		SalaryMin: intPtr(100), SalaryMax: intPtr(150), Notes: "Recruiter called.", Tags: []string{"backend", "remote"},
+	})
+	contact, _ := store.CreateContact(ctx, model.ContactRequest{Name: strPtr("Jane")})
+	store.LinkContact(ctx, target.ID, contact.ID, "recruiter")
+	store.LinkContact(ctx, source.ID, contact.ID, "recruiter")
+	store.LinkContact(ctx, source.ID, contact.ID, "referrer")
+	if _, err := store.UpdateWithOptions(ctx, source.ID, map[string]interface{}{"status": "interview"}, UpdateOptions{}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	merged, err := store.Merge(ctx, target.ID, source.ID, UpdateOptions{})
+	if err != nil || merged == nil {
+		t.Fatalf("Merge failed: %v %v", merged, err)
+	}
+	if merged.Notes != "Referred by Sam.\n\nRecruiter called." || merged.URL != source.URL ||
+		merged.SalaryMin != 100 || merged.SalaryMax != 150 || merged.Status != "applied" ||
+		strings.Join(merged.Tags, ",") != "backend,remote" || merged.Version != target.Version+1 {
+		t.Fatalf("unexpected merged application %+v", merged)
+	}
+
+	history, _ := store.History(ctx, target.ID)
+	if len(history) != 3 {
+		t.Fatalf("expected both histories on the target, got %+v", history)
+	}
+	links, _ := store.ApplicationContacts(ctx, target.ID)
+	if len(links) != 2 {
+		t.Fatalf("expected the recruiter link once plus the referrer, got %+v", links)
+	}
+	reminders, _ := store.ListReminders(ctx, target.ID)
+	open := 0
+	for _, r := range reminders {
+		if !r.Done {
+			open++
+		}
+	}
+	if len(reminders) != 2 || open != 1 {
+		t.Fatalf("expected one of the two follow-ups left open, got %+v", reminders)
+	}
+	if got, _ := store.Get(ctx, source.ID); got != nil {
+		t.Fatalf("expected the source trashed, got %+v", got)
+	}
+
+	actions := func(f AuditFilter) string {
+		f.Limit = 50
+		entries, _ := store.AuditLog(ctx, f)
+		var got []string
+		for _, e := range entries {
+			got = append(got, e.Action)
+		}
+		slices.Sort(got)
+		return strings.Join(got, ",")
+	}
+	if got := actions(AuditFilter{EntityID: target.ID}); got != "create,link,link,link,update" {
+		t.Fatalf("expected the referrer and backend tag linked to the target, got %s", got)
+	}
+	if got := actions(AuditFilter{EntityID: source.ID}); got != "create,delete,link,link,unlink,unlink,update" {
+		t.Fatalf("expected both contact links unlinked from the source, got %s", got)
+	}
+	if got := actions(AuditFilter{EntityType: "reminder"}); got != "update,update" {
+		t.Fatalf("expected the moved and the completed follow-up audited, got %s", got)
+	}
+
+	// Merging again finds no source; a missing target is the same.
+	if got, err := store.Merge(ctx, target.ID, source.ID, UpdateOptions{}); got != nil || err != nil {
+		t.Fatalf("expected nil, nil for a trashed source, got %v %v", got, err)
+	}
+	other := createTestApp(t, store)
+	if got, err := store.Merge(ctx, "deadbeef", other.ID, UpdateOptions{}); got != nil || err != nil {
+		t.Fatalf("expected nil, nil for a missing target, got %v %v", got, err)
+	}
+	if got, _ := store.Get(ctx, other.ID); got == nil {
+		t.Fatal("expected the source kept when the target is missing")
+	}
+	if _, err := store.Merge(ctx, target.ID, other.ID, UpdateOptions{IfVersions: []int{1}}); err != ErrVersionMismatch {
+		t.Fatalf("expected ErrVersionMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func recordStatusChange(ctx context.Context, tx *sql.Tx, appID, from, to, note, changedAt string) error {
+	_, err := tx.ExecContext(ctx,
+		"INSERT INTO status_history (application_id, owner_id, from_status, to_status, note, changed_at) VALUES (?, "+appOwner+", ?, ?, ?, ?)",
+		appID, appID, from, to, note, changedAt,
+	)
+	return err
+}
+
+// History returns the status timeline for an application, oldest first.
+func (s *Store) History(ctx context.Context, appID string) ([]model.StatusChange, error) {
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT id, application_id, from_status, to_status, note, changed_at FROM status_history WHERE application_id = ?"+owned+" ORDER BY changed_at, id",
+		append([]interface{}{appID}, ownerArgs...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []model.StatusChange{}
+	for rows.Next() {
+		var c model.StatusChange
+		if err := rows.Scan(&c.ID, &c.ApplicationID, &c.FromStatus, &c.ToStatus, &c.Note, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, c)
+	}
+	return history, rows.Err()
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestHistoryRecordsCreateAndStatusChanges(t *testing.T) {
+	store := setupTestStore(t)
+
+	app, err := store.Create(ctx, model.CreateRequest{Company: "HistCo", Role: "Eng", Status: "applied"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if _, err := store.UpdateWithOptions(ctx, app.ID, map[string]interface{}{"status": "phone_screen"}, UpdateOptions{StatusNote: "recruiter call"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := store.Update(ctx, app.ID, map[string]interface{}{"status": "interview"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	history, err := store.History(ctx, app.ID)
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected 3 history entries, got %d: %+v", len(history), history)
+	}
+
+	want := []struct{ from, to, note string }{
+		{"", "applied", ""},
+		{"applied", "phone_screen", "recruiter call"},
+		{"phone_screen", "interview", ""},
+	}
+	for i, w := range want {
+		got := history[i]
+		if got.FromStatus != w.from || got.ToStatus != w.to || got.Note != w.note {
+			t.Errorf("entry %d: expected %s->%s (%q), got %s->%s (%q)", i, w.from, w.to, w.note, got.FromStatus, got.ToStatus, got.Note)
+		}
+		if got.ApplicationID != app.ID || got.ChangedAt == "" {
+			t.Errorf("entry %d: missing application_id or changed_at: %+v", i, got)
+		}
+	}
+}
+
+func TestHistoryIgnoresNonStatusUpdates(t *testing.T) {
+	store := setupTestStore(t)
+	app := createTestApp(t, store)
+
+	if _, err := store.Update(ctx, app.ID, map[string]interface{}{"notes": "n"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := store.Update(ctx, app.ID, map[string]interface{}{"status": app.Status}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	history, err := store.History(ctx, app.ID)
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("expected only the create entry, got %d", len(history))
+	}
+}
+
+func TestHistoryDeletedWithApplication(t *testing.T) {
+	store := setupTestStore(t)
+	app := createTestApp(t, store)
+
+	if _, err := store.Delete(ctx, app.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Purge(ctx, app.ID); err != nil {
+		t.Fatalf("Purge failed: %v", err)
+	}
+
+	history, err := store.History(ctx, app.ID)
+	if err != nil {
+		t.Fatalf("History failed: %v", err)
+	}
+	if len(history) != 0 {
+		t.Fatalf("expected history to be removed with application, got %d entries", len(history))
+	}
+}
+
+func TestUpdateRejectsIllegalTransition(t *testing.T) {
+	store := setupTestStore(t)
+	app, err := store.Create(ctx, model.CreateRequest{Company: "DoneCo", Role: "Eng", Status: "applied"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := store.Update(ctx, app.ID, map[string]interface{}{"status": "rejected"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	_, err = store.Update(ctx, app.ID, map[string]interface{}{"status": "offer", "notes": "typo"})
+	var te *model.TransitionError
+	if !errors.As(err, &te) {
+		t.Fatalf("expected TransitionError, got %v", err)
+	}
+
+	got, _ := store.Get(ctx, app.ID)
+	if got.Status != "rejected" || got.Notes != "" {
+		t.Fatalf("rejected update must not change the row, got %+v", got)
+	}
+}
+
+func TestUpdateOverrideTransition(t *testing.T) {
+	store := setupTestStore(t)
+	app, err := store.Create(ctx, model.CreateRequest{Company: "FixCo", Role: "Eng", Status: "accepted"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	updated, err := store.UpdateWithOptions(ctx, app.ID, map[string]interface{}{"status": "offer"}, UpdateOptions{OverrideTransition: true, StatusNote: "clicked the wrong status"})
+	if err != nil {
+		t.Fatalf("override update failed: %v", err)
+	}
+	if updated.Status != "offer" {
+		t.Fatalf("expected status offer, got %s", updated.Status)
+	}
+
+	history, _ := store.History(ctx, app.ID)
+	if len(history) != 2 || history[1].Note != "clicked the wrong status" {
+		t.Fatalf("expected override to be recorded in history, got %+v", history)
+	}
+}
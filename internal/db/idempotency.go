@@ -0,0 +1,97 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// idempotencyLockTimeout is how long a reservation holds its key while the
+// request runs. If the server dies mid-request, retries work again after it.
+const idempotencyLockTimeout = time.Minute
+
+var (
+	// ErrIdempotencyMismatch is returned by BeginIdempotent when the key was
+	// used for a different request.
+	ErrIdempotencyMismatch = errors.New("idempotency key was used for a different request")
+	// ErrIdempotencyInProgress is returned by BeginIdempotent while the
+	// first request with the key is still being served.
+	ErrIdempotencyInProgress = errors.New("a request with this idempotency key is in progress")
+)
+
+// StoredResponse is a response saved under an idempotency key.
+type StoredResponse struct {
+	Status int
+	Header map[string]string
+	Body   []byte
+}
+
+// BeginIdempotent reserves key, scoped to the owner in ctx, for the request
+// whose hash is given. If the key has already been used for the same request
+// it returns that request's response instead, so the caller can replay it.
+// Expired keys are forgotten.
+func (s *Store) BeginIdempotent(ctx context.Context, key, requestHash string) (*StoredResponse, error) {
+	now := time.Now().UTC()
+	var stored *StoredResponse
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= ?", now.Format(time.RFC3339)); err != nil {
+			return err
+		}
+		var hash, header string
+		var resp StoredResponse
+		err := tx.QueryRowContext(ctx,
+			"SELECT request_hash, status, header, body FROM idempotency_keys WHERE owner_id = ? AND key = ?", ownerOf(ctx), key,
+		).Scan(&hash, &resp.Status, &header, &resp.Body)
+		if err == sql.ErrNoRows {
+			_, err = tx.ExecContext(ctx,
+				"INSERT INTO idempotency_keys (owner_id, key, request_hash, created_at, expires_at) VALUES (?, ?, ?, ?, ?)",
+				ownerOf(ctx), key, requestHash, now.Format(time.RFC3339), now.Add(idempotencyLockTimeout).Format(time.RFC3339),
+			)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if hash != requestHash {
+			return ErrIdempotencyMismatch
+		}
+		if resp.Status == 0 {
+			return ErrIdempotencyInProgress
+		}
+		if err := json.Unmarshal([]byte(header), &resp.Header); err != nil {
+			return err
+		}
+		stored = &resp
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// FinishIdempotent saves the response to the request that reserved key and
+// keeps it for ttl from when the request arrived.
+func (s *Store) FinishIdempotent(ctx context.Context, key string, resp StoredResponse, ttl time.Duration) error {
+	header, err := json.Marshal(resp.Header)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`UPDATE idempotency_keys SET status = ?, header = ?, body = ?,
+			expires_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at, ?)
+		WHERE owner_id = ? AND key = ? AND status = 0`,
+		resp.Status, string(header), resp.Body, fmt.Sprintf("%+d seconds", int(ttl.Seconds())), ownerOf(ctx), key,
+	)
+	return err
+}
+
+// AbandonIdempotent releases a reservation without saving a response, so the
+// request can be retried with the same key.
+func (s *Store) AbandonIdempotent(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE owner_id = ? AND key = ? AND status = 0", ownerOf(ctx), key)
+	return err
+}
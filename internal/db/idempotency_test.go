@@ -0,0 +1,49 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	store := setupTestStore(t)
+
+	if stored, err := store.BeginIdempotent(ctx, "k1", "hash-a"); err != nil || stored != nil {
+		t.Fatalf("expected a fresh key reserved, got %+v (%v)", stored, err)
+	}
+	if _, err := store.BeginIdempotent(ctx, "k1", "hash-a"); err != ErrIdempotencyInProgress {
+		t.Fatalf("expected ErrIdempotencyInProgress, got %v", err)
+	}
+	if _, err := store.BeginIdempotent(ctx, "k1", "hash-b"); err != ErrIdempotencyMismatch {
+		t.Fatalf("expected ErrIdempotencyMismatch, got %v", err)
+	}
+
+	resp := StoredResponse{Status: 201, Header: map[string]string{"ETag": `"1"`}, Body: []byte(`{"id":"x"}`)}
+	if err := store.FinishIdempotent(ctx, "k1", resp, time.Hour); err != nil {
+		t.Fatalf("FinishIdempotent failed: %v", err)
+	}
+	stored, err := store.BeginIdempotent(ctx, "k1", "hash-a")
+	if err != nil || stored == nil || stored.Status != 201 || stored.Header["ETag"] != `"1"` || string(stored.Body) != `{"id":"x"}` {
+		t.Fatalf("expected the saved response, got %+v (%v)", stored, err)
+	}
+
+	// Keys are per owner.
+	if stored, err := store.BeginIdempotent(WithOwner(ctx, "someone"), "k1", "hash-b"); err != nil || stored != nil {
+		t.Fatalf("expected another owner's key to be separate, got %+v (%v)", stored, err)
+	}
+
+	// An abandoned reservation can be taken again.
+	store.BeginIdempotent(ctx, "k2", "hash-a")
+	if err := store.AbandonIdempotent(ctx, "k2"); err != nil {
+		t.Fatalf("AbandonIdempotent failed: %v", err)
+	}
+	if stored, err := store.BeginIdempotent(ctx, "k2", "hash-b"); err != nil || stored != nil {
+		t.Fatalf("expected an abandoned key to be free, got %+v (%v)", stored, err)
+	}
+
+	// Expired responses are forgotten.
+	store.FinishIdempotent(ctx, "k2", resp, -time.Hour)
+	if stored, err := store.BeginIdempotent(ctx, "k2", "hash-c"); err != nil || stored != nil {
+		t.Fatalf("expected an expired key to be free, got %+v (%v)", stored, err)
+	}
+}
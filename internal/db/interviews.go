@@ -0,0 +1,200 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+const interviewColumns = "id, application_id, starts_at, ends_at, timezone, format, interviewers, location, outcome, feedback, created_at, updated_at"
+
+func scanInterview(row scanner, extra ...any) (model.Interview, error) {
+	var iv model.Interview
+	var interviewers string
+	dest := []any{&iv.ID, &iv.ApplicationID, &iv.StartsAt, &iv.EndsAt, &iv.Timezone, &iv.Format, &interviewers, &iv.Location, &iv.Outcome, &iv.Feedback, &iv.CreatedAt, &iv.UpdatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return iv, err
+	}
+	if err := json.Unmarshal([]byte(interviewers), &iv.Interviewers); err != nil {
+		return iv, fmt.Errorf("decoding interviewers: %w", err)
+	}
+	if iv.Interviewers == nil {
+		iv.Interviewers = []string{}
+	}
+	return iv, nil
+}
+
+// ListInterviews returns an application's interviews in start order.
+func (s *Store) ListInterviews(ctx context.Context, appID string) ([]model.Interview, error) {
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+interviewColumns+" FROM interviews WHERE application_id = ?"+owned+" ORDER BY starts_at, id",
+		append([]interface{}{appID}, ownerArgs...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	interviews := []model.Interview{}
+	for rows.Next() {
+		iv, err := scanInterview(rows)
+		if err != nil {
+			return nil, err
+		}
+		interviews = append(interviews, iv)
+	}
+	return interviews, rows.Err()
+}
+
+// GetInterview returns nil, nil unless the interview exists and belongs to
+// the application.
+func (s *Store) GetInterview(ctx context.Context, appID, id string) (*model.Interview, error) {
+	iv, err := findInterview(ctx, s.db, appID, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &iv, nil
+}
+
+// findInterview reads an interview of appID visible in ctx. Returns
+// sql.ErrNoRows if there is none.
+func findInterview(ctx context.Context, q rowQueryer, appID, id string) (model.Interview, error) {
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	return scanInterview(q.QueryRowContext(ctx,
+		"SELECT "+interviewColumns+" FROM interviews WHERE id = ? AND application_id = ?"+owned,
+		append([]interface{}{id, appID}, ownerArgs...)...,
+	))
+}
+
+// CreateInterview inserts iv, which must already be validated, under its
+// ApplicationID.
+func (s *Store) CreateInterview(ctx context.Context, iv model.Interview) (*model.Interview, error) {
+	interviewers, err := json.Marshal(iv.Interviewers)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	iv.ID = generateID()
+
+	var created model.Interview
+	err = s.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO interviews ("+interviewColumns+", owner_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, "+appOwner+")",
+			iv.ID, iv.ApplicationID, iv.StartsAt, iv.EndsAt, iv.Timezone, iv.Format, string(interviewers), iv.Location, iv.Outcome, iv.Feedback, now, now, iv.ApplicationID,
+		)
+		if err != nil {
+			return err
+		}
+		created, err = scanInterview(tx.QueryRowContext(ctx, "SELECT "+interviewColumns+" FROM interviews WHERE id = ?", iv.ID))
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, model.AuditCreate, auditInterview, iv.ID, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateInterview overwrites every editable column of the stored interview
+// with iv's values. Returns nil, nil if it no longer exists.
+func (s *Store) UpdateInterview(ctx context.Context, iv model.Interview) (*model.Interview, error) {
+	interviewers, err := json.Marshal(iv.Interviewers)
+	if err != nil {
+		return nil, err
+	}
+	var updated *model.Interview
+	err = s.inTx(ctx, func(tx *sql.Tx) error {
+		existing, err := findInterview(ctx, tx, iv.ApplicationID, iv.ID)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx,
+			`UPDATE interviews SET starts_at = ?, ends_at = ?, timezone = ?, format = ?, interviewers = ?,
+				location = ?, outcome = ?, feedback = ?, updated_at = ?
+			WHERE id = ?`,
+			iv.StartsAt, iv.EndsAt, iv.Timezone, iv.Format, string(interviewers),
+			iv.Location, iv.Outcome, iv.Feedback, time.Now().UTC().Format(time.RFC3339),
+			iv.ID,
+		)
+		if err != nil {
+			return err
+		}
+		after, err := scanInterview(tx.QueryRowContext(ctx, "SELECT "+interviewColumns+" FROM interviews WHERE id = ?", iv.ID))
+		if err != nil {
+			return err
+		}
+		updated = &after
+		return recordAudit(ctx, tx, model.AuditUpdate, auditInterview, iv.ID, existing, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *Store) DeleteInterview(ctx context.Context, appID, id string) (bool, error) {
+	deleted := false
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		existing, err := findInterview(ctx, tx, appID, id)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM interviews WHERE id = ?", id); err != nil {
+			return err
+		}
+		deleted = true
+		return recordAudit(ctx, tx, model.AuditDelete, auditInterview, id, existing, nil)
+	})
+	return deleted, err
+}
+
+// Agenda returns interviews across all applications starting in [from, to),
+// in start order. Both bounds are RFC3339 UTC; an empty to means no upper
+// bound.
+func (s *Store) Agenda(ctx context.Context, from, to string) ([]model.ScheduledInterview, error) {
+	query := "SELECT " + prefixColumns("i", interviewColumns) + ", a.company, a.role, a.status" +
+		" FROM interviews i JOIN applications a ON a.id = i.application_id" +
+		" WHERE a.deleted_at = '' AND i.starts_at >= ?"
+	args := []interface{}{from}
+	owned, ownerArgs := ownedBy(ctx, "i.owner_id")
+	query += owned
+	args = append(args, ownerArgs...)
+	if to != "" {
+		query += " AND i.starts_at < ?"
+		args = append(args, to)
+	}
+	query += " ORDER BY i.starts_at, i.id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	agenda := []model.ScheduledInterview{}
+	for rows.Next() {
+		var si model.ScheduledInterview
+		si.Interview, err = scanInterview(rows, &si.Company, &si.Role, &si.Status)
+		if err != nil {
+			return nil, err
+		}
+		agenda = append(agenda, si)
+	}
+	return agenda, rows.Err()
+}
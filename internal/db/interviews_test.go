@@ -0,0 +1,71 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestAgendaRangeAndCascade(t *testing.T) {
+	store := setupFileStore(t)
+	app := createTestApp(t, store)
+	other, err := store.Create(ctx, model.CreateRequest{Company: "Globex", Role: "SRE"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	for _, iv := range []model.Interview{
+		{ApplicationID: app.ID, StartsAt: "2026-03-03T09:00:00Z", Format: "onsite", Interviewers: []string{"Ana", "Ben"}},
+		{ApplicationID: other.ID, StartsAt: "2026-03-02T15:00:00Z", Format: "phone"},
+		{ApplicationID: app.ID, StartsAt: "2026-03-10T09:00:00Z", Format: "video"},
+	} {
+		iv.Timezone, iv.Outcome = "UTC", "pending"
+		if _, err := store.CreateInterview(ctx, iv); err != nil {
+			t.Fatalf("CreateInterview failed: %v", err)
+		}
+	}
+
+	agenda, err := store.Agenda(ctx, "2026-03-01T00:00:00Z", "2026-03-04T00:00:00Z")
+	if err != nil {
+		t.Fatalf("Agenda failed: %v", err)
+	}
+	if len(agenda) != 2 || agenda[0].Company != "Globex" || agenda[1].Company != "TestCo" {
+		t.Fatalf("expected Globex then TestCo in range, got %+v", agenda)
+	}
+	if len(agenda[1].Interviewers) != 2 || agenda[1].Interviewers[1] != "Ben" {
+		t.Fatalf("expected interviewers round-trip, got %v", agenda[1].Interviewers)
+	}
+
+	open, err := store.Agenda(ctx, "2026-03-05T00:00:00Z", "")
+	if err != nil || len(open) != 1 {
+		t.Fatalf("expected 1 interview with open upper bound, got %d (%v)", len(open), err)
+	}
+
+	if _, err := store.Delete(ctx, app.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if agenda, _ := store.Agenda(ctx, "2026-03-01T00:00:00Z", ""); len(agenda) != 1 || agenda[0].Company != "Globex" {
+		t.Fatalf("expected trashed application's interviews left out of the agenda, got %+v", agenda)
+	}
+	if _, err := store.Purge(ctx, app.ID); err != nil {
+		t.Fatalf("Purge failed: %v", err)
+	}
+	interviews, _ := store.ListInterviews(ctx, app.ID)
+	if len(interviews) != 0 {
+		t.Fatalf("expected interviews deleted with application, got %+v", interviews)
+	}
+}
+
+func TestGetInterviewWrongApplication(t *testing.T) {
+	store := setupFileStore(t)
+	app := createTestApp(t, store)
+	other := createTestApp(t, store)
+	iv, err := store.CreateInterview(ctx, model.Interview{ApplicationID: app.ID, StartsAt: "2026-03-03T09:00:00Z", Format: "phone", Timezone: "UTC", Outcome: "pending"})
+	if err != nil {
+		t.Fatalf("CreateInterview failed: %v", err)
+	}
+	got, err := store.GetInterview(ctx, other.ID, iv.ID)
+	if err != nil || got != nil {
+		t.Fatalf("expected nil, nil for another application's interview, got %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,860 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSchemaTooNew is returned when the database has migrations applied that
+// this binary does not know about. Running against it could silently drop or
+// misread data, so the store refuses to open.
+var ErrSchemaTooNew = errors.New("database schema is newer than this binary")
+
+// migration is a single numbered schema step. Versions must be contiguous and
+// start at 1; up and down may contain several statements.
+type migration struct {
+	version int
+	name    string
+	up      string
+	down    string
+}
+
+// migrations is the ordered schema history. Append new steps to the end and
+// never edit one that has shipped — existing databases have already run it.
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "create_applications",
+		// IF NOT EXISTS lets databases created before versioning adopt this step
+		// without touching their existing rows.
+		up: `CREATE TABLE IF NOT EXISTS applications (
+			id         TEXT PRIMARY KEY,
+			company    TEXT NOT NULL,
+			role       TEXT NOT NULL,
+			url        TEXT DEFAULT '',
+			salary_min INTEGER DEFAULT 0,
+			salary_max INTEGER DEFAULT 0,
+			location   TEXT DEFAULT '',
+			status     TEXT NOT NULL DEFAULT 'wishlist',
+			notes      TEXT DEFAULT '',
+			applied_at TEXT DEFAULT '',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		)`,
+		down: `DROP TABLE IF EXISTS applications`,
+	},
+	{
+		version: 2,
+		name:    "create_status_history",
+		// Existing applications get a single entry for their current status so
+		// every timeline has a starting point.
+		up: `CREATE TABLE status_history (
+			id             INTEGER PRIMARY KEY AUTOINCREMENT,
+			application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+			from_status    TEXT NOT NULL DEFAULT '',
+			to_status      TEXT NOT NULL,
+			note           TEXT NOT NULL DEFAULT '',
+			changed_at     TEXT NOT NULL
+		);
+		CREATE INDEX idx_status_history_application ON status_history(application_id, changed_at);
+		INSERT INTO status_history (application_id, from_status, to_status, changed_at)
+			SELECT id, '', status, updated_at FROM applications;`,
+		down: `DROP TABLE IF EXISTS status_history`,
+	},
+	{
+		version: 3,
+		name:    "create_stages",
+		// Seeds the nine statuses and transitions the API shipped with. Any
+		// other status already stored is added as an active stage so existing
+		// rows stay valid.
+		up: `CREATE TABLE stages (
+			name       TEXT PRIMARY KEY,
+			label      TEXT NOT NULL,
+			position   INTEGER NOT NULL,
+			terminal   INTEGER NOT NULL DEFAULT 0,
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		);
+		CREATE TABLE stage_transitions (
+			from_stage TEXT NOT NULL REFERENCES stages(name) ON DELETE CASCADE,
+			to_stage   TEXT NOT NULL REFERENCES stages(name) ON DELETE CASCADE,
+			PRIMARY KEY (from_stage, to_stage)
+		);
+		INSERT INTO stages (name, label, position, terminal, created_at, updated_at) VALUES
+			('wishlist',     'Wishlist',     10, 0, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+			('applied',      'Applied',      20, 0, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+			('phone_screen', 'Phone Screen', 30, 0, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+			('interview',    'Interview',    40, 0, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+			('offer',        'Offer',        50, 0, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+			('accepted',     'Accepted',     60, 1, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+			('rejected',     'Rejected',     70, 1, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+			('withdrawn',    'Withdrawn',    80, 1, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+			('ghosted',      'Ghosted',      90, 0, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now'));
+		INSERT INTO stage_transitions (from_stage, to_stage) VALUES
+			('wishlist', 'applied'), ('wishlist', 'withdrawn'),
+			('applied', 'phone_screen'), ('applied', 'interview'), ('applied', 'offer'),
+			('applied', 'rejected'), ('applied', 'withdrawn'), ('applied', 'ghosted'),
+			('phone_screen', 'interview'), ('phone_screen', 'offer'), ('phone_screen', 'rejected'),
+			('phone_screen', 'withdrawn'), ('phone_screen', 'ghosted'),
+			('interview', 'offer'), ('interview', 'rejected'), ('interview', 'withdrawn'), ('interview', 'ghosted'),
+			('offer', 'accepted'), ('offer', 'rejected'), ('offer', 'withdrawn'),
+			('ghosted', 'phone_screen'), ('ghosted', 'interview'), ('ghosted', 'offer'),
+			('ghosted', 'rejected'), ('ghosted', 'withdrawn');
+		INSERT OR IGNORE INTO stages (name, label, position, terminal, created_at, updated_at)
+			SELECT DISTINCT status, status, 1000, 0, strftime('%Y-%m-%dT%H:%M:%SZ', 'now'), strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
+			FROM applications WHERE status <> '';`,
+		down: `DROP TABLE IF EXISTS stage_transitions;
+		DROP TABLE IF EXISTS stages;`,
+	},
+	{
+		version: 4,
+		name:    "create_applications_fts",
+		// External-content FTS5 index over the searchable text columns, kept in
+		// sync by triggers and backfilled with 'rebuild'.
+		up: `CREATE VIRTUAL TABLE applications_fts USING fts5(
+			company, role, location, notes,
+			content='applications', content_rowid='rowid',
+			tokenize='unicode61 remove_diacritics 2'
+		);
+		CREATE TRIGGER applications_fts_ai AFTER INSERT ON applications BEGIN
+			INSERT INTO applications_fts (rowid, company, role, location, notes)
+			VALUES (new.rowid, new.company, new.role, new.location, new.notes);
+		END;
+		CREATE TRIGGER applications_fts_ad AFTER DELETE ON applications BEGIN
+			INSERT INTO applications_fts (applications_fts, rowid, company, role, location, notes)
+			VALUES ('delete', old.rowid, old.company, old.role, old.location, old.notes);
+		END;
+		CREATE TRIGGER applications_fts_au AFTER UPDATE OF company, role, location, notes ON applications BEGIN
+			INSERT INTO applications_fts (applications_fts, rowid, company, role, location, notes)
+			VALUES ('delete', old.rowid, old.company, old.role, old.location, old.notes);
+			INSERT INTO applications_fts (rowid, company, role, location, notes)
+			VALUES (new.rowid, new.company, new.role, new.location, new.notes);
+		END;
+		INSERT INTO applications_fts (applications_fts) VALUES ('rebuild');`,
+		down: `DROP TRIGGER IF EXISTS applications_fts_au;
+		DROP TRIGGER IF EXISTS applications_fts_ad;
+		DROP TRIGGER IF EXISTS applications_fts_ai;
+		DROP TABLE IF EXISTS applications_fts;`,
+	},
+	{
+		version: 5,
+		name:    "create_contacts",
+		// A contact can be linked to the same application more than once with
+		// different relationships, e.g. recruiter and interviewer.
+		up: `CREATE TABLE contacts (
+			id           TEXT PRIMARY KEY,
+			name         TEXT NOT NULL,
+			email        TEXT NOT NULL DEFAULT '',
+			phone        TEXT NOT NULL DEFAULT '',
+			linkedin_url TEXT NOT NULL DEFAULT '',
+			company      TEXT NOT NULL DEFAULT '',
+			role         TEXT NOT NULL DEFAULT '',
+			created_at   TEXT NOT NULL,
+			updated_at   TEXT NOT NULL
+		);
+		CREATE INDEX idx_contacts_company ON contacts (company COLLATE NOCASE);
+		CREATE TABLE application_contacts (
+			application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+			contact_id     TEXT NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+			relationship   TEXT NOT NULL,
+			created_at     TEXT NOT NULL,
+			PRIMARY KEY (application_id, contact_id, relationship)
+		);
+		CREATE INDEX idx_application_contacts_contact ON application_contacts (contact_id);`,
+		down: `DROP TABLE IF EXISTS application_contacts;
+		DROP TABLE IF EXISTS contacts;`,
+	},
+	{
+		version: 6,
+		name:    "create_interviews",
+		// starts_at and ends_at are RFC3339 UTC so range queries can compare
+		// strings; timezone is the IANA zone the interview was scheduled in.
+		up: `CREATE TABLE interviews (
+			id             TEXT PRIMARY KEY,
+			application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+			starts_at      TEXT NOT NULL,
+			ends_at        TEXT NOT NULL DEFAULT '',
+			timezone       TEXT NOT NULL DEFAULT 'UTC',
+			format         TEXT NOT NULL,
+			interviewers   TEXT NOT NULL DEFAULT '[]',
+			location       TEXT NOT NULL DEFAULT '',
+			outcome        TEXT NOT NULL DEFAULT 'pending',
+			feedback       TEXT NOT NULL DEFAULT '',
+			created_at     TEXT NOT NULL,
+			updated_at     TEXT NOT NULL
+		);
+		CREATE INDEX idx_interviews_application ON interviews (application_id, starts_at);
+		CREATE INDEX idx_interviews_starts_at ON interviews (starts_at);`,
+		down: `DROP TABLE IF EXISTS interviews;`,
+	},
+	{
+		version: 7,
+		name:    "create_reminders",
+		// kind is 'follow_up' for reminders the store creates when an
+		// application enters applied, 'custom' for ones added through the API.
+		up: `CREATE TABLE reminders (
+			id             TEXT PRIMARY KEY,
+			application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+			kind           TEXT NOT NULL DEFAULT 'custom',
+			due_at         TEXT NOT NULL,
+			message        TEXT NOT NULL,
+			done           INTEGER NOT NULL DEFAULT 0,
+			completed_at   TEXT NOT NULL DEFAULT '',
+			created_at     TEXT NOT NULL,
+			updated_at     TEXT NOT NULL
+		);
+		CREATE INDEX idx_reminders_due ON reminders (done, due_at);
+		CREATE INDEX idx_reminders_application ON reminders (application_id, due_at);`,
+		down: `DROP TABLE IF EXISTS reminders;`,
+	},
+	{
+		version: 8,
+		name:    "add_applications_stale_at",
+		// Set by the stale sweep in flag mode; any user update clears it.
+		up:   `ALTER TABLE applications ADD COLUMN stale_at TEXT NOT NULL DEFAULT ''`,
+		down: `ALTER TABLE applications DROP COLUMN stale_at`,
+	},
+	{
+		version: 9,
+		name:    "create_tags",
+		up: `CREATE TABLE tags (
+			name       TEXT PRIMARY KEY,
+			created_at TEXT NOT NULL
+		);
+		CREATE TABLE application_tags (
+			application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+			tag            TEXT NOT NULL REFERENCES tags(name) ON DELETE CASCADE,
+			created_at     TEXT NOT NULL,
+			PRIMARY KEY (application_id, tag)
+		);
+		CREATE INDEX idx_application_tags_tag ON application_tags (tag, application_id);`,
+		down: `DROP TABLE IF EXISTS application_tags;
+		DROP TABLE IF EXISTS tags;`,
+	},
+	{
+		version: 10,
+		name:    "create_attachments",
+		up: `CREATE TABLE attachment_blobs (
+			sha256     TEXT PRIMARY KEY,
+			size       INTEGER NOT NULL,
+			data       BLOB NOT NULL,
+			created_at TEXT NOT NULL
+		);
+		CREATE TABLE attachments (
+			id             TEXT PRIMARY KEY,
+			application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+			kind           TEXT NOT NULL,
+			filename       TEXT NOT NULL,
+			content_type   TEXT NOT NULL,
+			size           INTEGER NOT NULL,
+			sha256         TEXT NOT NULL REFERENCES attachment_blobs(sha256),
+			created_at     TEXT NOT NULL
+		);
+		CREATE INDEX idx_attachments_application ON attachments (application_id, created_at);
+		CREATE INDEX idx_attachments_sha256 ON attachments (sha256);
+		CREATE TRIGGER attachments_ad AFTER DELETE ON attachments
+		WHEN NOT EXISTS (SELECT 1 FROM attachments WHERE sha256 = old.sha256)
+		BEGIN
+			DELETE FROM attachment_blobs WHERE sha256 = old.sha256;
+		END;`,
+		down: `DROP TRIGGER IF EXISTS attachments_ad;
+		DROP TABLE IF EXISTS attachments;
+		DROP TABLE IF EXISTS attachment_blobs;`,
+	},
+	{
+		version: 11,
+		name:    "add_deleted_at",
+		up: `ALTER TABLE applications ADD COLUMN deleted_at TEXT NOT NULL DEFAULT '';
+		CREATE INDEX idx_applications_deleted_at ON applications (deleted_at);`,
+		down: `DROP INDEX IF EXISTS idx_applications_deleted_at;
+		ALTER TABLE applications DROP COLUMN deleted_at;`,
+	},
+	{
+		version: 12,
+		name:    "create_audit_log",
+		// Append-only: the triggers reject any change to recorded entries.
+		up: `CREATE TABLE audit_log (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			created_at  TEXT NOT NULL,
+			actor       TEXT NOT NULL,
+			request_id  TEXT NOT NULL,
+			action      TEXT NOT NULL,
+			entity_type TEXT NOT NULL,
+			entity_id   TEXT NOT NULL,
+			before      TEXT NOT NULL,
+			after       TEXT NOT NULL
+		);
+		CREATE INDEX idx_audit_log_entity ON audit_log (entity_id, id);
+		CREATE INDEX idx_audit_log_created_at ON audit_log (created_at);
+		CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log
+		BEGIN
+			SELECT RAISE(ABORT, 'audit_log is append-only');
+		END;
+		CREATE TRIGGER audit_log_no_delete BEFORE DELETE ON audit_log
+		BEGIN
+			SELECT RAISE(ABORT, 'audit_log is append-only');
+		END;`,
+		down: `DROP TABLE IF EXISTS audit_log;`,
+	},
+	{
+		version: 13,
+		name:    "create_api_keys",
+		// Only the SHA256 of each secret is stored.
+		up: `CREATE TABLE api_keys (
+			id           TEXT PRIMARY KEY,
+			name         TEXT NOT NULL UNIQUE,
+			prefix       TEXT NOT NULL,
+			key_hash     TEXT NOT NULL UNIQUE,
+			scope        TEXT NOT NULL,
+			created_at   TEXT NOT NULL,
+			last_used_at TEXT NOT NULL DEFAULT '',
+			revoked_at   TEXT NOT NULL DEFAULT ''
+		);`,
+		down: `DROP TABLE IF EXISTS api_keys;`,
+	},
+	{
+		version: 14,
+		name:    "create_users",
+		// Sessions, like API keys, are stored as the SHA256 of their token.
+		// Keys created before accounts existed have no user until one adopts
+		// them.
+		up: `CREATE TABLE users (
+			id            TEXT PRIMARY KEY,
+			email         TEXT NOT NULL UNIQUE COLLATE NOCASE,
+			password_hash TEXT NOT NULL,
+			created_at    TEXT NOT NULL
+		);
+		CREATE TABLE sessions (
+			token_hash TEXT PRIMARY KEY,
+			user_id    TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			created_at TEXT NOT NULL,
+			expires_at TEXT NOT NULL
+		);
+		CREATE INDEX idx_sessions_user ON sessions (user_id);
+		ALTER TABLE api_keys ADD COLUMN user_id TEXT NOT NULL DEFAULT '';`,
+		down: `ALTER TABLE api_keys DROP COLUMN user_id;
+		DROP TABLE IF EXISTS sessions;
+		DROP TABLE IF EXISTS users;`,
+	},
+	{
+		version: 15,
+		name:    "add_owner_ids",
+		// Existing rows are left unowned ('') until the first user adopts
+		// them. Tag names become per owner, which needs the two tag tables
+		// rebuilt; the rename carries the foreign key over to the new tags.
+		// Unowned audit entries may be given an owner once; nothing else about
+		// them can change. API key names become unique per user, whose ID
+		// api_keys already holds in user_id.
+		up: `ALTER TABLE applications ADD COLUMN owner_id TEXT NOT NULL DEFAULT '';
+		CREATE INDEX idx_applications_owner ON applications (owner_id, updated_at);
+		ALTER TABLE status_history ADD COLUMN owner_id TEXT NOT NULL DEFAULT '';
+		ALTER TABLE contacts ADD COLUMN owner_id TEXT NOT NULL DEFAULT '';
+		CREATE INDEX idx_contacts_owner ON contacts (owner_id);
+		ALTER TABLE application_contacts ADD COLUMN owner_id TEXT NOT NULL DEFAULT '';
+		ALTER TABLE interviews ADD COLUMN owner_id TEXT NOT NULL DEFAULT '';
+		CREATE INDEX idx_interviews_owner ON interviews (owner_id, starts_at);
+		ALTER TABLE reminders ADD COLUMN owner_id TEXT NOT NULL DEFAULT '';
+		CREATE INDEX idx_reminders_owner ON reminders (owner_id, done, due_at);
+		ALTER TABLE attachments ADD COLUMN owner_id TEXT NOT NULL DEFAULT '';
+		ALTER TABLE audit_log ADD COLUMN owner_id TEXT NOT NULL DEFAULT '';
+		CREATE INDEX idx_audit_log_owner ON audit_log (owner_id, id);
+		DROP TRIGGER audit_log_no_update;
+		CREATE TRIGGER audit_log_no_update BEFORE UPDATE OF id, created_at, actor, request_id, action, entity_type, entity_id, before, after ON audit_log
+		BEGIN
+			SELECT RAISE(ABORT, 'audit_log is append-only');
+		END;
+		CREATE TRIGGER audit_log_adopt_once BEFORE UPDATE OF owner_id ON audit_log WHEN old.owner_id <> ''
+		BEGIN
+			SELECT RAISE(ABORT, 'audit_log is append-only');
+		END;
+
+		CREATE TABLE owned_tags (
+			owner_id   TEXT NOT NULL DEFAULT '',
+			name       TEXT NOT NULL,
+			created_at TEXT NOT NULL,
+			PRIMARY KEY (owner_id, name)
+		);
+		INSERT INTO owned_tags (name, created_at) SELECT name, created_at FROM tags;
+		CREATE TABLE owned_application_tags (
+			application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+			owner_id       TEXT NOT NULL DEFAULT '',
+			tag            TEXT NOT NULL,
+			created_at     TEXT NOT NULL,
+			PRIMARY KEY (application_id, tag),
+			FOREIGN KEY (owner_id, tag) REFERENCES owned_tags (owner_id, name) ON DELETE CASCADE ON UPDATE CASCADE
+		);
+		INSERT INTO owned_application_tags (application_id, tag, created_at)
+			SELECT application_id, tag, created_at FROM application_tags;
+		DROP TABLE application_tags;
+		DROP TABLE tags;
+		ALTER TABLE owned_tags RENAME TO tags;
+		ALTER TABLE owned_application_tags RENAME TO application_tags;
+		CREATE INDEX idx_application_tags_tag ON application_tags (owner_id, tag, application_id);
+
+		CREATE TABLE owned_api_keys (
+			id           TEXT PRIMARY KEY,
+			user_id      TEXT NOT NULL DEFAULT '',
+			name         TEXT NOT NULL,
+			prefix       TEXT NOT NULL,
+			key_hash     TEXT NOT NULL UNIQUE,
+			scope        TEXT NOT NULL,
+			created_at   TEXT NOT NULL,
+			last_used_at TEXT NOT NULL DEFAULT '',
+			revoked_at   TEXT NOT NULL DEFAULT '',
+			UNIQUE (user_id, name)
+		);
+		INSERT INTO owned_api_keys (id, user_id, name, prefix, key_hash, scope, created_at, last_used_at, revoked_at)
+			SELECT id, user_id, name, prefix, key_hash, scope, created_at, last_used_at, revoked_at FROM api_keys;
+		DROP TABLE api_keys;
+		ALTER TABLE owned_api_keys RENAME TO api_keys;`,
+		// Rolling back merges tags of the same name across owners, and gives
+		// API keys whose name another user's older key has the ID as a
+		// suffix.
+		down: `CREATE TABLE shared_api_keys (
+			id           TEXT PRIMARY KEY,
+			name         TEXT NOT NULL UNIQUE,
+			prefix       TEXT NOT NULL,
+			key_hash     TEXT NOT NULL UNIQUE,
+			scope        TEXT NOT NULL,
+			created_at   TEXT NOT NULL,
+			last_used_at TEXT NOT NULL DEFAULT '',
+			revoked_at   TEXT NOT NULL DEFAULT '',
+			user_id      TEXT NOT NULL DEFAULT ''
+		);
+		INSERT INTO shared_api_keys (id, name, prefix, key_hash, scope, created_at, last_used_at, revoked_at, user_id)
+			SELECT k.id,
+				CASE WHEN EXISTS (SELECT 1 FROM api_keys o WHERE o.name = k.name AND (o.created_at, o.id) < (k.created_at, k.id))
+					THEN k.name || ' ' || k.id ELSE k.name END,
+				k.prefix, k.key_hash, k.scope, k.created_at, k.last_used_at, k.revoked_at, k.user_id
+			FROM api_keys k;
+		DROP TABLE api_keys;
+		ALTER TABLE shared_api_keys RENAME TO api_keys;
+
+		CREATE TABLE shared_tags (
+			name       TEXT PRIMARY KEY,
+			created_at TEXT NOT NULL
+		);
+		INSERT INTO shared_tags (name, created_at) SELECT name, MIN(created_at) FROM tags GROUP BY name;
+		CREATE TABLE shared_application_tags (
+			application_id TEXT NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+			tag            TEXT NOT NULL REFERENCES shared_tags(name) ON DELETE CASCADE,
+			created_at     TEXT NOT NULL,
+			PRIMARY KEY (application_id, tag)
+		);
+		INSERT INTO shared_application_tags (application_id, tag, created_at)
+			SELECT application_id, tag, created_at FROM application_tags;
+		DROP TABLE application_tags;
+		DROP TABLE tags;
+		ALTER TABLE shared_tags RENAME TO tags;
+		ALTER TABLE shared_application_tags RENAME TO application_tags;
+		CREATE INDEX idx_application_tags_tag ON application_tags (tag, application_id);
+
+		DROP TRIGGER audit_log_adopt_once;
+		DROP TRIGGER audit_log_no_update;
+		CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log
+		BEGIN
+			SELECT RAISE(ABORT, 'audit_log is append-only');
+		END;
+		DROP INDEX IF EXISTS idx_audit_log_owner;
+		ALTER TABLE audit_log DROP COLUMN owner_id;
+		ALTER TABLE attachments DROP COLUMN owner_id;
+		DROP INDEX IF EXISTS idx_reminders_owner;
+		ALTER TABLE reminders DROP COLUMN owner_id;
+		DROP INDEX IF EXISTS idx_interviews_owner;
+		ALTER TABLE interviews DROP COLUMN owner_id;
+		ALTER TABLE application_contacts DROP COLUMN owner_id;
+		DROP INDEX IF EXISTS idx_contacts_owner;
+		ALTER TABLE contacts DROP COLUMN owner_id;
+		ALTER TABLE status_history DROP COLUMN owner_id;
+		DROP INDEX IF EXISTS idx_applications_owner;
+		ALTER TABLE applications DROP COLUMN owner_id;`,
+	},
+	{
+		version: 16,
+		name:    "create_shares",
+		// Share tokens are not stored: they are signed with the key in
+		// settings, generated here once per database. application_id is NULL
+		// for a view share.
+		up: `CREATE TABLE settings (
+			name  TEXT PRIMARY KEY,
+			value TEXT NOT NULL
+		);
+		INSERT INTO settings (name, value) VALUES ('share_signing_key', lower(hex(randomblob(32))));
+		CREATE TABLE shares (
+			id             TEXT PRIMARY KEY,
+			owner_id       TEXT NOT NULL DEFAULT '',
+			kind           TEXT NOT NULL,
+			application_id TEXT REFERENCES applications(id) ON DELETE CASCADE,
+			query          TEXT NOT NULL DEFAULT '',
+			private        TEXT NOT NULL DEFAULT '[]',
+			created_at     TEXT NOT NULL,
+			expires_at     TEXT NOT NULL
+		);
+		CREATE INDEX idx_shares_owner ON shares (owner_id, created_at);
+		CREATE INDEX idx_shares_application ON shares (application_id);`,
+		down: `DROP TABLE IF EXISTS shares;
+		DROP TABLE IF EXISTS settings;`,
+	},
+	{
+		version: 17,
+		name:    "add_application_versions",
+		// version is bumped by every change to an application's JSON form
+		// and served as its ETag.
+		up:   `ALTER TABLE applications ADD COLUMN version INTEGER NOT NULL DEFAULT 1;`,
+		down: `ALTER TABLE applications DROP COLUMN version;`,
+	},
+	{
+		version: 18,
+		name:    "create_idempotency_keys",
+		// A row with status 0 is a request still being served. Keys are per
+		// owner, so two users cannot collide or see each other's responses.
+		up: `CREATE TABLE idempotency_keys (
+			owner_id     TEXT NOT NULL DEFAULT '',
+			key          TEXT NOT NULL,
+			request_hash TEXT NOT NULL,
+			status       INTEGER NOT NULL DEFAULT 0,
+			header       TEXT NOT NULL DEFAULT '{}',
+			body         BLOB NOT NULL DEFAULT x'',
+			created_at   TEXT NOT NULL,
+			expires_at   TEXT NOT NULL,
+			PRIMARY KEY (owner_id, key)
+		);
+		CREATE INDEX idx_idempotency_keys_expires ON idempotency_keys (expires_at);`,
+		down: `DROP TABLE IF EXISTS idempotency_keys;`,
+	},
+	{
+		version: 19,
+		name:    "add_stage_owners",
+		// The existing pipeline becomes the template owned by ''. Owners read
+		// it until they first change a stage, which copies it to them.
+		up: `CREATE TABLE owned_stages (
+			owner_id   TEXT NOT NULL DEFAULT '',
+			name       TEXT NOT NULL,
+			label      TEXT NOT NULL,
+			position   INTEGER NOT NULL,
+			terminal   INTEGER NOT NULL DEFAULT 0,
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL,
+			PRIMARY KEY (owner_id, name)
+		);
+		INSERT INTO owned_stages (name, label, position, terminal, created_at, updated_at)
+			SELECT name, label, position, terminal, created_at, updated_at FROM stages;
+		CREATE TABLE owned_stage_transitions (
+			owner_id   TEXT NOT NULL DEFAULT '',
+			from_stage TEXT NOT NULL,
+			to_stage   TEXT NOT NULL,
+			PRIMARY KEY (owner_id, from_stage, to_stage),
+			FOREIGN KEY (owner_id, from_stage) REFERENCES owned_stages (owner_id, name) ON DELETE CASCADE,
+			FOREIGN KEY (owner_id, to_stage) REFERENCES owned_stages (owner_id, name) ON DELETE CASCADE
+		);
+		INSERT INTO owned_stage_transitions (from_stage, to_stage) SELECT from_stage, to_stage FROM stage_transitions;
+		DROP TABLE stage_transitions;
+		DROP TABLE stages;
+		ALTER TABLE owned_stages RENAME TO stages;
+		ALTER TABLE owned_stage_transitions RENAME TO stage_transitions;`,
+		// Rolling back keeps only the template; owners' own pipelines are lost.
+		down: `CREATE TABLE shared_stages (
+			name       TEXT PRIMARY KEY,
+			label      TEXT NOT NULL,
+			position   INTEGER NOT NULL,
+			terminal   INTEGER NOT NULL DEFAULT 0,
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		);
+		INSERT INTO shared_stages SELECT name, label, position, terminal, created_at, updated_at FROM stages WHERE owner_id = '';
+		CREATE TABLE shared_stage_transitions (
+			from_stage TEXT NOT NULL REFERENCES shared_stages(name) ON DELETE CASCADE,
+			to_stage   TEXT NOT NULL REFERENCES shared_stages(name) ON DELETE CASCADE,
+			PRIMARY KEY (from_stage, to_stage)
+		);
+		INSERT INTO shared_stage_transitions SELECT from_stage, to_stage FROM stage_transitions WHERE owner_id = '';
+		DROP TABLE stage_transitions;
+		DROP TABLE stages;
+		ALTER TABLE shared_stages RENAME TO stages;
+		ALTER TABLE shared_stage_transitions RENAME TO stage_transitions;`,
+	},
+	{
+		version: 20,
+		name:    "key_applications_fts_by_id",
+		// Step 4 keyed the index on applications' implicit rowid, which VACUUM
+		// may renumber on a TEXT-keyed table. The index now stores its own
+		// copy of the text with the application ID, kept by the same triggers.
+		up: `DROP TRIGGER applications_fts_au;
+		DROP TRIGGER applications_fts_ad;
+		DROP TRIGGER applications_fts_ai;
+		DROP TABLE applications_fts;
+		CREATE VIRTUAL TABLE applications_fts USING fts5(
+			id UNINDEXED, company, role, location, notes,
+			tokenize='unicode61 remove_diacritics 2'
+		);
+		CREATE TRIGGER applications_fts_ai AFTER INSERT ON applications BEGIN
+			INSERT INTO applications_fts (id, company, role, location, notes)
+			VALUES (new.id, new.company, new.role, new.location, new.notes);
+		END;
+		CREATE TRIGGER applications_fts_ad AFTER DELETE ON applications BEGIN
+			DELETE FROM applications_fts WHERE id = old.id;
+		END;
+		CREATE TRIGGER applications_fts_au AFTER UPDATE OF id, company, role, location, notes ON applications BEGIN
+			DELETE FROM applications_fts WHERE id = old.id;
+			INSERT INTO applications_fts (id, company, role, location, notes)
+			VALUES (new.id, new.company, new.role, new.location, new.notes);
+		END;
+		INSERT INTO applications_fts (id, company, role, location, notes)
+			SELECT id, company, role, location, notes FROM applications;`,
+		down: `DROP TRIGGER applications_fts_au;
+		DROP TRIGGER applications_fts_ad;
+		DROP TRIGGER applications_fts_ai;
+		DROP TABLE applications_fts;
+		CREATE VIRTUAL TABLE applications_fts USING fts5(
+			company, role, location, notes,
+			content='applications', content_rowid='rowid',
+			tokenize='unicode61 remove_diacritics 2'
+		);
+		CREATE TRIGGER applications_fts_ai AFTER INSERT ON applications BEGIN
+			INSERT INTO applications_fts (rowid, company, role, location, notes)
+			VALUES (new.rowid, new.company, new.role, new.location, new.notes);
+		END;
+		CREATE TRIGGER applications_fts_ad AFTER DELETE ON applications BEGIN
+			INSERT INTO applications_fts (applications_fts, rowid, company, role, location, notes)
+			VALUES ('delete', old.rowid, old.company, old.role, old.location, old.notes);
+		END;
+		CREATE TRIGGER applications_fts_au AFTER UPDATE OF company, role, location, notes ON applications BEGIN
+			INSERT INTO applications_fts (applications_fts, rowid, company, role, location, notes)
+			VALUES ('delete', old.rowid, old.company, old.role, old.location, old.notes);
+			INSERT INTO applications_fts (rowid, company, role, location, notes)
+			VALUES (new.rowid, new.company, new.role, new.location, new.notes);
+		END;
+		INSERT INTO applications_fts (applications_fts) VALUES ('rebuild');`,
+	},
+	{
+		version: 21,
+		name:    "add_search_rowid",
+		// Step 20 could only find an application's index entry by scanning
+		// its UNINDEXED id. Each application now gets a stable integer
+		// search_rowid on insert, a real column that VACUUM leaves alone, and
+		// the index goes back to external content keyed by it. New values
+		// come from MAX + 1 rather than the implicit rowid, which could
+		// collide once renumbered.
+		up: `ALTER TABLE applications ADD COLUMN search_rowid INTEGER;
+		UPDATE applications SET search_rowid = rowid;
+		CREATE UNIQUE INDEX idx_applications_search_rowid ON applications (search_rowid);
+		DROP TRIGGER applications_fts_au;
+		DROP TRIGGER applications_fts_ad;
+		DROP TRIGGER applications_fts_ai;
+		DROP TABLE applications_fts;
+		CREATE VIRTUAL TABLE applications_fts USING fts5(
+			company, role, location, notes,
+			content='applications', content_rowid='search_rowid',
+			tokenize='unicode61 remove_diacritics 2'
+		);
+		CREATE TRIGGER applications_fts_ai AFTER INSERT ON applications BEGIN
+			UPDATE applications SET search_rowid = (SELECT IFNULL(MAX(search_rowid), 0) + 1 FROM applications)
+			WHERE id = new.id AND search_rowid IS NULL;
+			INSERT INTO applications_fts (rowid, company, role, location, notes)
+			SELECT search_rowid, company, role, location, notes FROM applications WHERE id = new.id;
+		END;
+		CREATE TRIGGER applications_fts_ad AFTER DELETE ON applications BEGIN
+			INSERT INTO applications_fts (applications_fts, rowid, company, role, location, notes)
+			VALUES ('delete', old.search_rowid, old.company, old.role, old.location, old.notes);
+		END;
+		CREATE TRIGGER applications_fts_au AFTER UPDATE OF company, role, location, notes ON applications BEGIN
+			INSERT INTO applications_fts (applications_fts, rowid, company, role, location, notes)
+			VALUES ('delete', old.search_rowid, old.company, old.role, old.location, old.notes);
+			INSERT INTO applications_fts (rowid, company, role, location, notes)
+			VALUES (new.search_rowid, new.company, new.role, new.location, new.notes);
+		END;
+		INSERT INTO applications_fts (applications_fts) VALUES ('rebuild');`,
+		down: `DROP TRIGGER applications_fts_au;
+		DROP TRIGGER applications_fts_ad;
+		DROP TRIGGER applications_fts_ai;
+		DROP TABLE applications_fts;
+		DROP INDEX idx_applications_search_rowid;
+		ALTER TABLE applications DROP COLUMN search_rowid;
+		CREATE VIRTUAL TABLE applications_fts USING fts5(
+			id UNINDEXED, company, role, location, notes,
+			tokenize='unicode61 remove_diacritics 2'
+		);
+		CREATE TRIGGER applications_fts_ai AFTER INSERT ON applications BEGIN
+			INSERT INTO applications_fts (id, company, role, location, notes)
+			VALUES (new.id, new.company, new.role, new.location, new.notes);
+		END;
+		CREATE TRIGGER applications_fts_ad AFTER DELETE ON applications BEGIN
+			DELETE FROM applications_fts WHERE id = old.id;
+		END;
+		CREATE TRIGGER applications_fts_au AFTER UPDATE OF id, company, role, location, notes ON applications BEGIN
+			DELETE FROM applications_fts WHERE id = old.id;
+			INSERT INTO applications_fts (id, company, role, location, notes)
+			VALUES (new.id, new.company, new.role, new.location, new.notes);
+		END;
+		INSERT INTO applications_fts (id, company, role, location, notes)
+			SELECT id, company, role, location, notes FROM applications;`,
+	},
+}
+
+// MigrationStatus describes one known migration and whether it has been applied.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt string
+}
+
+// LatestSchemaVersion returns the highest migration version this binary knows.
+func LatestSchemaVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+func ensureMigrationsTable(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TEXT NOT NULL
+	)`)
+	return err
+}
+
+// SchemaVersion returns the highest applied migration version, or 0 for a
+// database that has never been migrated.
+func (s *Store) SchemaVersion(ctx context.Context) (int, error) {
+	if err := ensureMigrationsTable(ctx, s.db); err != nil {
+		return 0, fmt.Errorf("creating schema_migrations: %w", err)
+	}
+	var version int
+	err := s.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("reading schema version: %w", err)
+	}
+	return version, nil
+}
+
+// Migrations lists every known migration along with any applied versions the
+// binary does not recognize.
+func (s *Store) Migrations(ctx context.Context) ([]MigrationStatus, error) {
+	if err := ensureMigrationsTable(ctx, s.db); err != nil {
+		return nil, fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT version, name, applied_at FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, fmt.Errorf("querying schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]MigrationStatus)
+	var unknown []MigrationStatus
+	for rows.Next() {
+		var m MigrationStatus
+		if err := rows.Scan(&m.Version, &m.Name, &m.AppliedAt); err != nil {
+			return nil, fmt.Errorf("scanning schema_migrations: %w", err)
+		}
+		m.Applied = true
+		applied[m.Version] = m
+		if m.Version > LatestSchemaVersion() {
+			unknown = append(unknown, m)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating schema_migrations: %w", err)
+	}
+
+	var statuses []MigrationStatus
+	for _, m := range migrations {
+		status := MigrationStatus{Version: m.version, Name: m.name}
+		if a, ok := applied[m.version]; ok {
+			status.Applied = true
+			status.AppliedAt = a.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	statuses = append(statuses, unknown...)
+	return statuses, nil
+}
+
+// MigrateUp applies every pending migration in order, each in its own
+// transaction, and returns how many were applied. It fails with
+// ErrSchemaTooNew if the database is ahead of this binary.
+func (s *Store) MigrateUp(ctx context.Context) (int, error) {
+	current, err := s.SchemaVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if current > LatestSchemaVersion() {
+		return 0, fmt.Errorf("%w: database at version %d, binary supports up to %d", ErrSchemaTooNew, current, LatestSchemaVersion())
+	}
+
+	applied := 0
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err := s.applyMigration(ctx, m, true); err != nil {
+			return applied, err
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+// MigrateDown rolls back the most recent steps applied migrations, newest
+// first, and returns how many were rolled back.
+func (s *Store) MigrateDown(ctx context.Context, steps int) (int, error) {
+	current, err := s.SchemaVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if current > LatestSchemaVersion() {
+		return 0, fmt.Errorf("%w: database at version %d, binary supports up to %d", ErrSchemaTooNew, current, LatestSchemaVersion())
+	}
+
+	rolledBack := 0
+	for i := len(migrations) - 1; i >= 0 && rolledBack < steps; i-- {
+		m := migrations[i]
+		if m.version > current {
+			continue
+		}
+		if err := s.applyMigration(ctx, m, false); err != nil {
+			return rolledBack, err
+		}
+		rolledBack++
+	}
+	return rolledBack, nil
+}
+
+func (s *Store) applyMigration(ctx context.Context, m migration, up bool) error {
+	direction := "up"
+	script := m.up
+	if !up {
+		direction = "down"
+		script = m.down
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning migration %d: %w", m.version, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("migration %d (%s) %s: %w", m.version, m.name, direction, err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+			m.version, m.name, time.Now().UTC().Format(time.RFC3339),
+		)
+	} else {
+		_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", m.version)
+	}
+	if err != nil {
+		return fmt.Errorf("recording migration %d: %w", m.version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing migration %d: %w", m.version, err)
+	}
+	return nil
+}
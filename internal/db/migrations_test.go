@@ -0,0 +1,184 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestMigrateFreshDatabase(t *testing.T) {
+	store := setupTestStore(t)
+
+	version, err := store.SchemaVersion(ctx)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if version != LatestSchemaVersion() {
+		t.Fatalf("expected version %d, got %d", LatestSchemaVersion(), version)
+	}
+
+	statuses, err := store.Migrations(ctx)
+	if err != nil {
+		t.Fatalf("Migrations failed: %v", err)
+	}
+	if len(statuses) != len(migrations) {
+		t.Fatalf("expected %d statuses, got %d", len(migrations), len(statuses))
+	}
+	for _, m := range statuses {
+		if !m.Applied || m.AppliedAt == "" {
+			t.Errorf("expected migration %d to be applied, got %+v", m.Version, m)
+		}
+	}
+}
+
+func TestMigrateUpIdempotent(t *testing.T) {
+	store := setupTestStore(t)
+
+	n, err := store.MigrateUp(ctx)
+	if err != nil {
+		t.Fatalf("MigrateUp failed: %v", err)
+	}
+	if n != 0 {
+		t.Fatalf("expected 0 migrations applied on second run, got %d", n)
+	}
+}
+
+func TestMigrationsContiguous(t *testing.T) {
+	for i, m := range migrations {
+		if m.version != i+1 {
+			t.Fatalf("migration at index %d has version %d, want %d", i, m.version, i+1)
+		}
+		if m.name == "" || m.up == "" || m.down == "" {
+			t.Fatalf("migration %d is missing name, up or down", m.version)
+		}
+	}
+}
+
+func TestMigrateDownAndUp(t *testing.T) {
+	store := setupFileStore(t)
+
+	n, err := store.MigrateDown(ctx, len(migrations))
+	if err != nil {
+		t.Fatalf("MigrateDown failed: %v", err)
+	}
+	if n != len(migrations) {
+		t.Fatalf("expected %d rolled back, got %d", len(migrations), n)
+	}
+	version, err := store.SchemaVersion(ctx)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if version != 0 {
+		t.Fatalf("expected version 0 after full rollback, got %d", version)
+	}
+
+	n, err = store.MigrateUp(ctx)
+	if err != nil {
+		t.Fatalf("MigrateUp failed: %v", err)
+	}
+	if n != len(migrations) {
+		t.Fatalf("expected %d applied, got %d", len(migrations), n)
+	}
+	createTestApp(t, store)
+}
+
+func TestMigrateAdoptsLegacyDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "legacy.db")
+
+	// Reproduce the schema created before versioned migrations existed.
+	raw, err := sql.Open("sqlite", "file:"+dbPath)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	_, err = raw.Exec(`CREATE TABLE applications (
+		id TEXT PRIMARY KEY, company TEXT NOT NULL, role TEXT NOT NULL, url TEXT DEFAULT '',
+		salary_min INTEGER DEFAULT 0, salary_max INTEGER DEFAULT 0, location TEXT DEFAULT '',
+		status TEXT NOT NULL DEFAULT 'wishlist', notes TEXT DEFAULT '', applied_at TEXT DEFAULT '',
+		created_at TEXT NOT NULL, updated_at TEXT NOT NULL)`)
+	if err != nil {
+		t.Fatalf("create legacy table: %v", err)
+	}
+	_, err = raw.Exec(`INSERT INTO applications (id, company, role, status, created_at, updated_at)
+		VALUES ('abcd1234', 'LegacyCo', 'Eng', 'applied', '2025-01-01T00:00:00Z', '2025-01-01T00:00:00Z')`)
+	if err != nil {
+		t.Fatalf("insert legacy row: %v", err)
+	}
+	raw.Close()
+
+	store, err := NewStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewStore on legacy db failed: %v", err)
+	}
+	defer store.Close()
+
+	got, err := store.Get(ctx, "abcd1234")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got == nil || got.Company != "LegacyCo" {
+		t.Fatalf("expected legacy row to survive migration, got %+v", got)
+	}
+}
+
+func TestNewStoreRefusesNewerSchema(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "future.db")
+
+	store, err := NewStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	future := LatestSchemaVersion() + 1
+	_, err = store.db.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+		future, "from_the_future", "2030-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("insert future migration: %v", err)
+	}
+	store.Close()
+
+	_, err = NewStore(dbPath)
+	if !errors.Is(err, ErrSchemaTooNew) {
+		t.Fatalf("expected ErrSchemaTooNew, got %v", err)
+	}
+
+	opened, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer opened.Close()
+	statuses, err := opened.Migrations(ctx)
+	if err != nil {
+		t.Fatalf("Migrations failed: %v", err)
+	}
+	last := statuses[len(statuses)-1]
+	if last.Version != future || !last.Applied {
+		t.Fatalf("expected unknown version %d in status, got %+v", future, last)
+	}
+	if _, err := opened.MigrateDown(ctx, 1); !errors.Is(err, ErrSchemaTooNew) {
+		t.Fatalf("expected MigrateDown to refuse newer schema, got %v", err)
+	}
+}
+
+func TestMigrationFailureRollsBack(t *testing.T) {
+	store := setupFileStore(t)
+
+	bad := migration{version: LatestSchemaVersion() + 1, name: "broken", up: "CREATE TABLE ok_table (id TEXT); NOT VALID SQL", down: "DROP TABLE ok_table"}
+	if err := store.applyMigration(ctx, bad, true); err == nil {
+		t.Fatal("expected broken migration to fail")
+	}
+
+	var count int
+	err := store.db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = 'ok_table'").Scan(&count)
+	if err != nil {
+		t.Fatalf("query sqlite_master: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("expected partial migration to be rolled back, found %d tables", count)
+	}
+	version, _ := store.SchemaVersion(ctx)
+	if version != LatestSchemaVersion() {
+		t.Fatalf("expected version unchanged at %d, got %d", LatestSchemaVersion(), version)
+	}
+}
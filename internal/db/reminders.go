@@ -0,0 +1,221 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+const (
+	// DefaultFollowUpDays is how long after an application enters
+	// followUpStatus its automatic follow-up reminder falls due.
+	DefaultFollowUpDays = 7
+
+	followUpStatus = "applied"
+)
+
+const reminderColumns = "id, application_id, kind, due_at, message, done, completed_at, created_at, updated_at"
+
+func scanReminder(row scanner, extra ...any) (model.Reminder, error) {
+	var r model.Reminder
+	dest := []any{&r.ID, &r.ApplicationID, &r.Kind, &r.DueAt, &r.Message, &r.Done, &r.CompletedAt, &r.CreatedAt, &r.UpdatedAt}
+	err := row.Scan(append(dest, extra...)...)
+	return r, err
+}
+
+// SetFollowUpDays changes the delay for automatic follow-up reminders. Zero
+// turns them off.
+func (s *Store) SetFollowUpDays(days int) {
+	s.followUpDays = days
+}
+
+// onStatusEntered keeps automatic follow-ups in step with a status change
+// inside tx: entering followUpStatus schedules one (unless one is already
+// open) and entering a terminal stage completes any that are open.
+func (s *Store) onStatusEntered(ctx context.Context, tx *sql.Tx, app model.Application, terminal bool, now string) error {
+	if terminal {
+		_, err := tx.ExecContext(ctx,
+			"UPDATE reminders SET done = 1, completed_at = ?, updated_at = ? WHERE application_id = ? AND kind = ? AND done = 0",
+			now, now, app.ID, model.ReminderFollowUp,
+		)
+		return err
+	}
+	if app.Status != followUpStatus || s.followUpDays <= 0 {
+		return nil
+	}
+
+	var open int
+	err := tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM reminders WHERE application_id = ? AND kind = ? AND done = 0",
+		app.ID, model.ReminderFollowUp,
+	).Scan(&open)
+	if err != nil || open > 0 {
+		return err
+	}
+
+	entered, err := time.Parse(time.RFC3339, now)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO reminders ("+reminderColumns+", owner_id) VALUES (?, ?, ?, ?, ?, 0, '', ?, ?, "+appOwner+")",
+		generateID(), app.ID, model.ReminderFollowUp,
+		entered.AddDate(0, 0, s.followUpDays).Format(time.RFC3339),
+		fmt.Sprintf("Follow up with %s about the %s application", app.Company, app.Role),
+		now, now, app.ID,
+	)
+	return err
+}
+
+// ListReminders returns an application's reminders, open ones first, each
+// group by due time.
+func (s *Store) ListReminders(ctx context.Context, appID string) ([]model.Reminder, error) {
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+reminderColumns+" FROM reminders WHERE application_id = ?"+owned+" ORDER BY done, due_at, id",
+		append([]interface{}{appID}, ownerArgs...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := []model.Reminder{}
+	for rows.Next() {
+		r, err := scanReminder(rows)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, r)
+	}
+	return reminders, rows.Err()
+}
+
+func (s *Store) GetReminder(ctx context.Context, id string) (*model.Reminder, error) {
+	r, err := findReminder(ctx, s.db, id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// findReminder reads a reminder visible in ctx. Returns sql.ErrNoRows if
+// there is none.
+func findReminder(ctx context.Context, q rowQueryer, id string) (model.Reminder, error) {
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	return scanReminder(q.QueryRowContext(ctx,
+		"SELECT "+reminderColumns+" FROM reminders WHERE id = ?"+owned,
+		append([]interface{}{id}, ownerArgs...)...,
+	))
+}
+
+// CreateReminder adds a custom reminder. dueAt must be RFC3339 UTC.
+func (s *Store) CreateReminder(ctx context.Context, appID, dueAt, message string) (*model.Reminder, error) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	id := generateID()
+	var created model.Reminder
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO reminders ("+reminderColumns+", owner_id) VALUES (?, ?, ?, ?, ?, 0, '', ?, ?, "+appOwner+")",
+			id, appID, model.ReminderCustom, dueAt, message, now, now, appID,
+		)
+		if err != nil {
+			return err
+		}
+		created, err = scanReminder(tx.QueryRowContext(ctx, "SELECT "+reminderColumns+" FROM reminders WHERE id = ?", id))
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, model.AuditCreate, auditReminder, id, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// CompleteReminder marks a reminder done. Completing a done reminder leaves
+// its completed_at unchanged. Returns nil, nil if it does not exist.
+func (s *Store) CompleteReminder(ctx context.Context, id string) (*model.Reminder, error) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	var completed *model.Reminder
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		existing, err := findReminder(ctx, tx, id)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		completed = &existing
+		if existing.Done {
+			return nil
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE reminders SET done = 1, completed_at = ?, updated_at = ? WHERE id = ?", now, now, id); err != nil {
+			return err
+		}
+		after, err := scanReminder(tx.QueryRowContext(ctx, "SELECT "+reminderColumns+" FROM reminders WHERE id = ?", id))
+		if err != nil {
+			return err
+		}
+		completed = &after
+		return recordAudit(ctx, tx, model.AuditUpdate, auditReminder, id, existing, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return completed, nil
+}
+
+func (s *Store) DeleteReminder(ctx context.Context, id string) (bool, error) {
+	deleted := false
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		existing, err := findReminder(ctx, tx, id)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM reminders WHERE id = ?", id); err != nil {
+			return err
+		}
+		deleted = true
+		return recordAudit(ctx, tx, model.AuditDelete, auditReminder, id, existing, nil)
+	})
+	return deleted, err
+}
+
+// DueReminders returns open reminders due at or before the given RFC3339 UTC
+// time, oldest first.
+func (s *Store) DueReminders(ctx context.Context, before string) ([]model.DueReminder, error) {
+	owned, ownerArgs := ownedBy(ctx, "r.owner_id")
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+prefixColumns("r", reminderColumns)+", a.company, a.role, a.status"+
+			" FROM reminders r JOIN applications a ON a.id = r.application_id"+
+			" WHERE r.done = 0 AND a.deleted_at = '' AND r.due_at <= ?"+owned+
+			" ORDER BY r.due_at, r.id",
+		append([]interface{}{before}, ownerArgs...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	due := []model.DueReminder{}
+	for rows.Next() {
+		var d model.DueReminder
+		d.Reminder, err = scanReminder(rows, &d.Company, &d.Role, &d.Status)
+		if err != nil {
+			return nil, err
+		}
+		due = append(due, d)
+	}
+	return due, rows.Err()
+}
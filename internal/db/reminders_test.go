@@ -0,0 +1,110 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestFollowUpReminderLifecycle(t *testing.T) {
+	store := setupFileStore(t)
+
+	wish := createTestApp(t, store)
+	if reminders, _ := store.ListReminders(ctx, wish.ID); len(reminders) != 0 {
+		t.Fatalf("expected no follow-up for wishlist, got %+v", reminders)
+	}
+
+	if _, err := store.Update(ctx, wish.ID, map[string]interface{}{"status": "applied"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	reminders, err := store.ListReminders(ctx, wish.ID)
+	if err != nil {
+		t.Fatalf("ListReminders failed: %v", err)
+	}
+	if len(reminders) != 1 || reminders[0].Kind != model.ReminderFollowUp || reminders[0].Done {
+		t.Fatalf("expected one open follow-up, got %+v", reminders)
+	}
+	due, _ := time.Parse(time.RFC3339, reminders[0].DueAt)
+	if d := time.Until(due); d < 6*24*time.Hour || d > 8*24*time.Hour {
+		t.Errorf("expected follow-up due in ~7 days, got %s", reminders[0].DueAt)
+	}
+
+	// Leaving and re-entering applied does not stack a second open follow-up.
+	store.UpdateWithOptions(ctx, wish.ID, map[string]interface{}{"status": "wishlist"}, UpdateOptions{OverrideTransition: true})
+	store.Update(ctx, wish.ID, map[string]interface{}{"status": "applied"})
+	if reminders, _ := store.ListReminders(ctx, wish.ID); len(reminders) != 1 {
+		t.Fatalf("expected still one follow-up, got %d", len(reminders))
+	}
+
+	custom, err := store.CreateReminder(ctx, wish.ID, "2026-01-01T00:00:00Z", "Send portfolio")
+	if err != nil {
+		t.Fatalf("CreateReminder failed: %v", err)
+	}
+
+	// A terminal status closes the automatic follow-up but not custom reminders.
+	if _, err := store.Update(ctx, wish.ID, map[string]interface{}{"status": "rejected"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	reminders, _ = store.ListReminders(ctx, wish.ID)
+	for _, r := range reminders {
+		if r.Kind == model.ReminderFollowUp && (!r.Done || r.CompletedAt == "") {
+			t.Errorf("expected follow-up completed on rejection, got %+v", r)
+		}
+		if r.ID == custom.ID && r.Done {
+			t.Errorf("expected custom reminder left open, got %+v", r)
+		}
+	}
+}
+
+func TestFollowUpOnCreateAndDisabled(t *testing.T) {
+	store := setupFileStore(t)
+
+	app, err := store.Create(ctx, model.CreateRequest{Company: "Acme", Role: "SRE", Status: "applied"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	reminders, _ := store.ListReminders(ctx, app.ID)
+	if len(reminders) != 1 || reminders[0].Message != "Follow up with Acme about the SRE application" {
+		t.Fatalf("expected follow-up on create, got %+v", reminders)
+	}
+
+	store.SetFollowUpDays(0)
+	app, _ = store.Create(ctx, model.CreateRequest{Company: "Globex", Role: "SRE", Status: "applied"})
+	if reminders, _ := store.ListReminders(ctx, app.ID); len(reminders) != 0 {
+		t.Fatalf("expected no follow-up when disabled, got %+v", reminders)
+	}
+}
+
+func TestDueAndCompleteReminders(t *testing.T) {
+	store := setupFileStore(t)
+	app := createTestApp(t, store)
+
+	past, _ := store.CreateReminder(ctx, app.ID, "2026-01-01T09:00:00Z", "Overdue")
+	store.CreateReminder(ctx, app.ID, "2099-01-01T09:00:00Z", "Someday")
+
+	due, err := store.DueReminders(ctx, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		t.Fatalf("DueReminders failed: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != past.ID || due[0].Company != "TestCo" {
+		t.Fatalf("expected only the overdue reminder, got %+v", due)
+	}
+
+	done, err := store.CompleteReminder(ctx, past.ID)
+	if err != nil || done == nil || !done.Done || done.CompletedAt == "" {
+		t.Fatalf("CompleteReminder: %+v, %v", done, err)
+	}
+	again, _ := store.CompleteReminder(ctx, past.ID)
+	if again.CompletedAt != done.CompletedAt {
+		t.Errorf("expected completing twice to keep completed_at")
+	}
+	due, _ = store.DueReminders(ctx, time.Now().UTC().Format(time.RFC3339))
+	if len(due) != 0 {
+		t.Fatalf("expected no due reminders after completing, got %+v", due)
+	}
+
+	if r, err := store.CompleteReminder(ctx, "deadbeef"); r != nil || err != nil {
+		t.Fatalf("expected nil, nil for missing reminder, got %+v, %v", r, err)
+	}
+}
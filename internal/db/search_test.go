@@ -0,0 +1,192 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func seedSearchApps(t *testing.T, store *Store) map[string]string {
+	t.Helper()
+	reqs := []model.CreateRequest{
+		{Company: "Kubeworks", Role: "Platform Engineer", Location: "Berlin", Notes: "Recruiter said the team runs everything on Kubernetes"},
+		{Company: "Acme", Role: "Backend Engineer", Location: "Remote", Notes: "Go and Postgres, strong on-call culture"},
+		{Company: "Globex", Role: "Site Reliability Engineer", Location: "Zürich", Notes: "Kubernetes mentioned once; mostly Terraform"},
+		{Company: "Initech", Role: "Frontend Developer", Location: "Austin", Notes: "React"},
+	}
+	ids := make(map[string]string)
+	for _, r := range reqs {
+		app, err := store.Create(ctx, r)
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		ids[r.Company] = app.ID
+	}
+	return ids
+}
+
+func companies(apps []model.Application) string {
+	var names []string
+	for _, a := range apps {
+		names = append(names, a.Company)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestSearchNotesAndSnippet(t *testing.T) {
+	store := setupTestStore(t)
+	seedSearchApps(t, store)
+
+	opts := model.ListOptions{Query: "kubernetes", SortBy: model.SortByRelevance, SortOrder: "desc", Limit: 10}
+	apps, err := store.List(ctx, opts)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(apps) != 2 {
+		t.Fatalf("expected 2 matches, got %d (%s)", len(apps), companies(apps))
+	}
+	for _, a := range apps {
+		if !strings.Contains(a.Snippet, "<mark>Kubernetes</mark>") {
+			t.Errorf("expected highlighted snippet for %s, got %q", a.Company, a.Snippet)
+		}
+	}
+
+	count, err := store.Count(ctx, opts)
+	if err != nil {
+		t.Fatalf("Count failed: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected count 2, got %d", count)
+	}
+}
+
+func TestSearchSyntax(t *testing.T) {
+	store := setupTestStore(t)
+	seedSearchApps(t, store)
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: `"backend engineer"`, want: "Acme"},
+		{query: `kube*`, want: "Globex,Kubeworks"},
+		{query: `kubernetes NOT terraform`, want: "Kubeworks"},
+		{query: `react OR postgres`, want: "Acme,Initech"},
+		{query: `location:zurich`, want: "Globex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			apps, err := store.List(ctx, model.ListOptions{Query: tt.query, SortBy: "company", SortOrder: "asc", Limit: 10})
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			if got := companies(apps); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSearchCombinesWithFilters(t *testing.T) {
+	store := setupTestStore(t)
+	ids := seedSearchApps(t, store)
+
+	if _, err := store.Update(ctx, ids["Globex"], map[string]interface{}{"status": "applied"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	apps, err := store.List(ctx, model.ListOptions{Query: "kubernetes", Status: "applied", Limit: 10})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if companies(apps) != "Globex" {
+		t.Fatalf("expected only Globex, got %s", companies(apps))
+	}
+}
+
+func TestSearchIndexTracksUpdatesAndDeletes(t *testing.T) {
+	store := setupTestStore(t)
+	ids := seedSearchApps(t, store)
+
+	if _, err := store.Update(ctx, ids["Initech"], map[string]interface{}{"notes": "Hiring manager loves Kubernetes too"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if _, err := store.Delete(ctx, ids["Kubeworks"]); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Purge(ctx, ids["Kubeworks"]); err != nil {
+		t.Fatalf("Purge failed: %v", err)
+	}
+
+	apps, err := store.List(ctx, model.ListOptions{Query: "kubernetes", SortBy: "company", SortOrder: "asc", Limit: 10})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if got := companies(apps); got != "Globex,Initech" {
+		t.Fatalf("expected Globex,Initech, got %s", got)
+	}
+}
+
+// VACUUM may renumber the implicit rowids of applications; the index is keyed
+// by search_rowid so results must not shift to other rows.
+func TestSearchSurvivesVacuum(t *testing.T) {
+	store := setupFileStore(t)
+	ids := seedSearchApps(t, store)
+
+	if _, err := store.Delete(ctx, ids["Kubeworks"]); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Purge(ctx, ids["Kubeworks"]); err != nil {
+		t.Fatalf("Purge failed: %v", err)
+	}
+	if _, err := store.db.ExecContext(ctx, "VACUUM"); err != nil {
+		t.Fatalf("VACUUM failed: %v", err)
+	}
+
+	apps, err := store.List(ctx, model.ListOptions{Query: "kubernetes", Limit: 10})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if got := companies(apps); got != "Globex" {
+		t.Fatalf("expected Globex, got %s", got)
+	}
+	if !strings.Contains(apps[0].Snippet, "<mark>Kubernetes</mark>") {
+		t.Errorf("expected highlighted snippet, got %q", apps[0].Snippet)
+	}
+
+	// Rows added and edited afterwards are keyed apart from the old ones.
+	added, err := store.Create(ctx, model.CreateRequest{Company: "Hooli", Role: "SRE", Notes: "Kubernetes at scale"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := store.Update(ctx, ids["Globex"], map[string]interface{}{"notes": "Terraform only"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	apps, _ = store.List(ctx, model.ListOptions{Query: "kubernetes", Limit: 10})
+	if len(apps) != 1 || apps[0].ID != added.ID {
+		t.Fatalf("expected only Hooli, got %s", companies(apps))
+	}
+	var distinct, total int
+	store.db.QueryRow("SELECT COUNT(DISTINCT search_rowid), COUNT(*) FROM applications").Scan(&distinct, &total)
+	if distinct != total {
+		t.Fatalf("expected a distinct search_rowid per application, got %d for %d", distinct, total)
+	}
+}
+
+func TestSearchInvalidQuery(t *testing.T) {
+	store := setupTestStore(t)
+	seedSearchApps(t, store)
+
+	for _, q := range []string{`"unterminated`, `salary:100`, `AND`} {
+		_, err := store.List(ctx, model.ListOptions{Query: q, Limit: 10})
+		if !errors.Is(err, ErrInvalidSearch) {
+			t.Errorf("List(%q): expected ErrInvalidSearch, got %v", q, err)
+		}
+		_, err = store.Count(ctx, model.ListOptions{Query: q})
+		if !errors.Is(err, ErrInvalidSearch) {
+			t.Errorf("Count(%q): expected ErrInvalidSearch, got %v", q, err)
+		}
+	}
+}
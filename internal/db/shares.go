@@ -0,0 +1,171 @@
+package db
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+const (
+	shareColumns = "id, owner_id, kind, application_id, query, private, created_at, expires_at"
+
+	// shareTokenPrefix marks a share link token, as apiKeySecretPrefix does
+	// for keys.
+	shareTokenPrefix = "jhr_"
+)
+
+func scanShare(row scanner) (model.Share, error) {
+	var sh model.Share
+	var appID *string
+	var private string
+	if err := row.Scan(&sh.ID, &sh.OwnerID, &sh.Kind, &appID, &sh.Query, &private, &sh.CreatedAt, &sh.ExpiresAt); err != nil {
+		return sh, err
+	}
+	sh.ApplicationID = deref(appID)
+	if err := json.Unmarshal([]byte(private), &sh.Private); err != nil {
+		return sh, fmt.Errorf("decoding private fields: %w", err)
+	}
+	return sh, nil
+}
+
+// shareToken signs a share's ID and expiry with the database's share key, so
+// a token cannot be forged or have its expiry extended.
+func (s *Store) shareToken(ctx context.Context, sh model.Share) (string, error) {
+	var keyHex string
+	if err := s.db.QueryRowContext(ctx, "SELECT value FROM settings WHERE name = 'share_signing_key'").Scan(&keyHex); err != nil {
+		return "", fmt.Errorf("reading share signing key: %w", err)
+	}
+	key, err := hex.DecodeString(keyHex)
+	if err != nil {
+		return "", fmt.Errorf("decoding share signing key: %w", err)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(sh.ID + "." + sh.ExpiresAt))
+	return shareTokenPrefix + sh.ID + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// CreateShare stores a share of the given kind from req, which must already
+// be normalized, and returns it with its token. An application share belongs
+// to the application's owner.
+func (s *Store) CreateShare(ctx context.Context, kind string, req model.ShareRequest) (*model.Share, error) {
+	now := time.Now().UTC()
+	sh := model.Share{
+		ID:            generateID(),
+		Kind:          kind,
+		ApplicationID: req.ApplicationID,
+		Query:         req.Query,
+		Private:       req.Private,
+		CreatedAt:     now.Format(time.RFC3339),
+		ExpiresAt:     now.AddDate(0, 0, req.ExpiresInDays).Format(time.RFC3339),
+	}
+	private, err := json.Marshal(sh.Private)
+	if err != nil {
+		return nil, err
+	}
+	var appID *string
+	if sh.ApplicationID != "" {
+		appID = &sh.ApplicationID
+	}
+
+	err = s.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO shares ("+shareColumns+") VALUES (?, COALESCE("+appOwner+", ?), ?, ?, ?, ?, ?, ?)",
+			sh.ID, sh.ApplicationID, ownerOf(ctx), sh.Kind, appID, sh.Query, string(private), sh.CreatedAt, sh.ExpiresAt,
+		)
+		if err != nil {
+			return err
+		}
+		return recordAudit(ctx, tx, model.AuditCreate, auditShare, sh.ID, nil, sh)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if sh.Token, err = s.shareToken(ctx, sh); err != nil {
+		return nil, err
+	}
+	return &sh, nil
+}
+
+// ListShares returns shares, expired ones included, newest first.
+func (s *Store) ListShares(ctx context.Context) ([]model.Share, error) {
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+shareColumns+" FROM shares WHERE 1 = 1"+owned+" ORDER BY created_at DESC, id",
+		ownerArgs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shares := []model.Share{}
+	for rows.Next() {
+		sh, err := scanShare(rows)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, sh)
+	}
+	return shares, rows.Err()
+}
+
+// DeleteShare revokes a share; its token stops working at once.
+func (s *Store) DeleteShare(ctx context.Context, id string) (bool, error) {
+	deleted := false
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		owned, ownerArgs := ownedBy(ctx, "owner_id")
+		existing, err := scanShare(tx.QueryRowContext(ctx,
+			"SELECT "+shareColumns+" FROM shares WHERE id = ?"+owned,
+			append([]interface{}{id}, ownerArgs...)...,
+		))
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM shares WHERE id = ?", id); err != nil {
+			return err
+		}
+		deleted = true
+		return recordAudit(ctx, tx, model.AuditDelete, auditShare, id, existing, nil)
+	})
+	return deleted, err
+}
+
+// ResolveShare returns the live share a token was issued for, or nil, nil if
+// the token is malformed, forged, expired or revoked. Reads made for the
+// share should be scoped to its OwnerID.
+func (s *Store) ResolveShare(ctx context.Context, token string) (*model.Share, error) {
+	id, _, ok := strings.Cut(strings.TrimPrefix(token, shareTokenPrefix), ".")
+	if !ok || !strings.HasPrefix(token, shareTokenPrefix) {
+		return nil, nil
+	}
+	sh, err := scanShare(s.db.QueryRowContext(ctx,
+		"SELECT "+shareColumns+" FROM shares WHERE id = ? AND expires_at > ?",
+		id, time.Now().UTC().Format(time.RFC3339),
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	want, err := s.shareToken(ctx, sh)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal([]byte(token), []byte(want)) {
+		return nil, nil
+	}
+	return &sh, nil
+}
@@ -0,0 +1,84 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestShareTokens(t *testing.T) {
+	store := setupFileStore(t)
+	app := createTestApp(t, store)
+
+	share, err := store.CreateShare(ctx, model.ShareApplication, model.ShareRequest{ApplicationID: app.ID, ExpiresInDays: 7, Private: []string{"salary"}})
+	if err != nil {
+		t.Fatalf("CreateShare failed: %v", err)
+	}
+	if !strings.HasPrefix(share.Token, "jhr_"+share.ID+".") {
+		t.Fatalf("unexpected token %q", share.Token)
+	}
+
+	got, err := store.ResolveShare(ctx, share.Token)
+	if err != nil || got == nil || got.ApplicationID != app.ID || len(got.Private) != 1 || got.Token != "" {
+		t.Fatalf("expected the share resolved, got %+v (%v)", got, err)
+	}
+	for _, bad := range []string{"", share.ID, "jhr_" + share.ID, "jhr_" + share.ID + ".forged", share.Token + "x", strings.TrimPrefix(share.Token, "jhr_")} {
+		if got, _ := store.ResolveShare(ctx, bad); got != nil {
+			t.Fatalf("expected %q refused", bad)
+		}
+	}
+
+	// Extending the expiry in the database does not revive a token signed
+	// for the old one, and an expired share stops resolving.
+	store.db.Exec("UPDATE shares SET expires_at = '2999-01-01T00:00:00Z' WHERE id = ?", share.ID)
+	if got, _ := store.ResolveShare(ctx, share.Token); got != nil {
+		t.Fatal("expected a token for a different expiry refused")
+	}
+	store.db.Exec("UPDATE shares SET expires_at = '2000-01-01T00:00:00Z' WHERE id = ?", share.ID)
+	if got, _ := store.ResolveShare(ctx, share.Token); got != nil {
+		t.Fatal("expected an expired share refused")
+	}
+
+	view, _ := store.CreateShare(ctx, model.ShareView, model.ShareRequest{Query: "status=applied", ExpiresInDays: 1, Private: []string{}})
+	if deleted, err := store.DeleteShare(ctx, view.ID); err != nil || !deleted {
+		t.Fatalf("expected delete, got %v (%v)", deleted, err)
+	}
+	if got, _ := store.ResolveShare(ctx, view.Token); got != nil {
+		t.Fatal("expected a deleted share refused")
+	}
+
+	// Purging the application takes its shares with it.
+	store.Delete(ctx, app.ID)
+	store.Purge(ctx, app.ID)
+	if shares, _ := store.ListShares(ctx); len(shares) != 0 {
+		t.Fatalf("expected shares purged with their application, got %+v", shares)
+	}
+}
+
+func TestShareOwnership(t *testing.T) {
+	store := setupFileStore(t)
+	ann := WithOwner(ctx, "ann")
+	app, _ := store.Create(ann, model.CreateRequest{Company: "Acme", Role: "Eng"})
+
+	// An application share belongs to the application's owner even when
+	// created unscoped.
+	share, err := store.CreateShare(ctx, model.ShareApplication, model.ShareRequest{ApplicationID: app.ID, ExpiresInDays: 1, Private: []string{}})
+	if err != nil {
+		t.Fatalf("CreateShare failed: %v", err)
+	}
+	if got, _ := store.ResolveShare(ctx, share.Token); got == nil || got.OwnerID != "ann" {
+		t.Fatalf("expected ann to own the share, got %+v", got)
+	}
+
+	bob := WithOwner(ctx, "bob")
+	if shares, _ := store.ListShares(bob); len(shares) != 0 {
+		t.Fatalf("expected bob to see none of ann's shares, got %+v", shares)
+	}
+	if deleted, _ := store.DeleteShare(bob, share.ID); deleted {
+		t.Fatal("expected bob unable to delete ann's share")
+	}
+	if shares, _ := store.ListShares(ann); len(shares) != 1 {
+		t.Fatalf("expected ann to see her share, got %+v", shares)
+	}
+}
@@ -0,0 +1,315 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+var (
+	// ErrStageExists is returned by CreateStage when the name is taken.
+	ErrStageExists = errors.New("stage already exists")
+	// ErrStageInUse is returned by DeleteStage while applications still have
+	// that status.
+	ErrStageInUse = errors.New("stage is in use by applications")
+	// ErrLastActiveStage is returned by UpdateStage and DeleteStage for a
+	// change that would leave no non-terminal stage for new applications.
+	ErrLastActiveStage = errors.New("pipeline needs at least one non-terminal stage")
+)
+
+// stageOwner selects whose pipeline ctx sees: its owner's own copy once it
+// has one, otherwise the template with an empty owner_id. It takes
+// ownerOf(ctx) twice.
+const stageOwner = "(SELECT CASE WHEN EXISTS (SELECT 1 FROM stages WHERE owner_id = ?) THEN ? ELSE '' END)"
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func loadPipeline(ctx context.Context, q queryer) (model.Pipeline, error) {
+	owner := ownerOf(ctx)
+	rows, err := q.QueryContext(ctx, `SELECT s.name, s.label, s.position, s.terminal, COALESCE(t.to_stage, '')
+		FROM stages s
+		LEFT JOIN stage_transitions t ON t.owner_id = s.owner_id AND t.from_stage = s.name
+		LEFT JOIN stages n ON n.owner_id = t.owner_id AND n.name = t.to_stage
+		WHERE s.owner_id = `+stageOwner+`
+		ORDER BY s.position, s.name, n.position, n.name`, owner, owner)
+	if err != nil {
+		return nil, fmt.Errorf("querying stages: %w", err)
+	}
+	defer rows.Close()
+
+	var p model.Pipeline
+	for rows.Next() {
+		var st model.Stage
+		var next string
+		if err := rows.Scan(&st.Name, &st.Label, &st.Position, &st.Terminal, &next); err != nil {
+			return nil, fmt.Errorf("scanning stage: %w", err)
+		}
+		if len(p) == 0 || p[len(p)-1].Name != st.Name {
+			st.Next = []string{}
+			p = append(p, st)
+		}
+		if next != "" {
+			last := &p[len(p)-1]
+			last.Next = append(last.Next, next)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating stages: %w", err)
+	}
+	return p, nil
+}
+
+// ownPipeline gives the owner in ctx their own copy of the template before
+// they first change it, so one user's stages never affect another's.
+func ownPipeline(ctx context.Context, tx *sql.Tx) error {
+	owner := ownerOf(ctx)
+	if owner == "" {
+		return nil
+	}
+	var own int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM stages WHERE owner_id = ?", owner).Scan(&own); err != nil || own > 0 {
+		return err
+	}
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO stages (owner_id, name, label, position, terminal, created_at, updated_at)
+			SELECT ?, name, label, position, terminal, created_at, updated_at FROM stages WHERE owner_id = ''`, owner)
+	if err != nil {
+		return fmt.Errorf("copying stages: %w", err)
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO stage_transitions (owner_id, from_stage, to_stage)
+			SELECT ?, from_stage, to_stage FROM stage_transitions WHERE owner_id = ''`, owner)
+	if err != nil {
+		return fmt.Errorf("copying stage transitions: %w", err)
+	}
+	return nil
+}
+
+// lastActive reports whether name is p's only non-terminal stage.
+func lastActive(p model.Pipeline, name string) bool {
+	for _, st := range p {
+		if !st.Terminal && st.Name != name {
+			return false
+		}
+	}
+	return true
+}
+
+// Pipeline returns the configured stages ordered by position.
+func (s *Store) Pipeline(ctx context.Context) (model.Pipeline, error) {
+	return loadPipeline(ctx, s.db)
+}
+
+func (s *Store) GetStage(ctx context.Context, name string) (*model.Stage, error) {
+	p, err := s.Pipeline(ctx)
+	if err != nil {
+		return nil, err
+	}
+	st, ok := p.Stage(name)
+	if !ok {
+		return nil, nil
+	}
+	return &st, nil
+}
+
+// CreateStage adds a stage. Without a position it is placed after every
+// existing stage.
+func (s *Store) CreateStage(ctx context.Context, req model.StageRequest) (*model.Stage, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+	if err := ownPipeline(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	owner := ownerOf(ctx)
+	var exists int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM stages WHERE owner_id = ? AND name = ?", owner, req.Name).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if exists > 0 {
+		return nil, ErrStageExists
+	}
+
+	var position int
+	if req.Position != nil {
+		position = *req.Position
+	} else if err := tx.QueryRowContext(ctx, "SELECT COALESCE(MAX(position), 0) + 10 FROM stages WHERE owner_id = ?", owner).Scan(&position); err != nil {
+		return nil, err
+	}
+	terminal := req.Terminal != nil && *req.Terminal
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO stages (owner_id, name, label, position, terminal, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		owner, req.Name, *req.Label, position, terminal, now, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Next != nil && !terminal {
+		if err := replaceTransitions(ctx, tx, req.Name, *req.Next); err != nil {
+			return nil, err
+		}
+	}
+	if err := auditStageChange(ctx, tx, model.AuditCreate, req.Name, nil); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return s.GetStage(ctx, req.Name)
+}
+
+// UpdateStage applies the non-nil fields of req. Making a stage terminal
+// clears its next stages, and fails with ErrLastActiveStage for the only
+// non-terminal one. Returns nil, nil if the stage does not exist.
+func (s *Store) UpdateStage(ctx context.Context, name string, req model.StageRequest) (*model.Stage, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+	if err := ownPipeline(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	pipeline, err := loadPipeline(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	before, ok := pipeline.Stage(name)
+	if !ok {
+		return nil, nil
+	}
+	terminal := before.Terminal
+	if req.Terminal != nil && *req.Terminal && !terminal && lastActive(pipeline, name) {
+		return nil, ErrLastActiveStage
+	}
+	owner := ownerOf(ctx)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if req.Label != nil {
+		if _, err := tx.ExecContext(ctx, "UPDATE stages SET label = ?, updated_at = ? WHERE owner_id = ? AND name = ?", *req.Label, now, owner, name); err != nil {
+			return nil, err
+		}
+	}
+	if req.Position != nil {
+		if _, err := tx.ExecContext(ctx, "UPDATE stages SET position = ?, updated_at = ? WHERE owner_id = ? AND name = ?", *req.Position, now, owner, name); err != nil {
+			return nil, err
+		}
+	}
+	if req.Terminal != nil {
+		terminal = *req.Terminal
+		if _, err := tx.ExecContext(ctx, "UPDATE stages SET terminal = ?, updated_at = ? WHERE owner_id = ? AND name = ?", terminal, now, owner, name); err != nil {
+			return nil, err
+		}
+	}
+
+	if terminal {
+		if err := replaceTransitions(ctx, tx, name, nil); err != nil {
+			return nil, err
+		}
+	} else if req.Next != nil {
+		if err := replaceTransitions(ctx, tx, name, *req.Next); err != nil {
+			return nil, err
+		}
+	}
+	if err := auditStageChange(ctx, tx, model.AuditUpdate, name, &before); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return s.GetStage(ctx, name)
+}
+
+// DeleteStage removes a stage and every transition to or from it. It fails
+// with ErrStageInUse while any application visible in ctx has the stage as
+// its status, trashed ones included since they may be restored, and with
+// ErrLastActiveStage for the only non-terminal stage.
+func (s *Store) DeleteStage(ctx context.Context, name string) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+	if err := ownPipeline(ctx, tx); err != nil {
+		return false, err
+	}
+
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	var inUse int
+	err = tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM applications WHERE status = ?"+owned,
+		append([]interface{}{name}, ownerArgs...)...,
+	).Scan(&inUse)
+	if err != nil {
+		return false, err
+	}
+	if inUse > 0 {
+		return false, ErrStageInUse
+	}
+
+	pipeline, err := loadPipeline(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+	before, ok := pipeline.Stage(name)
+	if !ok {
+		return false, nil
+	}
+	if !before.Terminal && lastActive(pipeline, name) {
+		return false, ErrLastActiveStage
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM stages WHERE owner_id = ? AND name = ?", ownerOf(ctx), name); err != nil {
+		return false, err
+	}
+	if err := recordAudit(ctx, tx, model.AuditDelete, auditStage, name, before, nil); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("committing transaction: %w", err)
+	}
+	return true, nil
+}
+
+// auditStageChange records a create (before nil) or update of the named
+// stage against its current state in tx.
+func auditStageChange(ctx context.Context, tx *sql.Tx, action, name string, before *model.Stage) error {
+	pipeline, err := loadPipeline(ctx, tx)
+	if err != nil {
+		return err
+	}
+	after, _ := pipeline.Stage(name)
+	var b any
+	if before != nil {
+		b = *before
+	}
+	return recordAudit(ctx, tx, action, auditStage, name, b, after)
+}
+
+func replaceTransitions(ctx context.Context, tx *sql.Tx, from string, next []string) error {
+	owner := ownerOf(ctx)
+	if _, err := tx.ExecContext(ctx, "DELETE FROM stage_transitions WHERE owner_id = ? AND from_stage = ?", owner, from); err != nil {
+		return err
+	}
+	for _, to := range next {
+		if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO stage_transitions (owner_id, from_stage, to_stage) VALUES (?, ?, ?)", owner, from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestPipelineSeeded(t *testing.T) {
+	store := setupTestStore(t)
+
+	p, err := store.Pipeline(ctx)
+	if err != nil {
+		t.Fatalf("Pipeline failed: %v", err)
+	}
+	want := "wishlist,applied,phone_screen,interview,offer,accepted,rejected,withdrawn,ghosted"
+	if got := strings.Join(p.Names(), ","); got != want {
+		t.Fatalf("expected stages %s, got %s", want, got)
+	}
+	offer, _ := p.Stage("offer")
+	if strings.Join(offer.Next, ",") != "accepted,rejected,withdrawn" {
+		t.Fatalf("unexpected offer transitions: %v", offer.Next)
+	}
+	accepted, _ := p.Stage("accepted")
+	if !accepted.Terminal || len(accepted.Next) != 0 {
+		t.Fatalf("expected accepted to be terminal with no next, got %+v", accepted)
+	}
+}
+
+func TestCustomStageUsableByApplications(t *testing.T) {
+	store := setupTestStore(t)
+
+	_, err := store.CreateStage(ctx, model.StageRequest{Name: "take_home", Label: strPtr("Take-home"), Position: intPtr(35), Next: &[]string{"interview", "rejected"}})
+	if err != nil {
+		t.Fatalf("CreateStage failed: %v", err)
+	}
+	if _, err := store.UpdateStage(ctx, "phone_screen", model.StageRequest{Next: &[]string{"take_home", "interview"}}); err != nil {
+		t.Fatalf("UpdateStage failed: %v", err)
+	}
+
+	app, err := store.Create(ctx, model.CreateRequest{Company: "TH", Role: "Eng", Status: "phone_screen"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	updated, err := store.Update(ctx, app.ID, map[string]interface{}{"status": "take_home"})
+	if err != nil {
+		t.Fatalf("Update to custom stage failed: %v", err)
+	}
+	if updated.Status != "take_home" {
+		t.Fatalf("expected take_home, got %s", updated.Status)
+	}
+
+	_, err = store.Update(ctx, app.ID, map[string]interface{}{"status": "offer"})
+	var te *model.TransitionError
+	if !errors.As(err, &te) || strings.Join(te.Allowed, ",") != "interview,rejected" {
+		t.Fatalf("expected transition error listing custom next stages, got %v", err)
+	}
+
+	stats, err := store.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.ByStatus["take_home"] != 1 {
+		t.Fatalf("expected take_home count 1, got %d", stats.ByStatus["take_home"])
+	}
+
+	p, _ := store.Pipeline(ctx)
+	if p.Names()[3] != "take_home" {
+		t.Fatalf("expected take_home ordered after phone_screen, got %v", p.Names())
+	}
+}
+
+func TestCreateStageDuplicate(t *testing.T) {
+	store := setupTestStore(t)
+
+	_, err := store.CreateStage(ctx, model.StageRequest{Name: "applied", Label: strPtr("Again")})
+	if !errors.Is(err, ErrStageExists) {
+		t.Fatalf("expected ErrStageExists, got %v", err)
+	}
+}
+
+func TestUpdateStageTerminalClearsNext(t *testing.T) {
+	store := setupTestStore(t)
+
+	yes := true
+	st, err := store.UpdateStage(ctx, "ghosted", model.StageRequest{Terminal: &yes, Label: strPtr("No reply")})
+	if err != nil {
+		t.Fatalf("UpdateStage failed: %v", err)
+	}
+	if !st.Terminal || len(st.Next) != 0 || st.Label != "No reply" {
+		t.Fatalf("unexpected stage after update: %+v", st)
+	}
+
+	missing, err := store.UpdateStage(ctx, "nope", model.StageRequest{Label: strPtr("x")})
+	if err != nil || missing != nil {
+		t.Fatalf("expected nil, nil for missing stage, got %+v, %v", missing, err)
+	}
+}
+
+func TestDeleteStage(t *testing.T) {
+	store := setupTestStore(t)
+	createTestApp(t, store)
+
+	if _, err := store.DeleteStage(ctx, "wishlist"); !errors.Is(err, ErrStageInUse) {
+		t.Fatalf("expected ErrStageInUse, got %v", err)
+	}
+
+	deleted, err := store.DeleteStage(ctx, "ghosted")
+	if err != nil || !deleted {
+		t.Fatalf("expected ghosted to be deleted, got %v, %v", deleted, err)
+	}
+	p, _ := store.Pipeline(ctx)
+	applied, _ := p.Stage("applied")
+	for _, n := range applied.Next {
+		if n == "ghosted" {
+			t.Fatal("expected transitions to deleted stage to be removed")
+		}
+	}
+
+	deleted, err = store.DeleteStage(ctx, "ghosted")
+	if err != nil || deleted {
+		t.Fatalf("expected false for already-deleted stage, got %v, %v", deleted, err)
+	}
+}
+
+func TestStagesPerOwner(t *testing.T) {
+	store := setupTestStore(t)
+	ann, bob := WithOwner(ctx, "ann"), WithOwner(ctx, "bob")
+
+	if _, err := store.CreateStage(ann, model.StageRequest{Name: "onsite", Label: strPtr("Onsite")}); err != nil {
+		t.Fatalf("CreateStage failed: %v", err)
+	}
+	if _, err := store.UpdateStage(ann, "applied", model.StageRequest{Label: strPtr("Sent")}); err != nil {
+		t.Fatalf("UpdateStage failed: %v", err)
+	}
+	for _, c := range []context.Context{bob, ctx} {
+		p, _ := store.Pipeline(c)
+		applied, _ := p.Stage("applied")
+		if p.Has("onsite") || applied.Label != "Applied" {
+			t.Fatalf("expected ann's changes to stay hers, got %+v", p)
+		}
+	}
+	if p, _ := store.Pipeline(ann); !p.Has("onsite") || len(p) != 10 {
+		t.Fatalf("expected ann's own copy of the pipeline plus onsite, got %+v", p)
+	}
+
+	// Only the caller's applications hold a stage, trashed ones included.
+	store.Create(ann, model.CreateRequest{Company: "Acme", Role: "Eng", Status: "wishlist"})
+	trashed, _ := store.Create(bob, model.CreateRequest{Company: "Acme", Role: "Eng", Status: "phone_screen"})
+	store.Delete(bob, trashed.ID)
+	if _, err := store.DeleteStage(ann, "wishlist"); !errors.Is(err, ErrStageInUse) {
+		t.Fatalf("expected ErrStageInUse for ann, got %v", err)
+	}
+	if deleted, err := store.DeleteStage(bob, "wishlist"); err != nil || !deleted {
+		t.Fatalf("expected bob to delete wishlist, got %v, %v", deleted, err)
+	}
+	if _, err := store.DeleteStage(bob, "phone_screen"); !errors.Is(err, ErrStageInUse) {
+		t.Fatalf("expected ErrStageInUse for bob's trashed application, got %v", err)
+	}
+	store.Purge(bob, trashed.ID)
+	if deleted, err := store.DeleteStage(bob, "phone_screen"); err != nil || !deleted {
+		t.Fatalf("expected bob to delete phone_screen once purged, got %v, %v", deleted, err)
+	}
+	if p, _ := store.Pipeline(ann); !p.Has("wishlist") || !p.Has("phone_screen") {
+		t.Fatalf("expected ann's stages untouched, got %+v", p)
+	}
+}
+
+func TestLastActiveStage(t *testing.T) {
+	store := setupTestStore(t)
+
+	yes := true
+	for _, name := range []string{"wishlist", "applied", "phone_screen", "interview", "offer"} {
+		if _, err := store.UpdateStage(ctx, name, model.StageRequest{Terminal: &yes}); err != nil {
+			t.Fatalf("UpdateStage %s failed: %v", name, err)
+		}
+	}
+	if _, err := store.UpdateStage(ctx, "ghosted", model.StageRequest{Terminal: &yes}); !errors.Is(err, ErrLastActiveStage) {
+		t.Fatalf("expected ErrLastActiveStage, got %v", err)
+	}
+	if _, err := store.DeleteStage(ctx, "ghosted"); !errors.Is(err, ErrLastActiveStage) {
+		t.Fatalf("expected ErrLastActiveStage, got %v", err)
+	}
+	if deleted, err := store.DeleteStage(ctx, "offer"); err != nil || !deleted {
+		t.Fatalf("expected a terminal stage to be deletable, got %v, %v", deleted, err)
+	}
+	if app := createTestApp(t, store); app.Status != "ghosted" {
+		t.Fatalf("expected new applications to start in ghosted, got %s", app.Status)
+	}
+}
+
+func intPtr(v int) *int { return &v }
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// StaleSweep selects applications with no updates since Before (RFC3339 UTC)
+// whose status is one of Statuses. With GhostStatus set they are moved to that
+// status; otherwise they are flagged by setting stale_at. Note is recorded in
+// each application's history.
+type StaleSweep struct {
+	Statuses    []string
+	Before      string
+	GhostStatus string
+	Note        string
+}
+
+// SweepStale applies sweep in a single transaction and returns the
+// applications it changed. In ghost mode, applications whose owner's pipeline
+// does not allow moving from their current stage to GhostStatus are left
+// alone. Flagging does not touch
+// updated_at, so a flagged application keeps its last real activity time.
+func (s *Store) SweepStale(ctx context.Context, sweep StaleSweep) ([]model.Application, error) {
+	if len(sweep.Statuses) == 0 {
+		return nil, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	pipeline, err := loadPipeline(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if sweep.GhostStatus != "" && !pipeline.Has(sweep.GhostStatus) {
+		return nil, fmt.Errorf("ghost status %q is not a stage", sweep.GhostStatus)
+	}
+
+	query := "SELECT " + applicationColumns + ", applications.owner_id FROM applications WHERE deleted_at = '' AND status IN (" + placeholders(len(sweep.Statuses)) + ") AND updated_at < ?"
+	if sweep.GhostStatus == "" {
+		query += " AND stale_at = ''"
+	}
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	query += owned + " ORDER BY updated_at, id"
+	args := make([]interface{}, 0, len(sweep.Statuses)+2)
+	for _, st := range sweep.Statuses {
+		args = append(args, st)
+	}
+	args = append(args, sweep.Before)
+	args = append(args, ownerArgs...)
+
+	candidates, err := collectOwned(ctx, tx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	changed := []model.Application{}
+	pipelines := map[string]model.Pipeline{}
+	for _, o := range candidates {
+		a, before := o.app, o.app
+		actx := WithOwner(ctx, o.owner)
+		if sweep.GhostStatus == "" {
+			if _, err := tx.ExecContext(ctx, "UPDATE applications SET stale_at = ?, version = version + 1 WHERE id = ?", now, a.ID); err != nil {
+				return nil, err
+			}
+			if err := recordStatusChange(ctx, tx, a.ID, a.Status, a.Status, sweep.Note, now); err != nil {
+				return nil, fmt.Errorf("recording status history: %w", err)
+			}
+			a.StaleAt = now
+			a.Version++
+			if err := recordAudit(actx, tx, model.AuditUpdate, auditApplication, a.ID, before, a); err != nil {
+				return nil, err
+			}
+			changed = append(changed, a)
+			continue
+		}
+
+		pipeline, ok := pipelines[o.owner]
+		if !ok {
+			if pipeline, err = loadPipeline(actx, tx); err != nil {
+				return nil, err
+			}
+			pipelines[o.owner] = pipeline
+		}
+		if !pipeline.Has(sweep.GhostStatus) || pipeline.ValidateTransition(a.Status, sweep.GhostStatus) != nil {
+			continue
+		}
+		_, err := tx.ExecContext(ctx,
+			"UPDATE applications SET status = ?, stale_at = '', updated_at = ?, version = version + 1 WHERE id = ?",
+			sweep.GhostStatus, now, a.ID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := recordStatusChange(ctx, tx, a.ID, a.Status, sweep.GhostStatus, sweep.Note, now); err != nil {
+			return nil, fmt.Errorf("recording status history: %w", err)
+		}
+		a.Status, a.StaleAt, a.UpdatedAt = sweep.GhostStatus, "", now
+		a.Version++
+		stage, _ := pipeline.Stage(sweep.GhostStatus)
+		if err := s.onStatusEntered(ctx, tx, a, stage.Terminal, now); err != nil {
+			return nil, fmt.Errorf("scheduling follow-up: %w", err)
+		}
+		if err := recordAudit(actx, tx, model.AuditUpdate, auditApplication, a.ID, before, a); err != nil {
+			return nil, err
+		}
+		changed = append(changed, a)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+	return changed, nil
+}
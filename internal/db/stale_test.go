@@ -0,0 +1,97 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func createAgedApp(t *testing.T, store *Store, company, status string) *model.Application {
+	t.Helper()
+	app, err := store.Create(ctx, model.CreateRequest{Company: company, Role: "Engineer", Status: status})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if _, err := store.db.Exec("UPDATE applications SET updated_at = '2026-01-01T00:00:00Z' WHERE id = ?", app.ID); err != nil {
+		t.Fatalf("aging application: %v", err)
+	}
+	return app
+}
+
+var watched = []string{"applied", "phone_screen", "interview"}
+
+func TestSweepStaleFlag(t *testing.T) {
+	store := setupFileStore(t)
+	old := createAgedApp(t, store, "Quiet", "applied")
+	createAgedApp(t, store, "Dream", "wishlist")
+	createTestApp(t, store) // recent
+
+	sweep := StaleSweep{Statuses: watched, Before: "2026-02-01T00:00:00Z", Note: "stale"}
+	changed, err := store.SweepStale(ctx, sweep)
+	if err != nil {
+		t.Fatalf("SweepStale failed: %v", err)
+	}
+	if len(changed) != 1 || changed[0].ID != old.ID || changed[0].StaleAt == "" {
+		t.Fatalf("expected only Quiet flagged, got %+v", changed)
+	}
+
+	got, _ := store.Get(ctx, old.ID)
+	if got.Status != "applied" || got.StaleAt == "" || got.UpdatedAt != "2026-01-01T00:00:00Z" {
+		t.Fatalf("expected flag without status or updated_at change, got %+v", got)
+	}
+	history, _ := store.History(ctx, old.ID)
+	if last := history[len(history)-1]; last.FromStatus != "applied" || last.ToStatus != "applied" || last.Note != "stale" {
+		t.Fatalf("expected flag recorded in history, got %+v", last)
+	}
+
+	if changed, _ := store.SweepStale(ctx, sweep); len(changed) != 0 {
+		t.Fatalf("expected flagged applications to be skipped, got %+v", changed)
+	}
+
+	stale, _ := store.List(ctx, model.ListOptions{Limit: 10, Stale: true, HasStale: true})
+	if len(stale) != 1 || stale[0].ID != old.ID {
+		t.Fatalf("expected stale filter to find Quiet, got %+v", stale)
+	}
+
+	if _, err := store.Update(ctx, old.ID, map[string]interface{}{"notes": "emailed again"}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	got, _ = store.Get(ctx, old.ID)
+	if got.StaleAt != "" {
+		t.Fatalf("expected update to clear stale_at, got %q", got.StaleAt)
+	}
+}
+
+func TestSweepStaleGhost(t *testing.T) {
+	store := setupFileStore(t)
+	applied := createAgedApp(t, store, "Quiet", "applied")
+	offer := createAgedApp(t, store, "Offer", "offer")
+
+	// offer is not watched; make interview unable to reach ghosted.
+	interview := createAgedApp(t, store, "Stuck", "interview")
+	if _, err := store.db.Exec("DELETE FROM stage_transitions WHERE from_stage = 'interview' AND to_stage = 'ghosted'"); err != nil {
+		t.Fatalf("removing transition: %v", err)
+	}
+
+	changed, err := store.SweepStale(ctx, StaleSweep{Statuses: watched, Before: "2026-02-01T00:00:00Z", GhostStatus: "ghosted", Note: "ghosted"})
+	if err != nil {
+		t.Fatalf("SweepStale failed: %v", err)
+	}
+	if len(changed) != 1 || changed[0].ID != applied.ID {
+		t.Fatalf("expected only Quiet ghosted, got %+v", changed)
+	}
+
+	got, _ := store.Get(ctx, applied.ID)
+	if got.Status != "ghosted" {
+		t.Fatalf("expected ghosted, got %s", got.Status)
+	}
+	history, _ := store.History(ctx, applied.ID)
+	if last := history[len(history)-1]; last.FromStatus != "applied" || last.ToStatus != "ghosted" || last.Note != "ghosted" {
+		t.Fatalf("expected transition in history, got %+v", last)
+	}
+	for _, id := range []string{offer.ID, interview.ID} {
+		if a, _ := store.Get(ctx, id); a.Status == "ghosted" {
+			t.Errorf("expected %s to keep its status", a.Company)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// placeholders returns n comma-separated "?" for an IN list.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// addTags attaches normalized tags to an application, creating any of its
+// owner's tags that do not exist yet. Tags already attached are left as they
+// are.
+func addTags(ctx context.Context, tx *sql.Tx, appID string, tags []string, now string) error {
+	for _, tag := range tags {
+		_, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO tags (owner_id, name, created_at) VALUES ("+appOwner+", ?, ?)", appID, tag, now)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx,
+			"INSERT OR IGNORE INTO application_tags (application_id, owner_id, tag, created_at) VALUES (?, "+appOwner+", ?, ?)",
+			appID, appID, tag, now,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceTags makes tags, which must be normalized, an application's full
+// tag list in place of current.
+func replaceTags(ctx context.Context, tx *sql.Tx, appID string, current, tags []string, now string) error {
+	var removed []interface{}
+	for _, tag := range current {
+		if !slices.Contains(tags, tag) {
+			removed = append(removed, tag)
+		}
+	}
+	var toAdd []string
+	for _, tag := range tags {
+		if !slices.Contains(current, tag) {
+			toAdd = append(toAdd, tag)
+		}
+	}
+	if len(removed) > 0 {
+		_, err := tx.ExecContext(ctx,
+			"DELETE FROM application_tags WHERE application_id = ? AND tag IN ("+placeholders(len(removed))+")",
+			append([]interface{}{appID}, removed...)...,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return addTags(ctx, tx, appID, toAdd, now)
+}
+
+// AddTags attaches tags (already normalized) to an application and returns
+// its full tag list. Tagging does not change updated_at, which tracks
+// activity on the application itself. Returns nil, nil if the application
+// does not exist.
+func (s *Store) AddTags(ctx context.Context, appID string, tags []string) ([]string, error) {
+	return s.changeTags(ctx, appID, func(tx *sql.Tx) error {
+		return addTags(ctx, tx, appID, tags, time.Now().UTC().Format(time.RFC3339))
+	})
+}
+
+// RemoveTags detaches tags from an application and returns its remaining
+// tags. Tags that were not attached are ignored; the tags themselves are kept
+// for reuse. Returns nil, nil if the application does not exist.
+func (s *Store) RemoveTags(ctx context.Context, appID string, tags []string) ([]string, error) {
+	return s.changeTags(ctx, appID, func(tx *sql.Tx) error {
+		if len(tags) == 0 {
+			return nil
+		}
+		args := []interface{}{appID}
+		for _, tag := range tags {
+			args = append(args, tag)
+		}
+		_, err := tx.ExecContext(ctx,
+			"DELETE FROM application_tags WHERE application_id = ? AND tag IN ("+placeholders(len(tags))+")",
+			args...,
+		)
+		return err
+	})
+}
+
+func (s *Store) changeTags(ctx context.Context, appID string, change func(tx *sql.Tx) error) ([]string, error) {
+	var tags []string
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		tags, err = changeTagsTx(ctx, tx, appID, change)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// changeTagsTx is changeTags within tx.
+func changeTagsTx(ctx context.Context, tx *sql.Tx, appID string, change func(tx *sql.Tx) error) ([]string, error) {
+	existing, err := liveApplication(ctx, tx, appID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := change(tx); err != nil {
+		return nil, err
+	}
+	app, err := scanApplication(tx.QueryRowContext(ctx, "SELECT "+applicationColumns+" FROM applications WHERE id = ?", appID))
+	if err != nil {
+		return nil, err
+	}
+	// Tags are part of the application's JSON form, so changing them is a
+	// new version even though updated_at stays put.
+	if !slices.Equal(existing.Tags, app.Tags) {
+		if _, err := tx.ExecContext(ctx, "UPDATE applications SET version = version + 1 WHERE id = ?", appID); err != nil {
+			return nil, err
+		}
+		app.Version++
+	}
+	if err := recordAudit(ctx, tx, model.AuditUpdate, auditApplication, appID, existing, app); err != nil {
+		return nil, err
+	}
+	return app.Tags, nil
+}
+
+// ListTags returns every tag with the number of live applications using it,
+// most used first. Unscoped, a name used by several owners is listed once per
+// owner.
+func (s *Store) ListTags(ctx context.Context) ([]model.TagCount, error) {
+	owned, ownerArgs := ownedBy(ctx, "t.owner_id")
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT t.name, (
+			SELECT COUNT(*) FROM application_tags at
+			JOIN applications a ON a.id = at.application_id
+			WHERE at.owner_id = t.owner_id AND at.tag = t.name AND a.deleted_at = ''
+		) AS uses
+		FROM tags t
+		WHERE 1 = 1`+owned+`
+		ORDER BY uses DESC, t.name`,
+		ownerArgs...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []model.TagCount{}
+	for rows.Next() {
+		var t model.TagCount
+		if err := rows.Scan(&t.Name, &t.Count); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// DeleteTag removes a tag from every application and from the tag list.
+func (s *Store) DeleteTag(ctx context.Context, name string) (bool, error) {
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	deleted := false
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			"UPDATE applications SET version = version + 1 WHERE id IN (SELECT application_id FROM application_tags WHERE tag = ?"+owned+")",
+			append([]interface{}{name}, ownerArgs...)...,
+		)
+		if err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, "DELETE FROM tags WHERE name = ?"+owned, append([]interface{}{name}, ownerArgs...)...)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil || n == 0 {
+			return err
+		}
+		deleted = true
+		return recordAudit(ctx, tx, model.AuditDelete, auditTag, name, map[string]any{"name": name}, nil)
+	})
+	return deleted, err
+}
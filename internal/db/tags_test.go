@@ -0,0 +1,106 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestTagFilters(t *testing.T) {
+	store := setupFileStore(t)
+	for _, app := range []model.CreateRequest{
+		{Company: "Acme", Role: "Eng", Tags: []string{"referral", "remote"}},
+		{Company: "Beta", Role: "Eng", Tags: []string{"referral"}},
+		{Company: "Cora", Role: "Eng", Tags: []string{"contract"}},
+		{Company: "Dune", Role: "Eng"},
+	} {
+		if _, err := store.Create(ctx, app); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		opts model.ListOptions
+		want string
+	}{
+		{name: "all of", opts: model.ListOptions{Tags: []string{"referral", "remote"}}, want: "Acme"},
+		{name: "single", opts: model.ListOptions{Tags: []string{"referral"}}, want: "Acme,Beta"},
+		{name: "any of", opts: model.ListOptions{TagsAny: []string{"remote", "contract"}}, want: "Acme,Cora"},
+		{name: "none of", opts: model.ListOptions{TagsNone: []string{"referral"}}, want: "Cora,Dune"},
+		{name: "combined", opts: model.ListOptions{TagsAny: []string{"referral", "contract"}, TagsNone: []string{"remote"}}, want: "Beta,Cora"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.SortBy, tt.opts.SortOrder, tt.opts.Limit = "company", "asc", 10
+			apps, err := store.List(ctx, tt.opts)
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			if got := companies(apps); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAddRemoveTags(t *testing.T) {
+	store := setupFileStore(t)
+	app := createTestApp(t, store)
+	if len(app.Tags) != 0 || app.Tags == nil {
+		t.Fatalf("expected empty tag list, got %#v", app.Tags)
+	}
+
+	tags, err := store.AddTags(ctx, app.ID, []string{"remote", "referral"})
+	if err != nil {
+		t.Fatalf("AddTags failed: %v", err)
+	}
+	if len(tags) != 2 || tags[0] != "referral" || tags[1] != "remote" {
+		t.Fatalf("expected sorted tags, got %v", tags)
+	}
+	got, _ := store.Get(ctx, app.ID)
+	if got.UpdatedAt != app.UpdatedAt {
+		t.Fatalf("expected tagging to leave updated_at alone")
+	}
+
+	tags, err = store.RemoveTags(ctx, app.ID, []string{"remote", "unused"})
+	if err != nil || len(tags) != 1 || tags[0] != "referral" {
+		t.Fatalf("expected [referral], got %v (%v)", tags, err)
+	}
+	if tags, err := store.AddTags(ctx, "deadbeef", []string{"x"}); err != nil || tags != nil {
+		t.Fatalf("expected nil for missing application, got %v (%v)", tags, err)
+	}
+
+	counts, err := store.ListTags(ctx)
+	if err != nil {
+		t.Fatalf("ListTags failed: %v", err)
+	}
+	if len(counts) != 2 || counts[0] != (model.TagCount{Name: "referral", Count: 1}) || counts[1] != (model.TagCount{Name: "remote", Count: 0}) {
+		t.Fatalf("unexpected counts: %+v", counts)
+	}
+
+	stats, err := store.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	if stats.ByTag["referral"] != 1 || stats.ByTag["remote"] != 0 {
+		t.Fatalf("unexpected by_tag: %v", stats.ByTag)
+	}
+
+	if deleted, err := store.DeleteTag(ctx, "referral"); err != nil || !deleted {
+		t.Fatalf("expected tag deleted, got %v (%v)", deleted, err)
+	}
+	got, _ = store.Get(ctx, app.ID)
+	if len(got.Tags) != 0 {
+		t.Fatalf("expected tag removed from application, got %v", got.Tags)
+	}
+
+	if _, err := store.AddTags(ctx, app.ID, []string{"remote"}); err != nil {
+		t.Fatalf("AddTags failed: %v", err)
+	}
+	store.Delete(ctx, app.ID)
+	counts, _ = store.ListTags(ctx)
+	if len(counts) != 1 || counts[0].Count != 0 {
+		t.Fatalf("expected trashed applications left out of counts, got %+v", counts)
+	}
+}
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// Restore takes an application out of the trash. Returns nil, nil if it is
+// not in the trash.
+func (s *Store) Restore(ctx context.Context, id string) (*model.Application, error) {
+	var restored *model.Application
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		owned, ownerArgs := ownedBy(ctx, "owner_id")
+		trashed, err := scanApplication(tx.QueryRowContext(ctx,
+			"SELECT "+applicationColumns+" FROM applications WHERE id = ? AND deleted_at <> ''"+owned,
+			append([]interface{}{id}, ownerArgs...)...,
+		))
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE applications SET deleted_at = '', version = version + 1 WHERE id = ?", id); err != nil {
+			return err
+		}
+		app := trashed
+		app.DeletedAt = ""
+		app.Version++
+		restored = &app
+		return recordAudit(ctx, tx, model.AuditRestore, auditApplication, id, trashed, app)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// Purge permanently deletes a trashed application together with its history,
+// interviews, reminders, contact links, tags and attachments. Live
+// applications must be trashed first.
+func (s *Store) Purge(ctx context.Context, id string) (bool, error) {
+	n, err := s.purge(ctx, "id = ?", id)
+	return n > 0, err
+}
+
+// PurgeTrash permanently deletes every application trashed before the given
+// RFC3339 UTC time, or the whole trash if before is empty, and returns how
+// many were removed.
+func (s *Store) PurgeTrash(ctx context.Context, before string) (int, error) {
+	if before == "" {
+		return s.purge(ctx, "1 = 1")
+	}
+	return s.purge(ctx, "deleted_at < ?", before)
+}
+
+// purge deletes the trashed applications matching cond, recording each one in
+// its owner's audit log.
+func (s *Store) purge(ctx context.Context, cond string, args ...interface{}) (int, error) {
+	owned, ownerArgs := ownedBy(ctx, "owner_id")
+	args = append(args, ownerArgs...)
+
+	purged := 0
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		trashed, err := collectOwned(ctx, tx, "SELECT "+applicationColumns+", applications.owner_id FROM applications WHERE deleted_at <> '' AND "+cond+owned, args...)
+		if err != nil {
+			return err
+		}
+		for _, o := range trashed {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM applications WHERE id = ?", o.app.ID); err != nil {
+				return err
+			}
+			if err := recordAudit(WithOwner(ctx, o.owner), tx, model.AuditPurge, auditApplication, o.app.ID, o.app, nil); err != nil {
+				return err
+			}
+		}
+		purged = len(trashed)
+		return nil
+	})
+	return purged, err
+}
+
+// ownedApplication is an application with its owner, for sweeps that change
+// several owners' rows at once and must attribute each change correctly.
+type ownedApplication struct {
+	app   model.Application
+	owner string
+}
+
+// collectOwned runs query, which selects applicationColumns followed by
+// owner_id, and reads every row before returning so the caller can write on
+// the same connection.
+func collectOwned(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]ownedApplication, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apps []ownedApplication
+	for rows.Next() {
+		var o ownedApplication
+		if o.app, err = scanApplication(rows, &o.owner); err != nil {
+			return nil, err
+		}
+		apps = append(apps, o)
+	}
+	return apps, rows.Err()
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestTrashRestoreAndPurge(t *testing.T) {
+	store := setupFileStore(t)
+	kept := createTestApp(t, store)
+	trashed, _ := store.Create(ctx, model.CreateRequest{Company: "Oops", Role: "Eng", Status: "applied", SalaryMin: intPtr(90000)})
+
+	if deleted, err := store.Delete(ctx, trashed.ID); err != nil || !deleted {
+		t.Fatalf("expected soft delete, got %v (%v)", deleted, err)
+	}
+	if deleted, _ := store.Delete(ctx, trashed.ID); deleted {
+		t.Fatal("expected deleting a trashed application to report not found")
+	}
+	if got, _ := store.Update(ctx, trashed.ID, map[string]interface{}{"notes": "x"}); got != nil {
+		t.Fatal("expected trashed application to be read-only")
+	}
+
+	live := model.ListOptions{Limit: 10}
+	if apps, _ := store.List(ctx, live); companies(apps) != "TestCo" {
+		t.Fatalf("expected trashed application hidden from List, got %s", companies(apps))
+	}
+	if n, _ := store.Count(ctx, live); n != 1 {
+		t.Fatalf("expected Count 1, got %d", n)
+	}
+	stats, _ := store.Stats(ctx)
+	if stats.Total != 1 || stats.SalaryRange.Max != 0 {
+		t.Fatalf("expected trashed application left out of stats, got %+v", stats)
+	}
+	if due, _ := store.DueReminders(ctx, "2100-01-01T00:00:00Z"); len(due) != 0 {
+		t.Fatalf("expected trashed application's follow-up hidden, got %+v", due)
+	}
+
+	trash, _ := store.List(ctx, model.ListOptions{Trashed: true, SortBy: "deleted_at", Limit: 10})
+	if len(trash) != 1 || trash[0].ID != trashed.ID || trash[0].DeletedAt == "" {
+		t.Fatalf("expected the trashed application in the trash, got %+v", trash)
+	}
+
+	restored, err := store.Restore(ctx, trashed.ID)
+	if err != nil || restored == nil || restored.DeletedAt != "" {
+		t.Fatalf("expected restore, got %+v (%v)", restored, err)
+	}
+	if again, _ := store.Restore(ctx, trashed.ID); again != nil {
+		t.Fatal("expected restoring a live application to report not found")
+	}
+	if purged, _ := store.Purge(ctx, kept.ID); purged {
+		t.Fatal("expected purge to refuse a live application")
+	}
+
+	store.Delete(ctx, trashed.ID)
+	store.Delete(ctx, kept.ID)
+	if _, err := store.db.Exec("UPDATE applications SET deleted_at = '2026-01-01T00:00:00Z' WHERE id = ?", trashed.ID); err != nil {
+		t.Fatalf("backdating deletion: %v", err)
+	}
+	n, err := store.PurgeTrash(ctx, "2026-02-01T00:00:00Z")
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 purged, got %d (%v)", n, err)
+	}
+	if history, _ := store.History(ctx, trashed.ID); len(history) != 0 {
+		t.Fatalf("expected purge to cascade, got %d history entries", len(history))
+	}
+	if n, _ := store.PurgeTrash(ctx, ""); n != 1 {
+		t.Fatalf("expected emptying the trash to purge the rest, got %d", n)
+	}
+}
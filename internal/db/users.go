@@ -0,0 +1,289 @@
+package db
+
+import (
+	"context"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+const (
+	// SessionTTL is how long a login lasts.
+	SessionTTL = 30 * 24 * time.Hour
+
+	sessionTokenPrefix = "jhs_"
+	passwordScheme     = "pbkdf2-sha256"
+)
+
+// passwordIterations is the PBKDF2 work factor for new hashes. Stored hashes
+// record their own, so raising it only affects passwords set afterwards.
+var passwordIterations = 600_000
+
+// ErrEmailTaken is returned by CreateUser when the address already has an
+// account.
+var ErrEmailTaken = errors.New("an account with that email already exists")
+
+type ownerKey struct{}
+
+// WithOwner returns a context in which store reads see only userID's rows
+// and new rows belong to userID. Without it the store is unscoped: reads see
+// every user's rows and new rows have no owner, which is how background jobs
+// and a server with auth disabled run.
+func WithOwner(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, ownerKey{}, userID)
+}
+
+func ownerFrom(ctx context.Context) (string, bool) {
+	owner, ok := ctx.Value(ownerKey{}).(string)
+	return owner, ok
+}
+
+// ownerOf returns the owner for rows created in ctx.
+func ownerOf(ctx context.Context) string {
+	owner, _ := ownerFrom(ctx)
+	return owner
+}
+
+// ownedBy returns " AND column = ?" with its argument when ctx is scoped to
+// an owner, and nothing when it is not.
+func ownedBy(ctx context.Context, column string) (string, []interface{}) {
+	owner, ok := ownerFrom(ctx)
+	if !ok {
+		return "", nil
+	}
+	return " AND " + column + " = ?", []interface{}{owner}
+}
+
+// appOwner selects an application's owner, for child rows inserted with
+// INSERT ... SELECT so they always match their parent.
+const appOwner = "(SELECT owner_id FROM applications WHERE id = ?)"
+
+func hashPassword(password string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := pbkdf2.Key(sha256.New, password, salt, passwordIterations, 32)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s$%d$%s$%s", passwordScheme, passwordIterations,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func checkPassword(hash, password string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != passwordScheme {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+	got, err := pbkdf2.Key(sha256.New, password, salt, iterations, len(want))
+	return err == nil && subtle.ConstantTimeCompare(got, want) == 1
+}
+
+// CreateUser adds an account. email and password must already be validated.
+// The first account adopts everything created before accounts existed:
+// applications and their children, contacts, tags, shares, audit entries
+// and API keys.
+func (s *Store) CreateUser(ctx context.Context, email, password string) (*model.User, error) {
+	hash, err := hashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("hashing password: %w", err)
+	}
+	user := model.User{ID: generateID(), Email: email, CreatedAt: time.Now().UTC().Format(time.RFC3339)}
+
+	err = s.inTx(ctx, func(tx *sql.Tx) error {
+		var users, taken int
+		err := tx.QueryRowContext(ctx,
+			"SELECT COUNT(*), COUNT(CASE WHEN email = ? THEN 1 END) FROM users", email,
+		).Scan(&users, &taken)
+		if err != nil {
+			return err
+		}
+		if taken > 0 {
+			return ErrEmailTaken
+		}
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO users (id, email, password_hash, created_at) VALUES (?, ?, ?, ?)",
+			user.ID, user.Email, hash, user.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		if users == 0 {
+			// Updating tags cascades to application_tags.
+			for _, table := range []string{"applications", "status_history", "contacts", "application_contacts", "interviews", "reminders", "attachments", "tags", "shares", "audit_log"} {
+				if _, err := tx.ExecContext(ctx, "UPDATE "+table+" SET owner_id = ? WHERE owner_id = ''", user.ID); err != nil {
+					return fmt.Errorf("adopting %s: %w", table, err)
+				}
+			}
+			if _, err := tx.ExecContext(ctx, "UPDATE api_keys SET user_id = ? WHERE user_id = ''", user.ID); err != nil {
+				return fmt.Errorf("adopting api_keys: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUser returns nil, nil if there is no such account.
+func (s *Store) GetUser(ctx context.Context, id string) (*model.User, error) {
+	return s.getUser(ctx, "id", id)
+}
+
+// GetUserByEmail returns nil, nil if there is no such account.
+func (s *Store) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	return s.getUser(ctx, "email", email)
+}
+
+func (s *Store) getUser(ctx context.Context, column, value string) (*model.User, error) {
+	var u model.User
+	err := s.db.QueryRowContext(ctx, "SELECT id, email, created_at FROM users WHERE "+column+" = ?", value).Scan(&u.ID, &u.Email, &u.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// ListUsers returns every account, oldest first.
+func (s *Store) ListUsers(ctx context.Context) ([]model.User, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, email, created_at FROM users ORDER BY created_at, email")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []model.User{}
+	for rows.Next() {
+		var u model.User
+		if err := rows.Scan(&u.ID, &u.Email, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+// Login checks a password and starts a session. Returns nil, nil if the email
+// or password is wrong, taking about as long either way.
+func (s *Store) Login(ctx context.Context, email, password string) (*model.Session, error) {
+	var u model.User
+	var hash string
+	err := s.db.QueryRowContext(ctx,
+		"SELECT id, email, created_at, password_hash FROM users WHERE email = ?", email,
+	).Scan(&u.ID, &u.Email, &u.CreatedAt, &hash)
+	if err == sql.ErrNoRows {
+		// Spend the same work as a real check so timing does not reveal
+		// which addresses have accounts.
+		checkPassword(dummyPasswordHash(), password)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !checkPassword(hash, password) {
+		return nil, nil
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("generating session token: %w", err)
+	}
+	now := time.Now().UTC()
+	session := model.Session{
+		Token:     sessionTokenPrefix + hex.EncodeToString(raw),
+		User:      u,
+		ExpiresAt: now.Add(SessionTTL).Format(time.RFC3339),
+	}
+	err = s.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM sessions WHERE user_id = ? AND expires_at <= ?", u.ID, now.Format(time.RFC3339)); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO sessions (token_hash, user_id, created_at, expires_at) VALUES (?, ?, ?, ?)",
+			hashSecret(session.Token), u.ID, now.Format(time.RFC3339), session.ExpiresAt,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// dummyPasswordHash is checked against when a login names an unknown email.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := hashPassword("not a real password")
+	return hash
+})
+
+// SessionUser returns the user a live session token belongs to, or nil, nil
+// if the token is unknown or expired.
+func (s *Store) SessionUser(ctx context.Context, token string) (*model.Session, error) {
+	if !strings.HasPrefix(token, sessionTokenPrefix) {
+		return nil, nil
+	}
+	var session model.Session
+	u := &session.User
+	err := s.db.QueryRowContext(ctx,
+		`SELECT u.id, u.email, u.created_at, s.expires_at FROM sessions s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.token_hash = ? AND s.expires_at > ?`,
+		hashSecret(token), time.Now().UTC().Format(time.RFC3339),
+	).Scan(&u.ID, &u.Email, &u.CreatedAt, &session.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Logout ends a session. Returns false if it did not exist.
+func (s *Store) Logout(ctx context.Context, token string) (bool, error) {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM sessions WHERE token_hash = ?", hashSecret(token))
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// IsSessionToken reports whether token has the shape of a session token
+// rather than an API key.
+func IsSessionToken(token string) bool {
+	return strings.HasPrefix(token, sessionTokenPrefix)
+}
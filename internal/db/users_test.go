@@ -0,0 +1,187 @@
+package db
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestPasswordHash(t *testing.T) {
+	hash, err := hashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("hashPassword failed: %v", err)
+	}
+	if !strings.HasPrefix(hash, "pbkdf2-sha256$") || strings.Contains(hash, "correct horse") {
+		t.Fatalf("unexpected hash %q", hash)
+	}
+	if !checkPassword(hash, "correct horse") {
+		t.Fatal("expected the right password accepted")
+	}
+	for _, bad := range []string{"", "correct horsE", "correct horse "} {
+		if checkPassword(hash, bad) {
+			t.Fatalf("expected %q rejected", bad)
+		}
+	}
+	if other, _ := hashPassword("correct horse"); other == hash {
+		t.Fatal("expected a fresh salt per hash")
+	}
+	if checkPassword("md5$1$x$y", "correct horse") {
+		t.Fatal("expected unknown schemes rejected")
+	}
+}
+
+func TestLoginSessions(t *testing.T) {
+	store := setupFileStore(t)
+
+	user, err := store.CreateUser(ctx, "ann@example.com", "correct horse")
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if _, err := store.CreateUser(ctx, "ann@example.com", "another one"); err != ErrEmailTaken {
+		t.Fatalf("expected ErrEmailTaken, got %v", err)
+	}
+
+	for _, creds := range [][2]string{{"ann@example.com", "wrong password"}, {"bob@example.com", "correct horse"}} {
+		if s, err := store.Login(ctx, creds[0], creds[1]); err != nil || s != nil {
+			t.Fatalf("expected %v refused, got %+v (%v)", creds, s, err)
+		}
+	}
+
+	session, err := store.Login(ctx, "ann@example.com", "correct horse")
+	if err != nil || session == nil || !IsSessionToken(session.Token) || session.User.ID != user.ID {
+		t.Fatalf("expected a session, got %+v (%v)", session, err)
+	}
+	got, err := store.SessionUser(ctx, session.Token)
+	if err != nil || got == nil || got.User.Email != "ann@example.com" || got.Token != "" {
+		t.Fatalf("expected the session's user, got %+v (%v)", got, err)
+	}
+	if got, _ := store.SessionUser(ctx, session.Token+"x"); got != nil {
+		t.Fatal("expected an unknown token refused")
+	}
+
+	// Expired sessions stop working and are cleared at the next login.
+	store.db.Exec("UPDATE sessions SET expires_at = '2000-01-01T00:00:00Z'")
+	if got, _ := store.SessionUser(ctx, session.Token); got != nil {
+		t.Fatal("expected an expired session refused")
+	}
+	fresh, _ := store.Login(ctx, "ann@example.com", "correct horse")
+	var n int
+	store.db.QueryRow("SELECT COUNT(*) FROM sessions").Scan(&n)
+	if n != 1 {
+		t.Fatalf("expected the expired session cleared, got %d sessions", n)
+	}
+
+	if ok, err := store.Logout(ctx, fresh.Token); err != nil || !ok {
+		t.Fatalf("expected logout, got %v (%v)", ok, err)
+	}
+	if got, _ := store.SessionUser(ctx, fresh.Token); got != nil {
+		t.Fatal("expected a logged-out session refused")
+	}
+}
+
+func TestFirstUserAdoptsExistingData(t *testing.T) {
+	store := setupFileStore(t)
+	app := createTestApp(t, store)
+	store.AddTags(ctx, app.ID, []string{"remote"})
+	store.CreateAPIKey(ctx, "", "laptop", model.ScopeRead)
+
+	first, err := store.CreateUser(ctx, "ann@example.com", "correct horse")
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	second, _ := store.CreateUser(ctx, "bob@example.com", "correct horse")
+
+	annCtx := WithOwner(ctx, first.ID)
+	if got, _ := store.Get(annCtx, app.ID); got == nil || len(got.Tags) != 1 {
+		t.Fatalf("expected the first user to own existing data, got %+v", got)
+	}
+	if tags, _ := store.ListTags(annCtx); len(tags) != 1 || tags[0].Count != 1 {
+		t.Fatalf("expected the first user to own existing tags, got %+v", tags)
+	}
+	if keys, _ := store.ListAPIKeys(ctx); keys[0].UserID != first.ID {
+		t.Fatalf("expected the first user to own existing keys, got %+v", keys)
+	}
+	if entries, _ := store.AuditLog(annCtx, AuditFilter{EntityID: app.ID, Limit: 10}); len(entries) == 0 {
+		t.Fatal("expected the first user to own existing audit entries")
+	}
+	if _, err := store.db.Exec("UPDATE audit_log SET owner_id = ?", second.ID); err == nil {
+		t.Fatal("expected adopted audit entries to stay append-only")
+	}
+	if got, _ := store.Get(WithOwner(ctx, second.ID), app.ID); got != nil {
+		t.Fatal("expected later users to adopt nothing")
+	}
+}
+
+func TestOwnerIsolation(t *testing.T) {
+	store := setupFileStore(t)
+	ann := WithOwner(ctx, "ann")
+	bob := WithOwner(ctx, "bob")
+
+	annApp, err := store.Create(ann, model.CreateRequest{Company: "Acme", Role: "Eng", Status: "applied", SalaryMin: intPtr(100000), Tags: []string{"remote"}})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	bobApp, _ := store.Create(bob, model.CreateRequest{Company: "Globex", Role: "Eng", Tags: []string{"remote"}})
+
+	all := model.ListOptions{Limit: 10}
+	if apps, _ := store.List(bob, all); companies(apps) != "Globex" {
+		t.Fatalf("expected bob to list only his applications, got %s", companies(apps))
+	}
+	if n, _ := store.Count(bob, all); n != 1 {
+		t.Fatalf("expected bob's count to be 1, got %d", n)
+	}
+	if apps, _ := store.List(ctx, all); len(apps) != 2 {
+		t.Fatalf("expected an unscoped list to see both, got %d", len(apps))
+	}
+	if got, _ := store.Get(bob, annApp.ID); got != nil {
+		t.Fatal("expected bob unable to get ann's application")
+	}
+	if got, _ := store.Update(bob, annApp.ID, map[string]interface{}{"notes": "mine now"}); got != nil {
+		t.Fatal("expected bob unable to update ann's application")
+	}
+	if deleted, _ := store.Delete(bob, annApp.ID); deleted {
+		t.Fatal("expected bob unable to delete ann's application")
+	}
+	if history, _ := store.History(bob, annApp.ID); len(history) != 0 {
+		t.Fatalf("expected bob to see none of ann's history, got %+v", history)
+	}
+
+	stats, _ := store.Stats(bob)
+	if stats.Total != 1 || stats.ByStatus["applied"] != 0 || stats.SalaryRange.Max != 0 || stats.ByTag["remote"] != 1 {
+		t.Fatalf("expected bob's stats to cover only his application, got %+v", stats)
+	}
+
+	// Both own a tag called remote; deleting bob's leaves ann's alone.
+	if deleted, _ := store.DeleteTag(bob, "remote"); !deleted {
+		t.Fatal("expected bob to delete his own tag")
+	}
+	if got, _ := store.Get(ann, annApp.ID); got == nil || len(got.Tags) != 1 {
+		t.Fatalf("expected ann's tag untouched, got %+v", got)
+	}
+
+	// Child rows follow their application's owner even when created
+	// unscoped, as background jobs do.
+	if _, err := store.CreateReminder(ctx, annApp.ID, "2000-01-01T00:00:00Z", "ping"); err != nil {
+		t.Fatalf("CreateReminder failed: %v", err)
+	}
+	if due, _ := store.DueReminders(bob, "2100-01-01T00:00:00Z"); len(due) != 0 {
+		t.Fatalf("expected bob to see none of ann's reminders, got %+v", due)
+	}
+	if due, _ := store.DueReminders(ann, "2100-01-01T00:00:00Z"); len(due) != 2 {
+		t.Fatalf("expected ann to see her follow-up and custom reminder, got %+v", due)
+	}
+
+	entries, _ := store.AuditLog(bob, AuditFilter{Limit: 100})
+	if len(entries) == 0 {
+		t.Fatal("expected bob to see his own audit entries")
+	}
+	for _, e := range entries {
+		if e.EntityID == annApp.ID {
+			t.Fatalf("expected none of ann's entries in bob's audit log, got %+v", e)
+		}
+	}
+	if got, _ := store.Get(bob, bobApp.ID); got == nil {
+		t.Fatal("expected bob to still see his own application")
+	}
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+const maxAttachmentBytes = 10 << 20 // 10 MB
+
+var validSHA256Regex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+func (h *Handler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	app, ok := h.applicationFromPath(w, r)
+	if !ok {
+		return
+	}
+	attachments, err := h.store.ListAttachments(r.Context(), app.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list attachments")
+		return
+	}
+	respondJSON(w, http.StatusOK, attachments)
+}
+
+// UploadAttachment stores the "file" part of a multipart/form-data body as an
+// attachment of the given "kind". Re-uploading a file the application already
+// has under that kind returns the existing attachment with 200.
+func (h *Handler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		respondError(w, http.StatusUnsupportedMediaType, "Content-Type must be multipart/form-data")
+		return
+	}
+	app, ok := h.applicationFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	// The body is already capped at maxAttachmentBytes, so the whole form
+	// fits in memory and nothing is spooled to temp files.
+	if err := r.ParseMultipartForm(maxAttachmentBytes); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondError(w, http.StatusRequestEntityTooLarge, "attachment too large (max 10 MB)")
+			return
+		}
+		respondError(w, http.StatusBadRequest, "invalid multipart body")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	kind := strings.TrimSpace(r.FormValue("kind"))
+	if kind == "" {
+		respondError(w, http.StatusBadRequest, "kind is required")
+		return
+	}
+	if err := model.ValidateAttachmentKind(kind); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "failed to read file")
+		return
+	}
+	if len(data) == 0 {
+		respondError(w, http.StatusBadRequest, "file is empty")
+		return
+	}
+
+	filename := filepath.Base(strings.ReplaceAll(header.Filename, `\`, "/"))
+	if filename == "." || filename == "/" {
+		respondError(w, http.StatusBadRequest, "file must have a filename")
+		return
+	}
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" || contentType == "application/octet-stream" {
+		contentType = http.DetectContentType(data)
+	}
+
+	att, created, err := h.store.CreateAttachment(r.Context(), model.Attachment{
+		ApplicationID: app.ID,
+		Kind:          kind,
+		Filename:      filename,
+		ContentType:   contentType,
+	}, data)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to store attachment")
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	respondJSON(w, status, att)
+}
+
+// DownloadAttachment responds with the attachment's content under its
+// original filename.
+func (h *Handler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	app, ok := h.applicationFromPath(w, r)
+	if !ok {
+		return
+	}
+	attachmentID := chi.URLParam(r, "attachmentID")
+	if !isValidID(attachmentID) {
+		respondError(w, http.StatusBadRequest, "invalid attachment ID format")
+		return
+	}
+
+	att, data, err := h.store.AttachmentContent(r.Context(), app.ID, attachmentID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get attachment")
+		return
+	}
+	if att == nil {
+		respondError(w, http.StatusNotFound, "attachment not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", att.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": att.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func (h *Handler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	app, ok := h.applicationFromPath(w, r)
+	if !ok {
+		return
+	}
+	attachmentID := chi.URLParam(r, "attachmentID")
+	if !isValidID(attachmentID) {
+		respondError(w, http.StatusBadRequest, "invalid attachment ID format")
+		return
+	}
+
+	deleted, err := h.store.DeleteAttachment(r.Context(), app.ID, attachmentID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to delete attachment")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "attachment not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListAttachmentUses answers "where did this version go?": every application
+// holding a file with the given sha256.
+func (h *Handler) ListAttachmentUses(w http.ResponseWriter, r *http.Request) {
+	sha := strings.ToLower(r.URL.Query().Get("sha256"))
+	if !validSHA256Regex.MatchString(sha) {
+		respondError(w, http.StatusBadRequest, "sha256 must be 64 hex characters")
+		return
+	}
+	uses, err := h.store.AttachmentUses(r.Context(), sha)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list attachments")
+		return
+	}
+	respondJSON(w, http.StatusOK, uses)
+}
@@ -0,0 +1,116 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func uploadFile(t *testing.T, r chi.Router, appID, kind, filename string, content []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if kind != "" {
+		mw.WriteField("kind", kind)
+	}
+	if filename != "" {
+		part, err := mw.CreateFormFile("file", filename)
+		if err != nil {
+			t.Fatalf("creating form file: %v", err)
+		}
+		part.Write(content)
+	}
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/applications/"+appID+"/attachments", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAttachments(t *testing.T) {
+	_, r := setupTest(t)
+	app := createApp(t, r, `{"company":"Acme","role":"Eng"}`)
+	pdf := []byte("%PDF-1.7\nresume for acme")
+
+	w := uploadFile(t, r, app.ID, "resume", "résumé-acme.pdf", pdf)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var att model.Attachment
+	json.NewDecoder(w.Body).Decode(&att)
+	if att.ContentType != "application/pdf" || att.Filename != "résumé-acme.pdf" || att.Size != int64(len(pdf)) {
+		t.Fatalf("unexpected metadata: %+v", att)
+	}
+
+	if w := uploadFile(t, r, app.ID, "resume", "copy.pdf", pdf); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 for duplicate upload, got %d", w.Code)
+	}
+	if w := uploadFile(t, r, app.ID, "portfolio", "a.pdf", pdf); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown kind, got %d", w.Code)
+	}
+	if w := uploadFile(t, r, app.ID, "resume", "", nil); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without file, got %d", w.Code)
+	}
+	if w := uploadFile(t, r, "deadbeef", "resume", "a.pdf", pdf); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for missing application, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodPost, "/applications/"+app.ID+"/attachments", `{}`); w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected 415 for JSON body, got %d", w.Code)
+	}
+
+	w = doRequest(r, http.MethodGet, "/applications/"+app.ID+"/attachments", "")
+	var list []model.Attachment
+	json.NewDecoder(w.Body).Decode(&list)
+	if len(list) != 1 || list[0].ID != att.ID {
+		t.Fatalf("expected one attachment, got %+v", list)
+	}
+
+	w = doRequest(r, http.MethodGet, "/applications/"+app.ID+"/attachments/"+att.ID, "")
+	if w.Code != http.StatusOK || w.Body.String() != string(pdf) {
+		t.Fatalf("unexpected download: %d %q", w.Code, w.Body.String())
+	}
+	if cd := w.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, "attachment;") || !strings.Contains(cd, "filename*=utf-8''r%C3%A9sum%C3%A9-acme.pdf") {
+		t.Fatalf("unexpected Content-Disposition: %q", cd)
+	}
+
+	other := createApp(t, r, `{"company":"Beta","role":"Eng"}`)
+	uploadFile(t, r, other.ID, "resume", "resume.pdf", pdf)
+	w = doRequest(r, http.MethodGet, "/attachments?sha256="+att.SHA256, "")
+	var uses []model.AttachmentUse
+	json.NewDecoder(w.Body).Decode(&uses)
+	if len(uses) != 2 || uses[0].Company+uses[1].Company != "AcmeBeta" && uses[0].Company+uses[1].Company != "BetaAcme" {
+		t.Fatalf("expected the resume listed for both applications, got %+v", uses)
+	}
+	if w := doRequest(r, http.MethodGet, "/attachments?sha256=xyz", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for bad hash, got %d", w.Code)
+	}
+
+	w = doRequest(r, http.MethodDelete, "/applications/"+app.ID+"/attachments/"+att.ID, "")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	w = doRequest(r, http.MethodGet, "/applications/"+app.ID+"/attachments/"+att.ID, "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestUploadAttachment_TooLarge(t *testing.T) {
+	_, r := setupTest(t)
+	app := createApp(t, r, `{"company":"Acme","role":"Eng"}`)
+
+	w := uploadFile(t, r, app.ID, "resume", "huge.pdf", bytes.Repeat([]byte("x"), 11<<20))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+)
+
+const (
+	// anonymousActor is recorded for requests that do not say who made them.
+	anonymousActor = "anonymous"
+
+	defaultAuditLimit = 100
+	maxAuditLimit     = 500
+)
+
+// auditContext attributes store changes made while serving a request to the
+// logged-in user's email or the API key's name (or, with auth off, the
+// X-Actor header) and the request ID
+// set by middleware.RequestID, and echoes the ID back so clients can find
+// their entries in the audit log.
+func auditContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := strings.TrimSpace(r.Header.Get("X-Actor"))
+		if p := principalFrom(r.Context()); p != nil {
+			actor = p.Actor
+		}
+		if actor == "" {
+			actor = anonymousActor
+		}
+		reqID := middleware.GetReqID(r.Context())
+		if reqID != "" {
+			w.Header().Set(middleware.RequestIDHeader, reqID)
+		}
+		ctx := db.WithAuditInfo(r.Context(), db.AuditInfo{Actor: actor, RequestID: reqID})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ListAudit returns audit log entries, newest first. entity_id, entity and
+// actor filter exactly; since is RFC3339 or YYYY-MM-DD.
+func (h *Handler) ListAudit(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	f := db.AuditFilter{
+		EntityID:   q.Get("entity_id"),
+		EntityType: q.Get("entity"),
+		Actor:      q.Get("actor"),
+		Limit:      defaultAuditLimit,
+	}
+	if v := q.Get("since"); v != "" {
+		since, err := parseAgendaBound(v, false)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("since %s", err))
+			return
+		}
+		f.Since = since
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxAuditLimit {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxAuditLimit))
+			return
+		}
+		f.Limit = limit
+	}
+
+	entries, err := h.store.AuditLog(r.Context(), f)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list audit log")
+		return
+	}
+	respondJSON(w, http.StatusOK, entries)
+}
@@ -0,0 +1,72 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestAuditLogRecordsActorAndRequestID(t *testing.T) {
+	_, r := setupTest(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/applications", bytes.NewBufferString(`{"company":"Acme","role":"Engineer"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Actor", "alice")
+	req.Header.Set("X-Request-Id", "req-42")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("X-Request-Id"); got != "req-42" {
+		t.Fatalf("expected request ID echoed, got %q", got)
+	}
+	var app model.Application
+	json.NewDecoder(w.Body).Decode(&app)
+
+	w = doRequest(r, http.MethodPut, "/applications/"+app.ID, `{"status":"applied"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("X-Request-Id") == "" {
+		t.Fatal("expected a generated request ID")
+	}
+	createApp(t, r, `{"company":"Other","role":"SRE"}`)
+
+	w = doRequest(r, http.MethodGet, "/audit?entity_id="+app.ID, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var entries []model.AuditEntry
+	json.NewDecoder(w.Body).Decode(&entries)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries for the application, got %+v", entries)
+	}
+	upd, create := entries[0], entries[1]
+	if create.Action != "create" || create.Actor != "alice" || create.RequestID != "req-42" {
+		t.Fatalf("unexpected create entry %+v", create)
+	}
+	if upd.Action != "update" || upd.Actor != "anonymous" || upd.RequestID == "" || string(upd.After) != `{"status":"applied"}` {
+		t.Fatalf("unexpected update entry %+v (after %s)", upd, upd.After)
+	}
+
+	w = doRequest(r, http.MethodGet, "/audit?actor=alice&since=2000-01-01", "")
+	entries = nil
+	json.NewDecoder(w.Body).Decode(&entries)
+	if len(entries) != 1 || entries[0].EntityID != app.ID {
+		t.Fatalf("expected only alice's entry, got %+v", entries)
+	}
+}
+
+func TestListAuditValidation(t *testing.T) {
+	_, r := setupTest(t)
+	for _, q := range []string{"since=yesterday", "limit=0", "limit=501", "limit=x"} {
+		if w := doRequest(r, http.MethodGet, "/audit?"+q, ""); w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", q, w.Code)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// principal is who a request acts for: the user whose data it sees, the name
+// the audit log records, and what it may do.
+type principal struct {
+	UserID string
+	Actor  string
+	Scope  string
+}
+
+type principalContextKey struct{}
+
+// principalFrom returns who the request was authenticated as, or nil when
+// auth is off.
+func principalFrom(ctx context.Context) *principal {
+	p, _ := ctx.Value(principalContextKey{}).(*principal)
+	return p
+}
+
+// bearerToken returns the credential in an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) string {
+	scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// authenticate rejects requests without a live session token or API key in
+// an "Authorization: Bearer" header, and scopes the store to that user.
+func (h *Handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !h.requireAuth {
+			next.ServeHTTP(w, r)
+			return
+		}
+		token := bearerToken(r)
+		if token == "" {
+			unauthorized(w, "missing credentials: send Authorization: Bearer <session token or API key>")
+			return
+		}
+		p, err := h.principalFor(r.Context(), token)
+		if err != nil {
+			slog.Error("authenticating request", "error", err)
+			respondError(w, http.StatusInternalServerError, "failed to check credentials")
+			return
+		}
+		if p == nil {
+			unauthorized(w, "invalid, expired or revoked credentials")
+			return
+		}
+		ctx := context.WithValue(r.Context(), principalContextKey{}, p)
+		next.ServeHTTP(w, r.WithContext(db.WithOwner(ctx, p.UserID)))
+	})
+}
+
+// principalFor resolves a session token or API key. Keys left over from
+// before accounts existed belong to no one and are refused.
+func (h *Handler) principalFor(ctx context.Context, token string) (*principal, error) {
+	if db.IsSessionToken(token) {
+		session, err := h.store.SessionUser(ctx, token)
+		if err != nil || session == nil {
+			return nil, err
+		}
+		return &principal{UserID: session.User.ID, Actor: session.User.Email, Scope: model.ScopeReadWrite}, nil
+	}
+	key, err := h.store.AuthenticateAPIKey(ctx, token)
+	if err != nil || key == nil || key.UserID == "" {
+		return nil, err
+	}
+	return &principal{UserID: key.UserID, Actor: key.Name, Scope: key.Scope}, nil
+}
+
+func unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="job-hunt-platform"`)
+	respondError(w, http.StatusUnauthorized, message)
+}
+
+// requireScope rejects requests whose credentials do not grant scope. It must
+// run after authenticate; with auth off there is nothing to check.
+func requireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if p := principalFrom(r.Context()); p != nil && !model.ScopeAllows(p.Scope, scope) {
+				respondError(w, http.StatusForbidden, "API key scope "+p.Scope+" does not allow this request")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Login exchanges an email and password for a session token.
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	var req model.LoginRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	email, err := model.NormalizeEmail(req.Email)
+	if err != nil || req.Password == "" {
+		unauthorized(w, "wrong email or password")
+		return
+	}
+	session, err := h.store.Login(r.Context(), email, req.Password)
+	if err != nil {
+		slog.Error("logging in", "error", err)
+		respondError(w, http.StatusInternalServerError, "failed to log in")
+		return
+	}
+	if session == nil {
+		unauthorized(w, "wrong email or password")
+		return
+	}
+	respondJSON(w, http.StatusOK, session)
+}
+
+// Logout ends the session the request was made with.
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	token := bearerToken(r)
+	if !db.IsSessionToken(token) {
+		respondError(w, http.StatusBadRequest, "logout needs a session token, not an API key")
+		return
+	}
+	if _, err := h.store.Logout(r.Context(), token); err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to log out")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Me returns the user the request acts for.
+func (h *Handler) Me(w http.ResponseWriter, r *http.Request) {
+	p := principalFrom(r.Context())
+	if p == nil {
+		respondError(w, http.StatusNotFound, "auth is disabled, so requests act for no user")
+		return
+	}
+	user, err := h.store.GetUser(r.Context(), p.UserID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get user")
+		return
+	}
+	if user == nil {
+		respondError(w, http.StatusNotFound, "user not found")
+		return
+	}
+	respondJSON(w, http.StatusOK, user)
+}
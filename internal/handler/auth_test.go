@@ -0,0 +1,115 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+	"github.com/shakilbd009/job-hunt-platform/internal/handler"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// setupAuthTest returns a router with credentials required, plus the secrets
+// of a read-only and a read-write key belonging to one user.
+func setupAuthTest(t *testing.T) (r chi.Router, readKey, writeKey string) {
+	t.Helper()
+	store, err := db.NewStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	ctx := t.Context()
+	user, err := store.CreateUser(ctx, "owner@example.com", "correct horse")
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if _, readKey, err = store.CreateAPIKey(ctx, user.ID, "dashboard", model.ScopeRead); err != nil {
+		t.Fatalf("CreateAPIKey failed: %v", err)
+	}
+	if _, writeKey, err = store.CreateAPIKey(ctx, user.ID, "laptop", model.ScopeReadWrite); err != nil {
+		t.Fatalf("CreateAPIKey failed: %v", err)
+	}
+
+	h := handler.New(store)
+	r = chi.NewRouter()
+	h.HealthRoutes(r)
+	h.Routes(r)
+	return r, readKey, writeKey
+}
+
+func doAuthRequest(r chi.Router, method, path, body, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthRequiresKey(t *testing.T) {
+	r, _, _ := setupAuthTest(t)
+
+	if w := doAuthRequest(r, http.MethodGet, "/health", "", ""); w.Code != http.StatusOK {
+		t.Fatalf("expected /health open, got %d", w.Code)
+	}
+	for _, key := range []string{"", "jht_0000", "not-a-key"} {
+		w := doAuthRequest(r, http.MethodGet, "/applications", "", key)
+		if w.Code != http.StatusUnauthorized || w.Header().Get("WWW-Authenticate") == "" {
+			t.Fatalf("key %q: expected 401 with a challenge, got %d", key, w.Code)
+		}
+	}
+	req := httptest.NewRequest(http.MethodGet, "/applications", nil)
+	req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected non-bearer auth rejected, got %d", w.Code)
+	}
+}
+
+func TestAuthScopes(t *testing.T) {
+	r, readKey, writeKey := setupAuthTest(t)
+
+	if w := doAuthRequest(r, http.MethodGet, "/applications", "", readKey); w.Code != http.StatusOK {
+		t.Fatalf("expected read key to list, got %d", w.Code)
+	}
+	if w := doAuthRequest(r, http.MethodPost, "/applications", `{"company":"Acme","role":"Eng"}`, readKey); w.Code != http.StatusForbidden {
+		t.Fatalf("expected read key refused a create, got %d", w.Code)
+	}
+	if w := doAuthRequest(r, http.MethodPost, "/reminders/abcd1234/complete", "", readKey); w.Code != http.StatusForbidden {
+		t.Fatalf("expected read key refused a bodiless write, got %d", w.Code)
+	}
+
+	w := doAuthRequest(r, http.MethodPost, "/applications", `{"company":"Acme","role":"Eng"}`, writeKey)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected read-write key to create, got %d: %s", w.Code, w.Body.String())
+	}
+	var app model.Application
+	json.NewDecoder(w.Body).Decode(&app)
+	if w := doAuthRequest(r, http.MethodDelete, "/applications/"+app.ID, "", readKey); w.Code != http.StatusForbidden {
+		t.Fatalf("expected read key refused a delete, got %d", w.Code)
+	}
+
+	// Changes are attributed to the key, not whatever X-Actor claims.
+	req := httptest.NewRequest(http.MethodPut, "/applications/"+app.ID, bytes.NewBufferString(`{"notes":"hi"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+writeKey)
+	req.Header.Set("X-Actor", "someone-else")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	w = doAuthRequest(r, http.MethodGet, "/audit?entity_id="+app.ID, "", readKey)
+	var entries []model.AuditEntry
+	json.NewDecoder(w.Body).Decode(&entries)
+	if len(entries) != 2 || entries[0].Actor != "laptop" || entries[1].Actor != "laptop" {
+		t.Fatalf("expected changes attributed to the key name, got %+v", entries)
+	}
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+type BulkItemResult struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkReport struct {
+	Action  string           `json:"action"`
+	DryRun  bool             `json:"dry_run"`
+	Matched int              `json:"matched"`
+	Changed int              `json:"changed"`
+	Failed  int              `json:"failed"`
+	Items   []BulkItemResult `json:"items"`
+}
+
+// wouldBe names what a dry run reports instead of each change it rolled
+// back.
+var wouldBe = map[string]string{
+	db.BulkUpdated: "would_update",
+	db.BulkDeleted: "would_delete",
+}
+
+// BulkApplications applies one action to a list of applications, given by
+// ID or as a list query, in a single transaction. Applications the action
+// cannot apply to are reported and left alone; the rest are changed.
+// dry_run=true reports what would happen without writing.
+func (h *Handler) BulkApplications(w http.ResponseWriter, r *http.Request) {
+	dryRun := false
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "dry_run must be true or false")
+			return
+		}
+		dryRun = b
+	}
+
+	var req model.BulkRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	fields, err := req.Normalize()
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	for _, id := range req.IDs {
+		if !isValidID(id) {
+			respondError(w, http.StatusBadRequest, "invalid application ID format: "+id)
+			return
+		}
+	}
+	if req.Action == model.BulkSetStatus {
+		pipeline, err := h.store.Pipeline(r.Context())
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "failed to load stages")
+			return
+		}
+		if err := pipeline.ValidateStatus(req.Status); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	var ids []string
+	var filter model.ListOptions
+	if req.Query != "" {
+		query, err := url.ParseQuery(strings.TrimPrefix(req.Query, "?"))
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "query must be URL query parameters, e.g. status=applied&tag=remote")
+			return
+		}
+		query.Del("limit")
+		query.Del("offset")
+		var ok bool
+		if filter, ok = h.parseListQuery(w, r, query); !ok {
+			return
+		}
+	} else {
+		ids = req.IDs
+	}
+
+	results, err := h.store.Bulk(r.Context(), ids, filter, db.BulkAction{
+		Kind:   req.Action,
+		Fields: fields,
+		Tags:   req.Tags,
+		Options: db.UpdateOptions{
+			StatusNote:         req.StatusNote,
+			OverrideTransition: req.OverrideTransition,
+		},
+	}, dryRun)
+	if errors.Is(err, db.ErrTooManyItems) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, db.ErrInvalidSearch) {
+		respondError(w, http.StatusBadRequest, invalidSearchMessage)
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to apply bulk action")
+		return
+	}
+
+	report := BulkReport{Action: req.Action, DryRun: dryRun, Matched: len(results), Items: make([]BulkItemResult, 0, len(results))}
+	for _, res := range results {
+		item := BulkItemResult{ID: res.ID, Status: res.Outcome, Error: res.Error}
+		switch res.Outcome {
+		case db.BulkUpdated, db.BulkDeleted:
+			report.Changed++
+			if dryRun {
+				item.Status = wouldBe[res.Outcome]
+			}
+		case db.BulkFailed:
+			report.Failed++
+		}
+		report.Items = append(report.Items, item)
+	}
+	respondJSON(w, http.StatusOK, report)
+}
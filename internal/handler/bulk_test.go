@@ -0,0 +1,107 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/handler"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func bulk(t *testing.T, r chi.Router, path, body string) handler.BulkReport {
+	t.Helper()
+	w := doRequest(r, http.MethodPost, path, body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var report handler.BulkReport
+	json.NewDecoder(w.Body).Decode(&report)
+	return report
+}
+
+func itemStatuses(report handler.BulkReport) string {
+	var out []string
+	for _, item := range report.Items {
+		out = append(out, item.Status)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestBulkApplications(t *testing.T) {
+	_, r := setupTest(t)
+	a := createApp(t, r, `{"company":"Acme","role":"Eng","status":"applied"}`)
+	b := createApp(t, r, `{"company":"Acme Labs","role":"Eng","status":"interview"}`)
+	c := createApp(t, r, `{"company":"Globex","role":"Eng","status":"rejected"}`)
+
+	// Preview first: the counts come back but nothing changes.
+	withdraw := `{"query":"company=acme","action":"set_status","status":"withdrawn","status_note":"hiring freeze"}`
+	report := bulk(t, r, "/applications/bulk?dry_run=true", withdraw)
+	if !report.DryRun || report.Matched != 2 || report.Changed != 2 || itemStatuses(report) != "would_update,would_update" {
+		t.Fatalf("unexpected preview %+v", report)
+	}
+	var got model.Application
+	json.NewDecoder(doRequest(r, http.MethodGet, "/applications/"+a.ID, "").Body).Decode(&got)
+	if got.Status != "applied" {
+		t.Fatalf("expected a preview to change nothing, got %s", got.Status)
+	}
+
+	report = bulk(t, r, "/applications/bulk", withdraw)
+	if report.DryRun || report.Changed != 2 || itemStatuses(report) != "updated,updated" || report.Items[0].ID != a.ID {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	json.NewDecoder(doRequest(r, http.MethodGet, "/applications/"+b.ID, "").Body).Decode(&got)
+	if got.Status != "withdrawn" {
+		t.Fatalf("expected b withdrawn, got %s", got.Status)
+	}
+
+	// Explicit IDs report each one, including those that cannot change.
+	report = bulk(t, r, "/applications/bulk", `{"ids":["`+a.ID+`","`+c.ID+`","deadbeef"],"action":"set_status","status":"offer"}`)
+	if itemStatuses(report) != "failed,failed,not_found" || report.Failed != 2 || report.Items[0].Error == "" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	report = bulk(t, r, "/applications/bulk", `{"ids":["`+a.ID+`","`+c.ID+`"],"action":"set_field","field":"location","value":"Remote"}`)
+	if itemStatuses(report) != "updated,updated" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	report = bulk(t, r, "/applications/bulk", `{"query":"location=remote","action":"add_tags","tags":["frozen"]}`)
+	if itemStatuses(report) != "updated,updated" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	report = bulk(t, r, "/applications/bulk", `{"query":"tag=frozen","action":"delete"}`)
+	if itemStatuses(report) != "deleted,deleted" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	var trash handler.PaginatedResponse
+	json.NewDecoder(doRequest(r, http.MethodGet, "/trash", "").Body).Decode(&trash)
+	if trash.Pagination.Total != 2 {
+		t.Fatalf("expected two applications in the trash, got %d", trash.Pagination.Total)
+	}
+}
+
+func TestBulkApplications_Invalid(t *testing.T) {
+	_, r := setupTest(t)
+	for _, tc := range []struct {
+		name string
+		path string
+		body string
+	}{
+		{"no target", "/applications/bulk", `{"action":"delete"}`},
+		{"bad id", "/applications/bulk", `{"ids":["nope"],"action":"delete"}`},
+		{"unknown action", "/applications/bulk", `{"ids":["deadbeef"],"action":"archive"}`},
+		{"unknown status", "/applications/bulk", `{"ids":["deadbeef"],"action":"set_status","status":"hired"}`},
+		{"wrong value type", "/applications/bulk", `{"ids":["deadbeef"],"action":"set_field","field":"salary_min","value":"lots"}`},
+		{"bad filter", "/applications/bulk", `{"query":"status=hired","action":"delete"}`},
+		{"bad dry_run", "/applications/bulk?dry_run=maybe", `{"ids":["deadbeef"],"action":"delete"}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if w := doRequest(r, http.MethodPost, tc.path, tc.body); w.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
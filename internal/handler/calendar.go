@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/ical"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+const (
+	calendarProdID = "-//job-hunt-platform//Calendar Feed//EN"
+	calendarDomain = "job-hunt-platform"
+
+	defaultFollowUpDays = 7
+	maxFollowUpDays     = 90
+
+	// calendarLookback keeps recent interviews in the feed; clients drop
+	// events that disappear from a subscription.
+	calendarLookback = 30 * 24 * time.Hour
+)
+
+var interviewFormatLabels = map[string]string{
+	"phone":     "Phone interview",
+	"video":     "Video interview",
+	"onsite":    "Onsite interview",
+	"take_home": "Take-home",
+}
+
+// GetCalendar serves an iCalendar feed of interviews from the last 30 days
+// onward plus one all-day follow-up event per active application. status
+// (comma-separated or repeated) limits both to applications in those stages.
+//
+// A follow-up is due follow_up_days (default 7) after the later of applied_at
+// and the last update, for applications past the pipeline's first stage and
+// not in a terminal one. Event UIDs depend only on record IDs, so clients
+// replace events when dates change.
+func (h *Handler) GetCalendar(w http.ResponseWriter, r *http.Request) {
+	pipeline, err := h.store.Pipeline(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to load stages")
+		return
+	}
+
+	var statuses []string
+	for _, v := range r.URL.Query()["status"] {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			}
+			if !pipeline.Has(s) {
+				respondError(w, http.StatusBadRequest, pipeline.ValidateStatus(s).Error())
+				return
+			}
+			statuses = append(statuses, s)
+		}
+	}
+	included := func(status string) bool {
+		return len(statuses) == 0 || slices.Contains(statuses, status)
+	}
+
+	followUpDays := defaultFollowUpDays
+	if v := r.URL.Query().Get("follow_up_days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxFollowUpDays {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("follow_up_days must be between 1 and %d", maxFollowUpDays))
+			return
+		}
+		followUpDays = n
+	}
+
+	cal := ical.Calendar{ProdID: calendarProdID, Name: "Job Hunt"}
+
+	from := time.Now().UTC().Add(-calendarLookback).Format(time.RFC3339)
+	agenda, err := h.store.Agenda(r.Context(), from, "")
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list interviews")
+		return
+	}
+	for _, si := range agenda {
+		if !included(si.Status) {
+			continue
+		}
+		if e, ok := interviewEvent(si); ok {
+			cal.Events = append(cal.Events, e)
+		}
+	}
+
+	err = h.store.Each(r.Context(), model.ListOptions{}, func(a model.Application) error {
+		stage, _ := pipeline.Stage(a.Status)
+		if stage.Terminal || a.Status == pipeline.Default() || !included(a.Status) {
+			return nil
+		}
+		if e, ok := followUpEvent(a, followUpDays); ok {
+			cal.Events = append(cal.Events, e)
+		}
+		return nil
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list applications")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="job-hunt.ics"`)
+	w.WriteHeader(http.StatusOK)
+	if err := cal.Encode(w); err != nil {
+		slog.Error("failed to write calendar", "error", err)
+	}
+}
+
+func interviewEvent(si model.ScheduledInterview) (ical.Event, bool) {
+	start, err := time.Parse(time.RFC3339, si.StartsAt)
+	if err != nil {
+		return ical.Event{}, false
+	}
+	e := ical.Event{
+		UID:        fmt.Sprintf("interview-%s@%s", si.ID, calendarDomain),
+		Stamp:      parseTimestamp(si.UpdatedAt),
+		Start:      start,
+		Summary:    fmt.Sprintf("%s: %s (%s)", interviewFormatLabels[si.Format], si.Company, si.Role),
+		Location:   si.Location,
+		Status:     ical.StatusConfirmed,
+		Categories: []string{"interview", si.Format},
+	}
+	if end, err := time.Parse(time.RFC3339, si.EndsAt); err == nil {
+		e.End = end
+	}
+	if si.Outcome == "cancelled" {
+		e.Status = ical.StatusCancelled
+	}
+	e.URL = httpURL(si.Location)
+
+	var desc []string
+	if len(si.Interviewers) > 0 {
+		desc = append(desc, "Interviewers: "+strings.Join(si.Interviewers, ", "))
+	}
+	desc = append(desc, "Application status: "+si.Status, "Timezone: "+si.Timezone)
+	if si.Outcome != "pending" {
+		desc = append(desc, "Outcome: "+si.Outcome)
+	}
+	e.Description = strings.Join(desc, "\n")
+	return e, true
+}
+
+func followUpEvent(a model.Application, days int) (ical.Event, bool) {
+	last := parseTimestamp(a.UpdatedAt)
+	if applied, err := time.Parse("2006-01-02", a.AppliedAt); err == nil && applied.After(last) {
+		last = applied
+	}
+	if last.IsZero() {
+		return ical.Event{}, false
+	}
+	due := last.UTC().Truncate(24*time.Hour).AddDate(0, 0, days)
+
+	return ical.Event{
+		UID:         fmt.Sprintf("follow-up-%s@%s", a.ID, calendarDomain),
+		Stamp:       parseTimestamp(a.UpdatedAt),
+		Start:       due,
+		AllDay:      true,
+		Summary:     fmt.Sprintf("Follow up: %s (%s)", a.Company, a.Role),
+		Description: fmt.Sprintf("Status: %s\nNo activity since %s", a.Status, last.Format("2006-01-02")),
+		URL:         httpURL(a.URL),
+		Categories:  []string{"follow-up"},
+	}, true
+}
+
+// httpURL returns s if it is an absolute http(s) URL, for the URL property
+// which must be a valid URI, and "" otherwise.
+func httpURL(s string) string {
+	if u, err := url.Parse(s); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		return s
+	}
+	return ""
+}
+
+// parseTimestamp parses a stored RFC3339 timestamp, returning the zero time if
+// it is malformed.
+func parseTimestamp(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
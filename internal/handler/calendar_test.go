@@ -0,0 +1,73 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestCalendarFeed(t *testing.T) {
+	_, r := setupTest(t)
+	applied := createApp(t, r, `{"company":"Acme","role":"Backend Engineer","status":"applied","url":"https://acme.example/jobs/1"}`)
+	createApp(t, r, `{"company":"Wishful","role":"Dream Job"}`)
+	nope := createApp(t, r, `{"company":"Nope","role":"SRE","status":"applied"}`)
+	doRequest(r, http.MethodPut, "/applications/"+nope.ID, `{"status":"rejected"}`)
+
+	start := time.Now().UTC().Add(72 * time.Hour).Truncate(time.Hour)
+	w := doRequest(r, http.MethodPost, "/applications/"+applied.ID+"/interviews",
+		`{"starts_at":"`+start.Format(time.RFC3339)+`","ends_at":"`+start.Add(time.Hour).Format(time.RFC3339)+`","format":"video","interviewers":["Ana"],"location":"https://meet.example.com/x"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create interview: %d %s", w.Code, w.Body.String())
+	}
+	var iv model.Interview
+	json.NewDecoder(w.Body).Decode(&iv)
+
+	w = doRequest(r, http.MethodGet, "/calendar.ics", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/calendar; charset=utf-8" {
+		t.Errorf("unexpected Content-Type %q", ct)
+	}
+	body := w.Body.String()
+	due := time.Now().UTC().AddDate(0, 0, 7).Format("20060102")
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:interview-" + iv.ID + "@job-hunt-platform\r\n",
+		"DTSTART:" + start.Format("20060102T150405Z") + "\r\n",
+		"SUMMARY:Video interview: Acme (Backend Engineer)\r\n",
+		"URL:https://meet.example.com/x\r\n",
+		"UID:follow-up-" + applied.ID + "@job-hunt-platform\r\n",
+		"DTSTART;VALUE=DATE:" + due + "\r\n",
+		"SUMMARY:Follow up: Acme (Backend Engineer)\r\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in feed:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "Wishful") || strings.Contains(body, "Nope") {
+		t.Errorf("expected no follow-ups for first-stage or terminal applications:\n%s", body)
+	}
+	if strings.Count(body, "BEGIN:VEVENT") != 2 {
+		t.Errorf("expected 2 events, got %d", strings.Count(body, "BEGIN:VEVENT"))
+	}
+
+	w = doRequest(r, http.MethodGet, "/calendar.ics?status=interview,offer", "")
+	if strings.Contains(w.Body.String(), "BEGIN:VEVENT") {
+		t.Errorf("expected status filter to exclude applied events:\n%s", w.Body.String())
+	}
+	w = doRequest(r, http.MethodGet, "/calendar.ics?status=applied&follow_up_days=14", "")
+	if !strings.Contains(w.Body.String(), "DTSTART;VALUE=DATE:"+time.Now().UTC().AddDate(0, 0, 14).Format("20060102")) {
+		t.Errorf("expected follow_up_days to move the follow-up:\n%s", w.Body.String())
+	}
+
+	for _, q := range []string{"?status=bogus", "?follow_up_days=0"} {
+		if w := doRequest(r, http.MethodGet, "/calendar.ics"+q, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", q, w.Code)
+		}
+	}
+}
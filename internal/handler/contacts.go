@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func (h *Handler) ListContacts(w http.ResponseWriter, r *http.Request) {
+	contacts, err := h.store.ListContacts(r.Context(), db.ContactFilter{
+		Name:    r.URL.Query().Get("name"),
+		Company: r.URL.Query().Get("company"),
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list contacts")
+		return
+	}
+	respondJSON(w, http.StatusOK, contacts)
+}
+
+func (h *Handler) GetContact(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid contact ID format")
+		return
+	}
+	contact, err := h.store.GetContact(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get contact")
+		return
+	}
+	if contact == nil {
+		respondError(w, http.StatusNotFound, "contact not found")
+		return
+	}
+	respondJSON(w, http.StatusOK, contact)
+}
+
+func (h *Handler) CreateContact(w http.ResponseWriter, r *http.Request) {
+	var req model.ContactRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := req.Validate(true); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	contact, err := h.store.CreateContact(r.Context(), req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to create contact")
+		return
+	}
+	respondJSON(w, http.StatusCreated, contact)
+}
+
+func (h *Handler) UpdateContact(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid contact ID format")
+		return
+	}
+
+	var req model.ContactRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := req.Validate(false); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	contact, err := h.store.UpdateContact(r.Context(), id, req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to update contact")
+		return
+	}
+	if contact == nil {
+		respondError(w, http.StatusNotFound, "contact not found")
+		return
+	}
+	respondJSON(w, http.StatusOK, contact)
+}
+
+func (h *Handler) DeleteContact(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid contact ID format")
+		return
+	}
+
+	deleted, err := h.store.DeleteContact(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to delete contact")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "contact not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetContactApplications lists the applications a contact is linked to, most
+// recently updated first.
+func (h *Handler) GetContactApplications(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid contact ID format")
+		return
+	}
+	contact, err := h.store.GetContact(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get contact")
+		return
+	}
+	if contact == nil {
+		respondError(w, http.StatusNotFound, "contact not found")
+		return
+	}
+
+	apps, err := h.store.ContactApplications(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get contact applications")
+		return
+	}
+	respondJSON(w, http.StatusOK, apps)
+}
+
+func (h *Handler) GetApplicationContacts(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid application ID format")
+		return
+	}
+	app, err := h.store.Get(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get application")
+		return
+	}
+	if app == nil {
+		respondError(w, http.StatusNotFound, "application not found")
+		return
+	}
+
+	contacts, err := h.store.ApplicationContacts(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get application contacts")
+		return
+	}
+	respondJSON(w, http.StatusOK, contacts)
+}
+
+// LinkApplicationContact links an existing contact to an application. It
+// returns 201 for a new link and 200 if the same link already existed.
+func (h *Handler) LinkApplicationContact(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid application ID format")
+		return
+	}
+
+	var req model.ContactLinkRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !isValidID(req.ContactID) {
+		respondError(w, http.StatusBadRequest, "invalid contact ID format")
+		return
+	}
+
+	app, err := h.store.Get(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get application")
+		return
+	}
+	if app == nil {
+		respondError(w, http.StatusNotFound, "application not found")
+		return
+	}
+	contact, err := h.store.GetContact(r.Context(), req.ContactID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get contact")
+		return
+	}
+	if contact == nil {
+		respondError(w, http.StatusNotFound, "contact not found")
+		return
+	}
+
+	created, err := h.store.LinkContact(r.Context(), id, req.ContactID, req.Relationship)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to link contact")
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+
+	contacts, err := h.store.ApplicationContacts(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get application contacts")
+		return
+	}
+	for _, c := range contacts {
+		if c.ID == req.ContactID && c.Relationship == req.Relationship {
+			respondJSON(w, status, c)
+			return
+		}
+	}
+	respondError(w, http.StatusInternalServerError, "failed to link contact")
+}
+
+// UnlinkApplicationContact removes a contact from an application. With
+// ?relationship= only that link is removed; otherwise all of them are.
+func (h *Handler) UnlinkApplicationContact(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid application ID format")
+		return
+	}
+	contactID := chi.URLParam(r, "contactID")
+	if !isValidID(contactID) {
+		respondError(w, http.StatusBadRequest, "invalid contact ID format")
+		return
+	}
+	relationship := r.URL.Query().Get("relationship")
+	if relationship != "" {
+		if err := model.ValidateRelationship(relationship); err != nil {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	removed, err := h.store.UnlinkContact(r.Context(), id, contactID, relationship)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to unlink contact")
+		return
+	}
+	if !removed {
+		respondError(w, http.StatusNotFound, "contact is not linked to this application")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
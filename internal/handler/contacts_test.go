@@ -0,0 +1,150 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestContacts_CRUD(t *testing.T) {
+	_, r := setupTest(t)
+
+	w := doRequest(r, http.MethodPost, "/contacts", `{"name":"Jane Doe","email":"jane@acme.com","company":"Acme","role":"Recruiter","linkedin_url":"https://www.linkedin.com/in/janedoe"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var jane model.Contact
+	json.NewDecoder(w.Body).Decode(&jane)
+	if len(jane.ID) != 8 || jane.Name != "Jane Doe" || jane.LinkedInURL != "https://www.linkedin.com/in/janedoe" {
+		t.Fatalf("unexpected contact: %+v", jane)
+	}
+
+	for _, body := range []string{
+		`{"email":"x@example.com"}`,
+		`{"name":"Bob","email":"not-an-email"}`,
+		`{"name":"Bob","linkedin_url":"linkedin.com/in/bob"}`,
+	} {
+		if w := doRequest(r, http.MethodPost, "/contacts", body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, w.Code)
+		}
+	}
+
+	w = doRequest(r, http.MethodPut, "/contacts/"+jane.ID, `{"phone":"+1 555 0100","role":"Senior Recruiter"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var updated model.Contact
+	json.NewDecoder(w.Body).Decode(&updated)
+	if updated.Phone != "+1 555 0100" || updated.Role != "Senior Recruiter" || updated.Email != "jane@acme.com" {
+		t.Fatalf("expected partial update, got %+v", updated)
+	}
+
+	if w := doRequest(r, http.MethodPut, "/contacts/"+jane.ID, `{"name":" "}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for empty name, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodGet, "/contacts/deadbeef", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodGet, "/contacts/nope", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for malformed ID, got %d", w.Code)
+	}
+
+	if w := doRequest(r, http.MethodDelete, "/contacts/"+jane.ID, ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodGet, "/contacts/"+jane.ID, ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestContacts_LinkToApplications(t *testing.T) {
+	_, r := setupTest(t)
+	older := createApp(t, r, `{"company":"Acme","role":"Backend Engineer"}`)
+	newer := createApp(t, r, `{"company":"Acme","role":"Platform Engineer"}`)
+	other := createApp(t, r, `{"company":"Globex","role":"SRE"}`)
+
+	w := doRequest(r, http.MethodPost, "/contacts", `{"name":"Sam Lee"}`)
+	var sam model.Contact
+	json.NewDecoder(w.Body).Decode(&sam)
+
+	link := func(appID, rel string) int {
+		return doRequest(r, http.MethodPost, "/applications/"+appID+"/contacts", `{"contact_id":"`+sam.ID+`","relationship":"`+rel+`"}`).Code
+	}
+	if code := link(older.ID, "recruiter"); code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", code)
+	}
+	if code := link(older.ID, "recruiter"); code != http.StatusOK {
+		t.Fatalf("expected 200 for repeated link, got %d", code)
+	}
+	if code := link(older.ID, "interviewer"); code != http.StatusCreated {
+		t.Fatalf("expected 201 for second relationship, got %d", code)
+	}
+	if code := link(newer.ID, "hiring_manager"); code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", code)
+	}
+	if code := link(older.ID, "friend"); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid relationship, got %d", code)
+	}
+	if code := link("deadbeef", "recruiter"); code != http.StatusNotFound {
+		t.Fatalf("expected 404 for missing application, got %d", code)
+	}
+	w = doRequest(r, http.MethodPost, "/applications/"+older.ID+"/contacts", `{"contact_id":"deadbeef","relationship":"recruiter"}`)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for missing contact, got %d", w.Code)
+	}
+
+	w = doRequest(r, http.MethodGet, "/applications/"+older.ID+"/contacts", "")
+	var appContacts []model.ApplicationContact
+	json.NewDecoder(w.Body).Decode(&appContacts)
+	if len(appContacts) != 2 || appContacts[0].Name != "Sam Lee" || appContacts[0].Relationship != "interviewer" || appContacts[1].Relationship != "recruiter" {
+		t.Fatalf("unexpected application contacts: %+v", appContacts)
+	}
+
+	// Company filter also matches contacts via their linked applications.
+	w = doRequest(r, http.MethodGet, "/contacts?company=acme", "")
+	var contacts []model.Contact
+	json.NewDecoder(w.Body).Decode(&contacts)
+	if len(contacts) != 1 || contacts[0].ID != sam.ID {
+		t.Fatalf("expected Sam via linked Acme applications, got %+v", contacts)
+	}
+	w = doRequest(r, http.MethodGet, "/contacts?company=globex", "")
+	json.NewDecoder(w.Body).Decode(&contacts)
+	if len(contacts) != 0 {
+		t.Fatalf("expected no Globex contacts, got %+v", contacts)
+	}
+
+	w = doRequest(r, http.MethodGet, "/contacts/"+sam.ID+"/applications", "")
+	var apps []model.ContactApplication
+	json.NewDecoder(w.Body).Decode(&apps)
+	if len(apps) != 3 {
+		t.Fatalf("expected 3 links, got %+v", apps)
+	}
+	for i, a := range apps {
+		if a.ID == other.ID {
+			t.Fatalf("unlinked application returned: %+v", a)
+		}
+		if i > 0 && a.UpdatedAt > apps[i-1].UpdatedAt {
+			t.Fatalf("expected most recently updated first, got %+v", apps)
+		}
+	}
+
+	w = doRequest(r, http.MethodDelete, "/applications/"+older.ID+"/contacts/"+sam.ID+"?relationship=interviewer", "")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	w = doRequest(r, http.MethodDelete, "/applications/"+older.ID+"/contacts/"+sam.ID+"?relationship=interviewer", "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for removed link, got %d", w.Code)
+	}
+	w = doRequest(r, http.MethodDelete, "/applications/"+older.ID, "")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	w = doRequest(r, http.MethodGet, "/contacts/"+sam.ID+"/applications", "")
+	json.NewDecoder(w.Body).Decode(&apps)
+	if len(apps) != 1 || apps[0].ID != newer.ID {
+		t.Fatalf("expected links to deleted application to cascade, got %+v", apps)
+	}
+}
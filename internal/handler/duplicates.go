@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// CreatedApplication is the response to creating an application: the
+// application plus, when force=true overrode them, any existing ones that
+// look like the same posting.
+type CreatedApplication struct {
+	*model.Application
+	Duplicates []model.Duplicate `json:"duplicates,omitempty"`
+}
+
+// DuplicateConflict is the 409 body when existing applications look like the
+// same posting and force=true was not given.
+type DuplicateConflict struct {
+	Error      string            `json:"error"`
+	Duplicates []model.Duplicate `json:"duplicates"`
+}
+
+// MergeApplication folds the application named by source_id into the one in
+// the path and trashes the source. If-Match applies to the target.
+func (h *Handler) MergeApplication(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid application ID format")
+		return
+	}
+	var req model.MergeRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if !isValidID(req.SourceID) {
+		respondError(w, http.StatusBadRequest, "source_id must be a valid application ID")
+		return
+	}
+	if req.SourceID == id {
+		respondError(w, http.StatusBadRequest, "cannot merge an application into itself")
+		return
+	}
+
+	opts := db.UpdateOptions{IfVersions: ifMatchVersions(r.Header.Get("If-Match"))}
+	app, err := h.store.Merge(r.Context(), id, req.SourceID, opts)
+	if h.respondUpdateError(w, r, id, err) {
+		return
+	}
+	if app == nil {
+		respondError(w, http.StatusNotFound, "application not found")
+		return
+	}
+	respondApplication(w, http.StatusOK, app)
+}
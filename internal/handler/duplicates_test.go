@@ -0,0 +1,86 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/handler"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestCreateApplication_Duplicates(t *testing.T) {
+	_, r := setupTest(t)
+	first := createApp(t, r, `{"company":"Acme Inc.","role":"Sr. Backend Eng","url":"https://acme.example/jobs/1"}`)
+	if w := doRequest(r, http.MethodPost, "/applications", `{"company":"Globex","role":"Eng"}`); w.Code != http.StatusCreated || strings.Contains(w.Body.String(), `"duplicates"`) {
+		t.Fatalf("expected 201 with no duplicates field, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for _, tc := range []struct {
+		path, body, reason string
+	}{
+		{"/applications", `{"company":"acme","role":"Senior Backend Engineer"}`, model.DuplicateSimilar},
+		{"/applications?force=false", `{"company":"Initech","role":"Eng","url":"https://ACME.example/jobs/1/"}`, model.DuplicateSameURL},
+	} {
+		w := doRequest(r, http.MethodPost, tc.path, tc.body)
+		var conflict handler.DuplicateConflict
+		json.NewDecoder(w.Body).Decode(&conflict)
+		if w.Code != http.StatusConflict || len(conflict.Duplicates) != 1 || conflict.Duplicates[0].ID != first.ID || conflict.Duplicates[0].Reason != tc.reason {
+			t.Fatalf("%s: expected 409 listing %s as %s, got %d: %+v", tc.body, first.ID, tc.reason, w.Code, conflict)
+		}
+	}
+	var list handler.PaginatedResponse
+	json.NewDecoder(doRequest(r, http.MethodGet, "/applications", "").Body).Decode(&list)
+	if list.Pagination.Total != 2 {
+		t.Fatalf("expected the duplicates not created, got %d", list.Pagination.Total)
+	}
+
+	w := doRequest(r, http.MethodPost, "/applications?force=true", `{"company":"acme","role":"Senior Backend Engineer"}`)
+	var created handler.CreatedApplication
+	json.NewDecoder(w.Body).Decode(&created)
+	if w.Code != http.StatusCreated || w.Header().Get("ETag") != `"1"` || created.Application == nil || created.ID == "" ||
+		len(created.Duplicates) != 1 || created.Duplicates[0].ID != first.ID || created.Duplicates[0].Reason != model.DuplicateSimilar {
+		t.Fatalf("expected force to create with one duplicate warning, got %d: %+v", w.Code, created)
+	}
+	if w := doRequest(r, http.MethodPost, "/applications?force=maybe", `{"company":"Initech","role":"Eng"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestMergeApplication(t *testing.T) {
+	_, r := setupTest(t)
+	target := createApp(t, r, `{"company":"Acme","role":"Eng","notes":"first","tags":["remote"]}`)
+	var source model.Application
+	json.NewDecoder(doRequest(r, http.MethodPost, "/applications?force=true", `{"company":"Acme","role":"Eng","notes":"second","tags":["backend"],"location":"Berlin"}`).Body).Decode(&source)
+
+	w := doRequest(r, http.MethodPost, "/applications/"+target.ID+"/merge", `{"source_id":"`+source.ID+`"}`)
+	var merged model.Application
+	json.NewDecoder(w.Body).Decode(&merged)
+	if w.Code != http.StatusOK || merged.Notes != "first\n\nsecond" || merged.Location != "Berlin" ||
+		len(merged.Tags) != 2 || w.Header().Get("ETag") != `"2"` {
+		t.Fatalf("unexpected merge result %d: %+v", w.Code, merged)
+	}
+	if w := doRequest(r, http.MethodGet, "/applications/"+source.ID, ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected the source gone, got %d", w.Code)
+	}
+
+	for _, tc := range []struct {
+		name string
+		id   string
+		body string
+		want int
+	}{
+		{"source already merged", target.ID, `{"source_id":"` + source.ID + `"}`, http.StatusNotFound},
+		{"missing target", "deadbeef", `{"source_id":"` + target.ID + `"}`, http.StatusNotFound},
+		{"itself", target.ID, `{"source_id":"` + target.ID + `"}`, http.StatusBadRequest},
+		{"bad source id", target.ID, `{"source_id":"nope"}`, http.StatusBadRequest},
+		{"bad body", target.ID, `not json`, http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if w := doRequest(r, http.MethodPost, "/applications/"+tc.id+"/merge", tc.body); w.Code != tc.want {
+				t.Fatalf("expected %d, got %d: %s", tc.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// applicationETag is a strong ETag for app's current version.
+func applicationETag(app *model.Application) string {
+	return `"` + strconv.Itoa(app.Version) + `"`
+}
+
+// respondApplication writes app with its ETag.
+func respondApplication(w http.ResponseWriter, status int, app *model.Application) {
+	w.Header().Set("ETag", applicationETag(app))
+	respondJSON(w, status, app)
+}
+
+// parseETags splits an If-Match or If-None-Match header into its entity
+// tags. It returns nil for an empty header or "*".
+func parseETags(header string) []string {
+	if strings.TrimSpace(header) == "" || strings.TrimSpace(header) == "*" {
+		return nil
+	}
+	var tags []string
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// ifMatchVersions turns an If-Match header into db.UpdateOptions.IfVersions:
+// nil when the header is absent or "*", otherwise the versions it names.
+// Weak and foreign tags never match, per the strong comparison If-Match
+// requires, so the result is non-nil even if none parse.
+func ifMatchVersions(header string) []int {
+	tags := parseETags(header)
+	if tags == nil {
+		return nil
+	}
+	versions := []int{}
+	for _, tag := range tags {
+		n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(tag, `"`), `"`))
+		if err == nil && strings.HasPrefix(tag, `"`) {
+			versions = append(versions, n)
+		}
+	}
+	return versions
+}
+
+// noneMatch reports whether an If-None-Match header lets a GET through, that
+// is, whether it names neither etag nor "*". It uses weak comparison.
+func noneMatch(header, etag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return false
+	}
+	for _, tag := range parseETags(header) {
+		if strings.TrimPrefix(tag, "W/") == etag {
+			return false
+		}
+	}
+	return true
+}
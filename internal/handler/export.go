@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// exportColumns is the CSV header row. The names match importFields so an
+// export can be fed back into POST /applications/import.
+var exportColumns = []string{
+	"id", "company", "role", "url", "salary_min", "salary_max", "location",
+	"status", "notes", "applied_at", "created_at", "updated_at", "tags",
+}
+
+// exportFlushEvery is how many rows are written between flushes to the client.
+const exportFlushEvery = 100
+
+// ExportApplications streams every application matching the same filters and
+// sort as GET /applications, as CSV (default) or NDJSON. limit and offset are
+// ignored. Rows are written as they are read from the database, so a failure
+// after the first row can only be logged and the response is truncated.
+func (h *Handler) ExportApplications(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "ndjson" {
+		respondError(w, http.StatusBadRequest, "invalid format, valid values: csv, ndjson")
+		return
+	}
+
+	opts, ok := h.listOptions(w, r)
+	if !ok {
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	csvWriter := csv.NewWriter(w)
+	encoder := json.NewEncoder(w)
+	rows := 0
+
+	// Headers are sent with the first row so that an error from the query
+	// itself, such as an invalid q, can still get a proper status code.
+	start := func() error {
+		filename := fmt.Sprintf("applications-%s.%s", time.Now().UTC().Format("20060102"), format)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		if format == "csv" {
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			return csvWriter.Write(exportColumns)
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+
+	err := h.store.Each(r.Context(), opts, func(a model.Application) error {
+		if rows == 0 {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		rows++
+
+		var err error
+		if format == "csv" {
+			err = csvWriter.Write(exportRecord(a))
+		} else {
+			a.Snippet = ""
+			err = encoder.Encode(a)
+		}
+		if err != nil {
+			return err
+		}
+
+		if rows%exportFlushEvery == 0 {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return err
+			}
+			if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil && rows == 0 {
+		if errors.Is(err, db.ErrInvalidSearch) {
+			respondError(w, http.StatusBadRequest, invalidSearchMessage)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "failed to export applications")
+		return
+	}
+	if err != nil {
+		slog.Error("export interrupted", "error", err, "rows_written", rows)
+		return
+	}
+
+	if rows == 0 {
+		if err := start(); err != nil {
+			slog.Error("export interrupted", "error", err, "rows_written", rows)
+			return
+		}
+	}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		slog.Error("export interrupted", "error", err, "rows_written", rows)
+	}
+}
+
+func exportRecord(a model.Application) []string {
+	return []string{
+		a.ID, escapeCell(a.Company), escapeCell(a.Role), escapeCell(a.URL),
+		strconv.Itoa(a.SalaryMin), strconv.Itoa(a.SalaryMax),
+		escapeCell(a.Location), a.Status, escapeCell(a.Notes), escapeCell(a.AppliedAt), a.CreatedAt, a.UpdatedAt,
+		escapeCell(strings.Join(a.Tags, ",")),
+	}
+}
+
+// formulaPrefixes are the leading characters that make a spreadsheet treat a
+// cell as a formula, including the tab and carriage return OWASP lists.
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeCell prefixes user text that a spreadsheet would run as a formula with
+// a single quote, so it opens as text. Import strips the quote again.
+func escapeCell(s string) string {
+	if s != "" && strings.ContainsRune(formulaPrefixes, rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// unescapeCell reverses escapeCell.
+func unescapeCell(s string) string {
+	if len(s) > 1 && s[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(s[1])) {
+		return s[1:]
+	}
+	return s
+}
@@ -0,0 +1,174 @@
+package handler_test
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func seedExportApps(t *testing.T, r http.Handler) {
+	t.Helper()
+	for _, body := range []string{
+		`{"company":"Acme","role":"Backend Engineer","salary_min":150000,"status":"applied","notes":"Referred, \"strong\" fit\nfollow up"}`,
+		`{"company":"Globex","role":"SRE","status":"applied"}`,
+		`{"company":"Initech","role":"Frontend Developer"}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/applications", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("create failed: %d %s", w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestExportApplications_CSV(t *testing.T) {
+	_, r := setupTest(t)
+	seedExportApps(t, r)
+
+	req := httptest.NewRequest(http.MethodGet, "/applications/export?status=applied&sort_by=company&sort_order=desc&limit=1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+		t.Errorf("unexpected Content-Type %q", ct)
+	}
+	if cd := w.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, `attachment; filename="applications-`) || !strings.HasSuffix(cd, `.csv"`) {
+		t.Errorf("unexpected Content-Disposition %q", cd)
+	}
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header + 2 rows (limit ignored), got %d: %v", len(records), records)
+	}
+	if strings.Join(records[0], ",") != "id,company,role,url,salary_min,salary_max,location,status,notes,applied_at,created_at,updated_at,tags" {
+		t.Errorf("unexpected header %v", records[0])
+	}
+	if records[1][1] != "Globex" || records[2][1] != "Acme" {
+		t.Errorf("expected Globex then Acme, got %s then %s", records[1][1], records[2][1])
+	}
+	if records[2][4] != "150000" || records[2][8] != "Referred, \"strong\" fit\nfollow up" {
+		t.Errorf("unexpected Acme row %v", records[2])
+	}
+}
+
+func TestExportApplications_CSVEscapesFormulas(t *testing.T) {
+	_, r := setupTest(t)
+	body := `{"company":"=HYPERLINK(\"http://evil.example\",\"Acme\")","role":"\tSRE","location":"@Remote","notes":"-5% equity","applied_at":"=1+1"}`
+	req := httptest.NewRequest(http.MethodPost, "/applications", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create failed: %d %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/applications/export", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	exported := w.Body.String()
+	records, err := csv.NewReader(strings.NewReader(exported)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	row := records[1]
+	if row[1] != `'=HYPERLINK("http://evil.example","Acme")` || row[2] != "'\tSRE" || row[6] != "'@Remote" || row[8] != "'-5% equity" || row[9] != "'=1+1" {
+		t.Fatalf("expected formula cells to be quoted, got %v", row)
+	}
+
+	// Importing the export restores the original text.
+	_, r2 := setupTest(t)
+	if w := postCSV(t, r2, "", exported); w.Code != http.StatusOK {
+		t.Fatalf("import failed: %d %s", w.Code, w.Body.String())
+	}
+	req = httptest.NewRequest(http.MethodGet, "/applications", nil)
+	w = httptest.NewRecorder()
+	r2.ServeHTTP(w, req)
+	if !strings.Contains(w.Body.String(), `"company":"=HYPERLINK(\"http://evil.example\",\"Acme\")"`) ||
+		!strings.Contains(w.Body.String(), `"notes":"-5% equity"`) ||
+		!strings.Contains(w.Body.String(), `"applied_at":"=1+1"`) {
+		t.Errorf("expected original text after import, got %s", w.Body.String())
+	}
+}
+
+func TestExportApplications_NDJSON(t *testing.T) {
+	_, r := setupTest(t)
+	seedExportApps(t, r)
+
+	req := httptest.NewRequest(http.MethodGet, "/applications/export?format=ndjson&q=follow", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("unexpected Content-Type %q", ct)
+	}
+
+	var apps []model.Application
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		var a model.Application
+		if err := json.Unmarshal(scanner.Bytes(), &a); err != nil {
+			t.Fatalf("invalid NDJSON line %q: %v", scanner.Text(), err)
+		}
+		apps = append(apps, a)
+	}
+	if len(apps) != 1 || apps[0].Company != "Acme" {
+		t.Fatalf("expected only Acme to match q, got %+v", apps)
+	}
+	if apps[0].Snippet != "" {
+		t.Errorf("expected no snippet in export, got %q", apps[0].Snippet)
+	}
+}
+
+func TestExportApplications_Empty(t *testing.T) {
+	_, r := setupTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/applications/export", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if !strings.HasPrefix(w.Body.String(), "id,company,role,") || strings.Count(w.Body.String(), "\n") != 1 {
+		t.Errorf("expected only a header row, got %q", w.Body.String())
+	}
+}
+
+func TestExportApplications_BadRequests(t *testing.T) {
+	_, r := setupTest(t)
+	seedExportApps(t, r)
+
+	for _, query := range []string{
+		"?format=xlsx",
+		"?status=bogus",
+		"?sort_by=password",
+		`?q="unterminated`,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/applications/export"+query, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", query, w.Code, w.Body.String())
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s: expected JSON error, got Content-Type %q", query, ct)
+		}
+	}
+}
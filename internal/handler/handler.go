@@ -3,14 +3,17 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 
 	"github.com/shakilbd009/job-hunt-platform/internal/db"
 	"github.com/shakilbd009/job-hunt-platform/internal/model"
@@ -24,6 +27,8 @@ func isValidID(id string) bool {
 
 const maxBodyBytes = 1 << 20 // 1 MB
 
+const invalidSearchMessage = "invalid q: use double quotes for phrases, * for prefixes, and AND/OR/NOT between terms"
+
 type PaginatedResponse struct {
 	Data       []model.Application `json:"data"`
 	Pagination PaginationMeta      `json:"pagination"`
@@ -34,14 +39,37 @@ type PaginationMeta struct {
 	Limit   int  `json:"limit"`
 	Offset  int  `json:"offset"`
 	HasMore bool `json:"has_more"`
+	// NextCursor continues the list after this page when passed back as
+	// cursor; it is empty on the last page and when sorting by relevance.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
+// DefaultIdempotencyTTL is how long a response to a request with an
+// Idempotency-Key is kept for replay.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
 type Handler struct {
-	store *db.Store
+	store          *db.Store
+	requireAuth    bool
+	idempotencyTTL time.Duration
 }
 
+// New returns a Handler that requires a session token or API key on every
+// route but /health and /auth/login.
 func New(store *db.Store) *Handler {
-	return &Handler{store: store}
+	return &Handler{store: store, requireAuth: true, idempotencyTTL: DefaultIdempotencyTTL}
+}
+
+// SetAuthRequired turns credential checks on or off. With them off every
+// request has full access to every user's data.
+func (h *Handler) SetAuthRequired(required bool) {
+	h.requireAuth = required
+}
+
+// SetIdempotencyTTL changes how long responses to requests with an
+// Idempotency-Key are kept. Zero ignores the header.
+func (h *Handler) SetIdempotencyTTL(ttl time.Duration) {
+	h.idempotencyTTL = ttl
 }
 
 func requireJSON(next http.Handler) http.Handler {
@@ -86,16 +114,119 @@ func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) Routes(r chi.Router) {
+	// Logging in and opening a share link are the API routes that need no
+	// credentials; share tokens grant their own, read-only access.
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.RequestID)
+		r.Use(maxBodyMiddleware(maxBodyBytes))
+		r.Use(requireJSON)
+		r.Post("/auth/login", h.Login)
+		r.Get("/shared/{token}", h.GetShared)
+	})
+	// Every other API route runs with a request ID, a session or API key
+	// (unless auth is off) and audit attribution so store mutations land in
+	// the audit log with all three.
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.RequestID)
+		r.Use(h.authenticate)
+		r.Use(auditContext)
+		h.apiRoutes(r)
+	})
+}
+
+func (h *Handler) apiRoutes(r chi.Router) {
+	// Reads need a read key; anything that changes data needs a read-write
+	// key.
+	write := requireScope(model.ScopeReadWrite)
+
 	// Stats endpoint must be before {id} to avoid chi matching "stats" as an ID
 	r.Get("/applications/stats", h.GetStats)
+	// CSV import takes a larger, non-JSON body and checks its own Content-Type.
+	r.Group(func(r chi.Router) {
+		r.Use(write)
+		r.Use(maxBodyMiddleware(maxImportBytes))
+		r.Use(h.idempotent)
+		r.Post("/applications/import", h.ImportApplications)
+	})
+	// Attachment uploads are multipart with their own size limit.
+	r.Group(func(r chi.Router) {
+		r.Use(write)
+		r.Use(maxBodyMiddleware(maxAttachmentBytes))
+		r.Use(h.idempotent)
+		r.Post("/applications/{id}/attachments", h.UploadAttachment)
+	})
+	// Completing a reminder, restoring from the trash and logging out are
+	// bodiless POSTs, so they skip requireJSON.
+	r.With(write, h.idempotent).Post("/reminders/{id}/complete", h.CompleteReminder)
+	r.With(write, h.idempotent).Post("/applications/{id}/restore", h.RestoreApplication)
+	r.Post("/auth/logout", h.Logout)
+	// PATCH takes the patch media types, which PatchApplication checks.
+	r.With(write, maxBodyMiddleware(maxBodyBytes), h.idempotent).Patch("/applications/{id}", h.PatchApplication)
+	r.Get("/auth/me", h.Me)
 	r.Group(func(r chi.Router) {
 		r.Use(maxBodyMiddleware(maxBodyBytes))
 		r.Use(requireJSON)
 		r.Get("/applications", h.ListApplications)
+		r.Get("/applications/export", h.ExportApplications)
 		r.Get("/applications/{id}", h.GetApplication)
-		r.Post("/applications", h.CreateApplication)
-		r.Put("/applications/{id}", h.UpdateApplication)
-		r.Delete("/applications/{id}", h.DeleteApplication)
+		r.Get("/applications/{id}/history", h.GetApplicationHistory)
+		r.Get("/applications/{id}/contacts", h.GetApplicationContacts)
+		r.Get("/applications/{id}/interviews", h.ListInterviews)
+		r.Get("/applications/{id}/interviews/{interviewID}", h.GetInterview)
+		r.Get("/applications/{id}/reminders", h.ListReminders)
+		r.Get("/applications/{id}/attachments", h.ListAttachments)
+		r.Get("/applications/{id}/attachments/{attachmentID}", h.DownloadAttachment)
+		r.Get("/stages", h.ListStages)
+		r.Get("/stages/{name}", h.GetStage)
+		r.Get("/attachments", h.ListAttachmentUses)
+		r.Get("/trash", h.ListTrash)
+		r.Get("/tags", h.ListTags)
+		r.Get("/contacts", h.ListContacts)
+		r.Get("/contacts/{id}", h.GetContact)
+		r.Get("/contacts/{id}/applications", h.GetContactApplications)
+		r.Get("/interviews", h.ListAgenda)
+		r.Get("/calendar.ics", h.GetCalendar)
+		r.Get("/reminders/due", h.ListDueReminders)
+		r.Get("/audit", h.ListAudit)
+		r.Get("/shares", h.ListShares)
+
+		r.Group(func(r chi.Router) {
+			r.Use(write)
+			// Only POST and PATCH consult Idempotency-Key.
+			r.Use(h.idempotent)
+			r.Post("/applications", h.CreateApplication)
+			r.Post("/applications/bulk", h.BulkApplications)
+			r.Post("/applications/{id}/merge", h.MergeApplication)
+			r.Put("/applications/{id}", h.UpdateApplication)
+			r.Delete("/applications/{id}", h.DeleteApplication)
+			r.Post("/applications/{id}/contacts", h.LinkApplicationContact)
+			r.Delete("/applications/{id}/contacts/{contactID}", h.UnlinkApplicationContact)
+			r.Post("/applications/{id}/interviews", h.CreateInterview)
+			r.Put("/applications/{id}/interviews/{interviewID}", h.UpdateInterview)
+			r.Delete("/applications/{id}/interviews/{interviewID}", h.DeleteInterview)
+			r.Post("/applications/{id}/reminders", h.CreateReminder)
+			r.Delete("/applications/{id}/attachments/{attachmentID}", h.DeleteAttachment)
+			r.Post("/applications/{id}/tags", h.AddApplicationTags)
+			r.Delete("/applications/{id}/tags", h.RemoveApplicationTags)
+
+			r.Post("/stages", h.CreateStage)
+			r.Put("/stages/{name}", h.UpdateStage)
+			r.Delete("/stages/{name}", h.DeleteStage)
+
+			r.Delete("/trash", h.EmptyTrash)
+			r.Delete("/trash/{id}", h.PurgeApplication)
+
+			r.Delete("/tags/{name}", h.DeleteTag)
+
+			r.Post("/contacts", h.CreateContact)
+			r.Put("/contacts/{id}", h.UpdateContact)
+			r.Delete("/contacts/{id}", h.DeleteContact)
+
+			r.Delete("/reminders/{id}", h.DeleteReminder)
+
+			r.Post("/shares", h.CreateShare)
+			r.Delete("/shares/{id}", h.DeleteShare)
+		})
 	})
 }
 
@@ -108,87 +239,104 @@ func (h *Handler) GetStats(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, stats)
 }
 
-func (h *Handler) ListApplications(w http.ResponseWriter, r *http.Request) {
-	status := r.URL.Query().Get("status")
-	if status != "" {
-		if err := model.ValidateStatus(status); err != nil {
-			respondError(w, http.StatusBadRequest, err.Error())
-			return
-		}
+// parseListOptions validates the filter, sort and pagination query params
+// shared by every endpoint that lists applications. Any error is a 400.
+// pipeline is only consulted when a status filter is present.
+func parseListOptions(query url.Values, pipeline model.Pipeline) (model.ListOptions, error) {
+	status := query.Get("status")
+	if err := pipeline.ValidateStatus(status); err != nil {
+		return model.ListOptions{}, err
 	}
 
 	limit := 50
-	if v := r.URL.Query().Get("limit"); v != "" {
+	if v := query.Get("limit"); v != "" {
 		n, err := strconv.Atoi(v)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "invalid limit parameter")
-			return
+			return model.ListOptions{}, errors.New("invalid limit parameter")
 		}
 		if n < 1 || n > 500 {
-			respondError(w, http.StatusBadRequest, "limit must be between 1 and 500")
-			return
+			return model.ListOptions{}, errors.New("limit must be between 1 and 500")
 		}
 		limit = n
 	}
 
 	offset := 0
-	if v := r.URL.Query().Get("offset"); v != "" {
+	if v := query.Get("offset"); v != "" {
 		n, err := strconv.Atoi(v)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "invalid offset parameter")
-			return
+			return model.ListOptions{}, errors.New("invalid offset parameter")
 		}
 		if n < 0 {
-			respondError(w, http.StatusBadRequest, "offset must be non-negative")
-			return
+			return model.ListOptions{}, errors.New("offset must be non-negative")
 		}
 		offset = n
 	}
 
-	// sort_by - default "updated_at", validate against ValidSortColumns
-	sortBy := r.URL.Query().Get("sort_by")
+	// q - full-text search (FTS5 syntax: phrases in quotes, prefix*, AND/OR/NOT)
+	q := strings.TrimSpace(query.Get("q"))
+
+	// sort_by - default "updated_at", validate against ValidSortColumns;
+	// "relevance" is only meaningful with q
+	sortBy := query.Get("sort_by")
 	if sortBy == "" {
 		sortBy = "updated_at"
 	}
-	if !model.ValidSortColumns[sortBy] {
-		respondError(w, http.StatusBadRequest, "invalid sort_by: must be one of company, role, status, salary_min, salary_max, location, created_at, updated_at")
-		return
+	if sortBy == model.SortByRelevance {
+		if q == "" {
+			return model.ListOptions{}, errors.New("sort_by=relevance requires a q search parameter")
+		}
+	} else if !model.ValidSortColumns[sortBy] {
+		return model.ListOptions{}, errors.New("invalid sort_by: must be one of company, role, status, salary_min, salary_max, location, created_at, updated_at, relevance")
 	}
 
 	// sort_order - default "desc", must be "asc" or "desc"
-	sortOrder := r.URL.Query().Get("sort_order")
+	sortOrder := query.Get("sort_order")
 	if sortOrder == "" {
 		sortOrder = "desc"
 	}
 	if sortOrder != "asc" && sortOrder != "desc" {
-		respondError(w, http.StatusBadRequest, "sort_order must be asc or desc")
-		return
+		return model.ListOptions{}, errors.New("sort_order must be asc or desc")
+	}
+
+	// cursor - a next_cursor from an earlier page with the same sort; it
+	// replaces offset
+	var after *model.Cursor
+	if v := query.Get("cursor"); v != "" {
+		if query.Get("offset") != "" {
+			return model.ListOptions{}, errors.New("cursor and offset cannot be combined")
+		}
+		c, err := model.DecodeCursor(v)
+		if err != nil {
+			return model.ListOptions{}, errors.New("invalid cursor")
+		}
+		if c.SortBy != sortBy || c.SortOrder != sortOrder {
+			return model.ListOptions{}, errors.New("cursor does not match sort_by and sort_order")
+		}
+		after = &c
 	}
 
 	// String filters (no validation needed, empty = no filter)
-	company := r.URL.Query().Get("company")
-	role := r.URL.Query().Get("role")
-	location := r.URL.Query().Get("location")
+	company := query.Get("company")
+	role := query.Get("role")
+	location := query.Get("location")
 
 	// Date filters - RFC3339 format
-	appliedAfter := r.URL.Query().Get("applied_after")
+	appliedAfter := query.Get("applied_after")
 	if appliedAfter != "" {
 		if _, err := strconv.ParseInt(appliedAfter, 10, 64); err != nil {
 			// Not a Unix timestamp, try RFC3339
 			if _, err := time.Parse(time.RFC3339, appliedAfter); err != nil {
-				respondError(w, http.StatusBadRequest, "invalid applied_after: must be RFC3339 format (e.g. 2026-01-01T00:00:00Z)")
-				return
+				return model.ListOptions{}, errors.New("invalid applied_after: must be RFC3339 format (e.g. 2026-01-01T00:00:00Z)")
 			}
 		}
 	}
 
-	appliedBefore := r.URL.Query().Get("applied_before")
+	appliedBefore := query.Get("applied_before")
 	if appliedBefore != "" {
 		if _, err := strconv.ParseInt(appliedBefore, 10, 64); err != nil {
 			// Not a Unix timestamp, try RFC3339
 			if _, err := time.Parse(time.RFC3339, appliedBefore); err != nil {
-				respondError(w, http.StatusBadRequest, "invalid applied_before: must be RFC3339 format (e.g. 2026-01-01T00:00:00Z)")
-				return
+				return model.ListOptions{}, errors.New("invalid applied_before: must be RFC3339 format (e.g. 2026-01-01T00:00:00Z)")
 			}
 		}
 	}
@@ -196,15 +344,13 @@ func (h *Handler) ListApplications(w http.ResponseWriter, r *http.Request) {
 	// Salary filters - must be non-negative integers
 	hasSalaryMinGTE := false
 	salaryMinGTE := 0
-	if v := r.URL.Query().Get("salary_min_gte"); v != "" {
+	if v := query.Get("salary_min_gte"); v != "" {
 		n, err := strconv.Atoi(v)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "salary_min_gte must be a non-negative integer")
-			return
+			return model.ListOptions{}, errors.New("salary_min_gte must be a non-negative integer")
 		}
 		if n < 0 {
-			respondError(w, http.StatusBadRequest, "salary_min_gte must be a non-negative integer")
-			return
+			return model.ListOptions{}, errors.New("salary_min_gte must be a non-negative integer")
 		}
 		salaryMinGTE = n
 		hasSalaryMinGTE = true
@@ -212,21 +358,46 @@ func (h *Handler) ListApplications(w http.ResponseWriter, r *http.Request) {
 
 	hasSalaryMaxLTE := false
 	salaryMaxLTE := 0
-	if v := r.URL.Query().Get("salary_max_lte"); v != "" {
+	if v := query.Get("salary_max_lte"); v != "" {
 		n, err := strconv.Atoi(v)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "salary_max_lte must be a non-negative integer")
-			return
+			return model.ListOptions{}, errors.New("salary_max_lte must be a non-negative integer")
 		}
 		if n < 0 {
-			respondError(w, http.StatusBadRequest, "salary_max_lte must be a non-negative integer")
-			return
+			return model.ListOptions{}, errors.New("salary_max_lte must be a non-negative integer")
 		}
 		salaryMaxLTE = n
 		hasSalaryMaxLTE = true
 	}
 
-	opts := model.ListOptions{
+	hasStale := false
+	stale := false
+	if v := query.Get("stale"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return model.ListOptions{}, errors.New("stale must be true or false")
+		}
+		stale = b
+		hasStale = true
+	}
+
+	// Tag filters - repeated or comma-separated; tag requires all, tag_any
+	// at least one, tag_none excludes
+	tags, err := parseTagParam(query, "tag")
+	if err != nil {
+		return model.ListOptions{}, err
+	}
+	tagsAny, err := parseTagParam(query, "tag_any")
+	if err != nil {
+		return model.ListOptions{}, err
+	}
+	tagsNone, err := parseTagParam(query, "tag_none")
+	if err != nil {
+		return model.ListOptions{}, err
+	}
+
+	return model.ListOptions{
+		Query:           q,
 		Status:          status,
 		Limit:           limit,
 		Offset:          offset,
@@ -241,29 +412,115 @@ func (h *Handler) ListApplications(w http.ResponseWriter, r *http.Request) {
 		SalaryMaxLTE:    salaryMaxLTE,
 		HasSalaryMinGTE: hasSalaryMinGTE,
 		HasSalaryMaxLTE: hasSalaryMaxLTE,
+		Stale:           stale,
+		HasStale:        hasStale,
+		Tags:            tags,
+		TagsAny:         tagsAny,
+		TagsNone:        tagsNone,
+		After:           after,
+	}, nil
+}
+
+// parseTagParam collects the tags in every value of the query param name,
+// splitting each on commas.
+func parseTagParam(query url.Values, name string) ([]string, error) {
+	var raw []string
+	for _, v := range query[name] {
+		raw = append(raw, strings.Split(v, ",")...)
 	}
+	tags, err := model.NormalizeTags(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return tags, nil
+}
 
-	apps, err := h.store.List(r.Context(), opts)
+// listOptions parses r's query into ListOptions, loading the pipeline for
+// status validation. It writes the error response and returns false on failure.
+func (h *Handler) listOptions(w http.ResponseWriter, r *http.Request) (model.ListOptions, bool) {
+	return h.parseListQuery(w, r, r.URL.Query())
+}
+
+// parseListQuery is listOptions for a query other than r's own.
+func (h *Handler) parseListQuery(w http.ResponseWriter, r *http.Request, query url.Values) (model.ListOptions, bool) {
+	var pipeline model.Pipeline
+	if query.Get("status") != "" {
+		var err error
+		pipeline, err = h.store.Pipeline(r.Context())
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "failed to load stages")
+			return model.ListOptions{}, false
+		}
+	}
+	opts, err := parseListOptions(query, pipeline)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "failed to list applications")
+		respondError(w, http.StatusBadRequest, err.Error())
+		return model.ListOptions{}, false
+	}
+	return opts, true
+}
+
+func (h *Handler) ListApplications(w http.ResponseWriter, r *http.Request) {
+	opts, ok := h.listOptions(w, r)
+	if !ok {
 		return
 	}
+	h.respondList(w, r, opts)
+}
+
+// respondList writes one page of applications matching opts with its
+// pagination metadata.
+func (h *Handler) respondList(w http.ResponseWriter, r *http.Request, opts model.ListOptions) {
+	if page, ok := h.listPage(w, r, opts); ok {
+		respondJSON(w, http.StatusOK, page)
+	}
+}
+
+// listPage loads one page of applications matching opts. It writes the error
+// response and returns false on failure.
+func (h *Handler) listPage(w http.ResponseWriter, r *http.Request, opts model.ListOptions) (PaginatedResponse, bool) {
+	// Past a cursor the offset is unknown, so one extra row tells whether
+	// there is another page.
+	fetch := opts
+	if opts.After != nil {
+		fetch.Limit++
+	}
+	apps, err := h.store.List(r.Context(), fetch)
+	if errors.Is(err, db.ErrInvalidSearch) {
+		respondError(w, http.StatusBadRequest, invalidSearchMessage)
+		return PaginatedResponse{}, false
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list applications")
+		return PaginatedResponse{}, false
+	}
 
 	total, err := h.store.Count(r.Context(), opts)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "failed to count applications")
-		return
+		return PaginatedResponse{}, false
 	}
 
-	respondJSON(w, http.StatusOK, PaginatedResponse{
+	hasMore := opts.Offset+len(apps) < total
+	if opts.After != nil {
+		hasMore = len(apps) > opts.Limit
+		apps = apps[:min(len(apps), opts.Limit)]
+	}
+	var next string
+	if hasMore && len(apps) > 0 && opts.SortBy != model.SortByRelevance {
+		next = model.NewCursor(apps[len(apps)-1], opts.SortBy, opts.SortOrder).Encode()
+	}
+
+	return PaginatedResponse{
 		Data: apps,
 		Pagination: PaginationMeta{
-			Total:   total,
-			Limit:   limit,
-			Offset:  offset,
-			HasMore: offset+len(apps) < total,
+			Total:      total,
+			Limit:      opts.Limit,
+			Offset:     opts.Offset,
+			HasMore:    hasMore,
+			NextCursor: next,
 		},
-	})
+	}, true
 }
 
 func (h *Handler) GetApplication(w http.ResponseWriter, r *http.Request) {
@@ -281,7 +538,12 @@ func (h *Handler) GetApplication(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusNotFound, "application not found")
 		return
 	}
-	respondJSON(w, http.StatusOK, app)
+	if etag := applicationETag(app); !noneMatch(r.Header.Get("If-None-Match"), etag) {
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	respondApplication(w, http.StatusOK, app)
 }
 
 func (h *Handler) CreateApplication(w http.ResponseWriter, r *http.Request) {
@@ -295,10 +557,37 @@ func (h *Handler) CreateApplication(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, "invalid JSON body")
 		return
 	}
-	if err := req.Validate(); err != nil {
+	pipeline, err := h.store.Pipeline(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to load stages")
+		return
+	}
+	if err := req.Validate(pipeline); err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	force := false
+	if v := r.URL.Query().Get("force"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "force must be true or false")
+			return
+		}
+		force = b
+	}
+
+	dups, err := h.store.FindDuplicates(r.Context(), model.Application{Company: req.Company, Role: req.Role, URL: req.URL})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to check for duplicates")
+		return
+	}
+	if !force && len(dups) > 0 {
+		respondJSON(w, http.StatusConflict, DuplicateConflict{
+			Error:      "application looks like a duplicate; retry with force=true to create it anyway",
+			Duplicates: dups,
+		})
+		return
+	}
 
 	app, err := h.store.Create(r.Context(), req)
 	if err != nil {
@@ -306,9 +595,13 @@ func (h *Handler) CreateApplication(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondJSON(w, http.StatusCreated, app)
+	w.Header().Set("ETag", applicationETag(app))
+	respondJSON(w, http.StatusCreated, CreatedApplication{Application: app, Duplicates: dups})
 }
 
+// UpdateApplication sets the fields in the body and leaves the rest alone.
+// The result goes through the same typed decoding and validation as a PATCH,
+// so fields must have their proper JSON types and cannot be null.
 func (h *Handler) UpdateApplication(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if !isValidID(id) {
@@ -317,41 +610,108 @@ func (h *Handler) UpdateApplication(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var fields map[string]interface{}
-	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
-		var maxBytesErr *http.MaxBytesError
-		if errors.As(err, &maxBytesErr) {
-			respondError(w, http.StatusRequestEntityTooLarge, "request body too large")
+	if !decodeJSON(w, r, &fields) {
+		return
+	}
+
+	// If-Match makes the update conditional on the version the client last
+	// saw. status_note and override_transition control how a status change
+	// is recorded; they are not application columns.
+	opts := db.UpdateOptions{IfVersions: ifMatchVersions(r.Header.Get("If-Match"))}
+	if noteVal, ok := fields["status_note"]; ok {
+		note, ok := noteVal.(string)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "status_note must be a string")
 			return
 		}
-		respondError(w, http.StatusBadRequest, "invalid JSON body")
+		opts.StatusNote = note
+		delete(fields, "status_note")
+	}
+	if overrideVal, ok := fields["override_transition"]; ok {
+		override, ok := overrideVal.(bool)
+		if !ok {
+			respondError(w, http.StatusBadRequest, "override_transition must be a boolean")
+			return
+		}
+		opts.OverrideTransition = override
+		delete(fields, "override_transition")
+	}
+	// Decoding null into a typed field leaves it unchanged, so it has to be
+	// caught here.
+	for field, v := range fields {
+		if v == nil {
+			respondError(w, http.StatusBadRequest, field+" cannot be null")
+			return
+		}
+	}
+
+	pipeline, err := h.store.Pipeline(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to load stages")
 		return
 	}
 
-	if statusVal, ok := fields["status"]; ok {
-		if s, ok := statusVal.(string); ok {
-			if err := model.ValidateStatus(s); err != nil {
-				respondError(w, http.StatusBadRequest, err.Error())
-				return
-			}
+	set := func(doc any) (any, error) {
+		m, _ := doc.(map[string]any)
+		for field, v := range fields {
+			m[field] = v
 		}
+		return m, nil
+	}
+	app, err := h.store.PatchWithOptions(r.Context(), id, func(existing model.Application) (model.Application, error) {
+		return patchApplication(existing, set, pipeline)
+	}, opts)
+	var pe *patchError
+	if errors.As(err, &pe) {
+		respondError(w, pe.status, pe.message)
+		return
+	}
+	if h.respondUpdateError(w, r, id, err) {
+		return
+	}
+	if app == nil {
+		respondError(w, http.StatusNotFound, "application not found")
+		return
 	}
 
-	if minVal, minOk := fields["salary_min"]; minOk {
-		if maxVal, maxOk := fields["salary_max"]; maxOk {
-			if salaryMin, ok := minVal.(float64); ok {
-				if salaryMax, ok := maxVal.(float64); ok {
-					if salaryMin > salaryMax {
-						respondError(w, http.StatusBadRequest, "salary_min cannot be greater than salary_max")
-						return
-					}
-				}
-			}
+	respondApplication(w, http.StatusOK, app)
+}
+
+// respondUpdateError writes the response for an error from updating
+// application id, if there was one, and reports whether it did.
+func (h *Handler) respondUpdateError(w http.ResponseWriter, r *http.Request, id string, err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, db.ErrVersionMismatch) {
+		if current, _ := h.store.Get(r.Context(), id); current != nil {
+			w.Header().Set("ETag", applicationETag(current))
 		}
+		respondError(w, http.StatusPreconditionFailed, "application has changed since it was read; GET it again and retry")
+		return true
+	}
+	var transitionErr *model.TransitionError
+	if errors.As(err, &transitionErr) {
+		respondJSON(w, http.StatusConflict, map[string]interface{}{
+			"error":          transitionErr.Error(),
+			"current_status": transitionErr.From,
+			"allowed":        transitionErr.Allowed,
+		})
+		return true
 	}
+	respondError(w, http.StatusInternalServerError, "failed to update application")
+	return true
+}
 
-	app, err := h.store.Update(r.Context(), id, fields)
+func (h *Handler) GetApplicationHistory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid application ID format")
+		return
+	}
+	app, err := h.store.Get(r.Context(), id)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "failed to update application")
+		respondError(w, http.StatusInternalServerError, "failed to get application")
 		return
 	}
 	if app == nil {
@@ -359,7 +719,12 @@ func (h *Handler) UpdateApplication(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondJSON(w, http.StatusOK, app)
+	history, err := h.store.History(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get application history")
+		return
+	}
+	respondJSON(w, http.StatusOK, history)
 }
 
 func (h *Handler) DeleteApplication(w http.ResponseWriter, r *http.Request) {
@@ -382,6 +747,21 @@ func (h *Handler) DeleteApplication(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// decodeJSON decodes the request body into v, writing a 413 or 400 response
+// and returning false on failure.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondError(w, http.StatusRequestEntityTooLarge, "request body too large")
+			return false
+		}
+		respondError(w, http.StatusBadRequest, "invalid JSON body")
+		return false
+	}
+	return true
+}
+
 func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -29,6 +29,7 @@ func setupTest(t *testing.T) (*handler.Handler, chi.Router) {
 	t.Cleanup(func() { store.Close() })
 
 	h := handler.New(store)
+	h.SetAuthRequired(false)
 	r := chi.NewRouter()
 	h.HealthRoutes(r)
 	h.Routes(r)
@@ -236,6 +237,69 @@ func TestUpdateApplication_NotFound(t *testing.T) {
 	}
 }
 
+func TestApplicationETags(t *testing.T) {
+	_, r := setupTest(t)
+	w := doRequest(r, http.MethodPost, "/applications", `{"company":"Acme","role":"Eng"}`)
+	etag := w.Header().Get("ETag")
+	var app model.Application
+	json.NewDecoder(w.Body).Decode(&app)
+	if etag != `"1"` || app.Version != 1 {
+		t.Fatalf("expected ETag \"1\" on create, got %q (version %d)", etag, app.Version)
+	}
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/applications/"+app.ID, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	put := func(ifMatch, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/applications/"+app.ID, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := get(""); w.Code != http.StatusOK || w.Header().Get("ETag") != etag {
+		t.Fatalf("expected 200 with ETag %s, got %d %q", etag, w.Code, w.Header().Get("ETag"))
+	}
+	for _, header := range []string{etag, `W/"1"`, `"7", "1"`, "*"} {
+		if w := get(header); w.Code != http.StatusNotModified || w.Body.Len() != 0 || w.Header().Get("ETag") != etag {
+			t.Fatalf("If-None-Match %s: expected empty 304, got %d", header, w.Code)
+		}
+	}
+	if w := get(`"2"`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 for a different ETag, got %d", w.Code)
+	}
+
+	// Two tabs both read version 1; the second save is refused.
+	if w := put(etag, `{"notes":"tab one"}`); w.Code != http.StatusOK || w.Header().Get("ETag") != `"2"` {
+		t.Fatalf("expected the first save to succeed with ETag \"2\", got %d %q", w.Code, w.Header().Get("ETag"))
+	}
+	for _, header := range []string{etag, `W/"2"`, "garbage"} {
+		w := put(header, `{"notes":"tab two"}`)
+		if w.Code != http.StatusPreconditionFailed || w.Header().Get("ETag") != `"2"` {
+			t.Fatalf("If-Match %s: expected 412 with the current ETag, got %d %q", header, w.Code, w.Header().Get("ETag"))
+		}
+	}
+	if w := put(`"1", "2"`, `{"notes":"tab two, retried"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected a matching ETag in a list to succeed, got %d", w.Code)
+	}
+	if w := put("*", `{"notes":"anything"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected If-Match * to succeed, got %d", w.Code)
+	}
+	if w := put("", `{"notes":"unconditional"}`); w.Code != http.StatusOK || w.Header().Get("ETag") != `"5"` {
+		t.Fatalf("expected an unconditional save to succeed with ETag \"5\", got %d %q", w.Code, w.Header().Get("ETag"))
+	}
+}
+
 func TestUpdateApplication_InvalidStatus(t *testing.T) {
 	_, r := setupTest(t)
 
@@ -485,6 +549,62 @@ func TestListApplications_NegativeOffset(t *testing.T) {
 	}
 }
 
+func TestListApplications_Cursor(t *testing.T) {
+	_, r := setupTest(t)
+	for _, company := range []string{"A", "B", "C", "D", "E"} {
+		createApp(t, r, `{"company":"`+company+`","role":"Eng"}`)
+	}
+
+	seen := map[string]bool{}
+	path := "/applications?limit=2"
+	for page := 0; path != ""; page++ {
+		w := doRequest(r, http.MethodGet, path, "")
+		var resp handler.PaginatedResponse
+		json.NewDecoder(w.Body).Decode(&resp)
+		if w.Code != http.StatusOK || resp.Pagination.Total != 5 || resp.Pagination.HasMore != (resp.Pagination.NextCursor != "") {
+			t.Fatalf("page %d: unexpected response %d %+v", page, w.Code, resp.Pagination)
+		}
+		for _, app := range resp.Data {
+			if seen[app.ID] {
+				t.Fatalf("page %d: %s seen twice", page, app.ID)
+			}
+			seen[app.ID] = true
+		}
+		// Edits to rows already read must not shift the rest.
+		if page == 0 {
+			doRequest(r, http.MethodPut, "/applications/"+resp.Data[0].ID, `{"notes":"touched"}`)
+		}
+		path = ""
+		if resp.Pagination.NextCursor != "" {
+			path = "/applications?limit=2&cursor=" + resp.Pagination.NextCursor
+		}
+	}
+	if len(seen) != 5 {
+		t.Fatalf("expected all 5 applications, got %d", len(seen))
+	}
+
+	w := doRequest(r, http.MethodGet, "/applications?limit=2&sort_by=company&sort_order=asc", "")
+	var resp handler.PaginatedResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	cursor := resp.Pagination.NextCursor
+	w = doRequest(r, http.MethodGet, "/applications?limit=2&sort_by=company&sort_order=asc&cursor="+cursor, "")
+	json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp.Data) != 2 || resp.Data[0].Company != "C" || resp.Data[1].Company != "D" {
+		t.Fatalf("expected C and D after the cursor, got %+v", resp.Data)
+	}
+
+	for _, path := range []string{
+		"/applications?cursor=garbage",
+		"/applications?sort_by=company&sort_order=asc&offset=2&cursor=" + cursor,
+		"/applications?sort_by=company&sort_order=desc&cursor=" + cursor,
+		"/applications?cursor=" + cursor,
+	} {
+		if w := doRequest(r, http.MethodGet, path, ""); w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
+
 func TestMalformedIDs(t *testing.T) {
 	h, _ := setupTest(t)
 
@@ -1105,3 +1225,291 @@ func TestListApplications_NegativeSalaryMaxLTE(t *testing.T) {
 		t.Fatalf("expected 400, got %d", w.Code)
 	}
 }
+
+func createApp(t *testing.T, r chi.Router, body string) model.Application {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/applications", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var app model.Application
+	json.NewDecoder(w.Body).Decode(&app)
+	return app
+}
+
+// doRequest sends a request with a JSON content type and returns the recorder.
+func doRequest(r chi.Router, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetApplicationHistory(t *testing.T) {
+	_, r := setupTest(t)
+	created := createApp(t, r, `{"company":"Acme Corp","role":"Engineer","status":"applied"}`)
+
+	update := `{"status":"phone_screen","status_note":"Recruiter reached out"}`
+	req := httptest.NewRequest(http.MethodPut, "/applications/"+created.ID, bytes.NewBufferString(update))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "status_note") {
+		t.Fatalf("status_note should not be stored on the application: %s", w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/applications/"+created.ID+"/history", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var history []model.StatusChange
+	json.NewDecoder(w.Body).Decode(&history)
+	if len(history) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(history))
+	}
+	last := history[1]
+	if last.FromStatus != "applied" || last.ToStatus != "phone_screen" || last.Note != "Recruiter reached out" {
+		t.Errorf("unexpected transition: %+v", last)
+	}
+}
+
+func TestGetApplicationHistory_NotFound(t *testing.T) {
+	_, r := setupTest(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/applications/deadbeef/history", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestUpdateApplication_InvalidStatusNote(t *testing.T) {
+	_, r := setupTest(t)
+	created := createApp(t, r, `{"company":"Acme Corp","role":"Engineer"}`)
+
+	req := httptest.NewRequest(http.MethodPut, "/applications/"+created.ID, bytes.NewBufferString(`{"status":"applied","status_note":42}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestUpdateApplication_TypedValidation(t *testing.T) {
+	_, r := setupTest(t)
+	created := createApp(t, r, `{"company":"Acme Corp","role":"Engineer","notes":"keep me","salary_min":100000}`)
+
+	for body, want := range map[string]string{
+		`{"notes":null}`:            "notes cannot be null",
+		`{"salary_min":"abc"}`:      "salary_min must be an integer, not string",
+		`{"salary_max":50000}`:      "",
+		`{"company":""}`:            "",
+		`{"id":"0123456789abcdef"}`: "id is read-only",
+		`{"bogus":1}`:               "unknown field",
+	} {
+		w := doRequest(r, http.MethodPut, "/applications/"+created.ID, body)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d: %s", body, w.Code, w.Body.String())
+		}
+		if want != "" && !strings.Contains(w.Body.String(), want) {
+			t.Errorf("%s: expected %q, got %s", body, want, w.Body.String())
+		}
+	}
+
+	w := doRequest(r, http.MethodGet, "/applications/"+created.ID, "")
+	var got model.Application
+	json.NewDecoder(w.Body).Decode(&got)
+	if got.Notes != "keep me" || got.SalaryMin != 100000 || got.Version != created.Version {
+		t.Fatalf("expected rejected updates to change nothing, got %+v", got)
+	}
+}
+
+func TestUpdateApplication_IllegalTransition(t *testing.T) {
+	_, r := setupTest(t)
+	created := createApp(t, r, `{"company":"Acme Corp","role":"Engineer","status":"rejected"}`)
+
+	req := httptest.NewRequest(http.MethodPut, "/applications/"+created.ID, bytes.NewBufferString(`{"status":"offer"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Error         string   `json:"error"`
+		CurrentStatus string   `json:"current_status"`
+		Allowed       []string `json:"allowed"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.CurrentStatus != "rejected" || resp.Allowed == nil || resp.Error == "" {
+		t.Fatalf("unexpected conflict body: %+v", resp)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/applications/"+created.ID, bytes.NewBufferString(`{"status":"offer","override_transition":true}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 with override, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestUpdateApplication_ConflictListsAllowed(t *testing.T) {
+	_, r := setupTest(t)
+	created := createApp(t, r, `{"company":"Acme Corp","role":"Engineer","status":"offer"}`)
+
+	req := httptest.NewRequest(http.MethodPut, "/applications/"+created.ID, bytes.NewBufferString(`{"status":"wishlist"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", w.Code)
+	}
+	var resp struct {
+		Allowed []string `json:"allowed"`
+	}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if strings.Join(resp.Allowed, ",") != "accepted,rejected,withdrawn" {
+		t.Fatalf("unexpected allowed list: %v", resp.Allowed)
+	}
+}
+
+func TestStages_CRUD(t *testing.T) {
+	_, r := setupTest(t)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodGet, "/stages", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var stages []model.Stage
+	json.NewDecoder(w.Body).Decode(&stages)
+	if len(stages) != 9 || stages[0].Name != "wishlist" || stages[0].Label != "Wishlist" {
+		t.Fatalf("unexpected seeded stages: %+v", stages)
+	}
+
+	w = do(http.MethodPost, "/stages", `{"name":"onsite","label":"Onsite","position":45,"next":["offer","rejected"]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	w = do(http.MethodPost, "/stages", `{"name":"onsite","label":"Onsite"}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for duplicate, got %d", w.Code)
+	}
+	w = do(http.MethodPost, "/stages", `{"name":"reference_check","label":"Refs","next":["bogus"]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown next stage, got %d", w.Code)
+	}
+
+	w = do(http.MethodPut, "/stages/interview", `{"next":["onsite","offer","rejected"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	app := createApp(t, r, `{"company":"Acme","role":"Eng","status":"interview"}`)
+	w = do(http.MethodPut, "/applications/"+app.ID, `{"status":"onsite"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected move to custom stage to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = do(http.MethodDelete, "/stages/onsite", "")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 deleting in-use stage, got %d", w.Code)
+	}
+	w = do(http.MethodDelete, "/stages/wishlist", "")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", w.Code, w.Body.String())
+	}
+	w = do(http.MethodGet, "/stages/wishlist", "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 after delete, got %d", w.Code)
+	}
+
+	w = do(http.MethodPost, "/applications", `{"company":"Acme","role":"Eng","status":"wishlist"}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "onsite") {
+		t.Fatalf("expected 400 listing configured stages, got %d: %s", w.Code, w.Body.String())
+	}
+	created := createApp(t, r, `{"company":"Globex","role":"Eng"}`)
+	if created.Status != "applied" {
+		t.Fatalf("expected default status to be first active stage, got %q", created.Status)
+	}
+}
+
+func TestListApplications_Search(t *testing.T) {
+	_, r := setupTest(t)
+	createApp(t, r, `{"company":"Kubeworks","role":"Platform Engineer","notes":"Recruiter mentioned Kubernetes"}`)
+	createApp(t, r, `{"company":"Acme","role":"Backend Engineer","notes":"Go"}`)
+
+	req := httptest.NewRequest(http.MethodGet, "/applications?q=kubernetes&sort_by=relevance", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp handler.PaginatedResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Pagination.Total != 1 || len(resp.Data) != 1 {
+		t.Fatalf("expected 1 result, got total=%d len=%d", resp.Pagination.Total, len(resp.Data))
+	}
+	if !strings.Contains(resp.Data[0].Snippet, "<mark>Kubernetes</mark>") {
+		t.Fatalf("expected highlighted snippet, got %q", resp.Data[0].Snippet)
+	}
+}
+
+func TestListApplications_SearchErrors(t *testing.T) {
+	_, r := setupTest(t)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "relevance without q", query: "sort_by=relevance"},
+		{name: "malformed query", query: "q=%22unterminated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/applications?"+tt.query, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestListApplications_StaleFilter(t *testing.T) {
+	_, r := setupTest(t)
+	createApp(t, r, `{"company":"Acme","role":"Engineer"}`)
+
+	if w := doRequest(r, http.MethodGet, "/applications?stale=maybe", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	w := doRequest(r, http.MethodGet, "/applications?stale=false", "")
+	var resp handler.PaginatedResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp.Pagination.Total != 1 || resp.Data[0].StaleAt != "" {
+		t.Fatalf("expected the unflagged application, got %+v", resp)
+	}
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+)
+
+const maxIdempotencyKeyLen = 255
+
+// replayedHeaders are the response headers saved with an idempotent
+// response; the rest are either set by middleware again on replay or
+// meaningless the second time.
+var replayedHeaders = []string{"Content-Type", "Content-Disposition", "ETag", "Location"}
+
+// recordingWriter passes a response through while keeping a copy of it.
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// idempotent makes POST and PATCH requests carrying an Idempotency-Key safe
+// to retry. The first request with a key runs and its response is saved
+// unless it is a server error; a repeat with the same method, URL,
+// Content-Type and body gets the saved response back with
+// Idempotent-Replayed: true instead of running again. Reusing a key for a
+// different request is a 422, and retrying while the first is still running
+// a 409. It must come after the route's body limit.
+func (h *Handler) idempotent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if key == "" || h.idempotencyTTL <= 0 || (r.Method != http.MethodPost && r.Method != http.MethodPatch) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !validIdempotencyKey(key) {
+			respondError(w, http.StatusBadRequest, "Idempotency-Key must be 1-255 printable ASCII characters")
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				respondError(w, http.StatusRequestEntityTooLarge, "request body too large")
+				return
+			}
+			respondError(w, http.StatusBadRequest, "failed to read body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		hash := sha256.New()
+		io.WriteString(hash, r.Method+"\n"+r.URL.RequestURI()+"\n"+r.Header.Get("Content-Type")+"\n")
+		hash.Write(body)
+
+		stored, err := h.store.BeginIdempotent(r.Context(), key, hex.EncodeToString(hash.Sum(nil)))
+		switch {
+		case errors.Is(err, db.ErrIdempotencyMismatch):
+			respondError(w, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+			return
+		case errors.Is(err, db.ErrIdempotencyInProgress):
+			respondError(w, http.StatusConflict, "a request with this Idempotency-Key is still being processed; retry shortly")
+			return
+		case err != nil:
+			respondError(w, http.StatusInternalServerError, "failed to check Idempotency-Key")
+			return
+		case stored != nil:
+			for name, value := range stored.Header {
+				w.Header().Set(name, value)
+			}
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(stored.Status)
+			w.Write(stored.Body)
+			return
+		}
+
+		rec := &recordingWriter{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+
+		// The client may be gone, but the key must still be settled.
+		ctx := context.WithoutCancel(r.Context())
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		if rec.status >= http.StatusInternalServerError {
+			err = h.store.AbandonIdempotent(ctx, key)
+		} else {
+			resp := db.StoredResponse{Status: rec.status, Header: map[string]string{}, Body: rec.body.Bytes()}
+			for _, name := range replayedHeaders {
+				if value := w.Header().Get(name); value != "" {
+					resp.Header[name] = value
+				}
+			}
+			err = h.store.FinishIdempotent(ctx, key, resp, h.idempotencyTTL)
+		}
+		if err != nil {
+			slog.Error("failed to save idempotent response", "error", err)
+		}
+	})
+}
+
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLen {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/handler"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func doWithKey(r chi.Router, method, path, body, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	_, r := setupTest(t)
+	body := `{"company":"Acme","role":"Eng"}`
+
+	first := doWithKey(r, http.MethodPost, "/applications", body, "retry-1")
+	if first.Code != http.StatusCreated || first.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("expected 201, got %d: %s", first.Code, first.Body.String())
+	}
+	again := doWithKey(r, http.MethodPost, "/applications", body, "retry-1")
+	if again.Code != http.StatusCreated || again.Body.String() != first.Body.String() ||
+		again.Header().Get("Idempotent-Replayed") != "true" || again.Header().Get("ETag") != first.Header().Get("ETag") {
+		t.Fatalf("expected the first response replayed, got %d %v: %s", again.Code, again.Header(), again.Body.String())
+	}
+
+	var list handler.PaginatedResponse
+	json.NewDecoder(doRequest(r, http.MethodGet, "/applications", "").Body).Decode(&list)
+	if list.Pagination.Total != 1 {
+		t.Fatalf("expected one application, got %d", list.Pagination.Total)
+	}
+
+	if w := doWithKey(r, http.MethodPost, "/applications", `{"company":"Globex","role":"Eng"}`, "retry-1"); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 for a reused key, got %d", w.Code)
+	}
+	if w := doWithKey(r, http.MethodPost, "/applications?force=true", body, "retry-2"); w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("expected a new key to create again, got %d", w.Code)
+	}
+
+	// Client errors are replayed too; the request would fail the same way.
+	bad := doWithKey(r, http.MethodPost, "/applications", `{"role":"Eng"}`, "retry-3")
+	if w := doWithKey(r, http.MethodPost, "/applications", `{"role":"Eng"}`, "retry-3"); w.Code != bad.Code || w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("expected the 400 replayed, got %d", w.Code)
+	}
+
+	// PATCH honors the key; PUT ignores it.
+	var app model.Application
+	json.NewDecoder(first.Body).Decode(&app)
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPatch, "/applications/"+app.ID, bytes.NewBufferString(`{"notes":"once"}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("Idempotency-Key", "patch-1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK || w.Header().Get("ETag") != `"2"` {
+			t.Fatalf("patch %d: expected 200 at version 2, got %d %q", i, w.Code, w.Header().Get("ETag"))
+		}
+	}
+	if w := doWithKey(r, http.MethodPut, "/applications/"+app.ID, `{"notes":"put"}`, "patch-1"); w.Code != http.StatusOK {
+		t.Fatalf("expected PUT to ignore the key, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := doWithKey(r, http.MethodPost, "/applications", body, "bad\x01key"); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid key, got %d", w.Code)
+	}
+}
+
+func TestIdempotencyKeyPerUser(t *testing.T) {
+	r, _ := setupUsersTest(t)
+	ann, bob := login(t, r, "ann@example.com"), login(t, r, "bob@example.com")
+	post := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/applications", bytes.NewBufferString(`{"company":"Acme","role":"Eng"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Idempotency-Key", "same-key")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	a, b := post(ann.Token), post(bob.Token)
+	if a.Code != http.StatusCreated || b.Code != http.StatusCreated || b.Header().Get("Idempotent-Replayed") != "" || a.Body.String() == b.Body.String() {
+		t.Fatalf("expected each user's key to be separate, got %d and %d", a.Code, b.Code)
+	}
+}
@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+const maxImportBytes = 10 << 20 // 10 MB
+
+// importFields are the CreateRequest fields a CSV column can map to.
+var importFields = map[string]bool{
+	"company":    true,
+	"role":       true,
+	"url":        true,
+	"salary_min": true,
+	"salary_max": true,
+	"location":   true,
+	"status":     true,
+	"notes":      true,
+	"applied_at": true,
+	"tags":       true,
+}
+
+// ImportRowResult reports the outcome for one CSV data row. Row is the
+// 1-based line where the record starts, so the first data row is usually 2.
+type ImportRowResult struct {
+	Row    int    `json:"row"`
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun         bool              `json:"dry_run"`
+	Created        int               `json:"created"`
+	Skipped        int               `json:"skipped"`
+	Failed         int               `json:"failed"`
+	IgnoredColumns []string          `json:"ignored_columns"`
+	Rows           []ImportRowResult `json:"rows"`
+}
+
+// Row statuses in an ImportReport.
+const (
+	importCreated     = "created"
+	importWouldCreate = "would_create"
+	importSkipped     = "skipped"
+	importFailed      = "failed"
+)
+
+// ImportApplications bulk-loads applications from a text/csv body. Columns
+// are matched to fields by normalized header name unless overridden with
+// map=<header>:<field> query params. Every row is validated like POST
+// /applications; valid rows are inserted in one transaction, invalid ones are
+// reported and skipped. dry_run=true reports without writing.
+func (h *Handler) ImportApplications(w http.ResponseWriter, r *http.Request) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "text/csv" {
+		respondError(w, http.StatusUnsupportedMediaType, "Content-Type must be text/csv")
+		return
+	}
+
+	dryRun := false
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "dry_run must be true or false")
+			return
+		}
+		dryRun = b
+	}
+
+	overrides := make(map[string]string)
+	for _, m := range r.URL.Query()["map"] {
+		i := strings.LastIndex(m, ":")
+		if i <= 0 {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("invalid map %q: must be <csv header>:<field>", m))
+			return
+		}
+		header, field := m[:i], m[i+1:]
+		if !importFields[field] {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("invalid map %q: unknown field %q", m, field))
+			return
+		}
+		overrides[normalizeHeader(header)] = field
+	}
+
+	reader := csv.NewReader(r.Body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		respondImportReadError(w, err)
+		return
+	}
+
+	// columns[i] is the field for CSV column i, or "" if ignored.
+	columns := make([]string, len(header))
+	mapped := make(map[string]bool)
+	report := ImportReport{DryRun: dryRun, IgnoredColumns: []string{}, Rows: []ImportRowResult{}}
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // Excel writes a UTF-8 BOM
+		}
+		key := normalizeHeader(name)
+		field, ok := overrides[key]
+		if !ok && importFields[key] {
+			field = key
+		}
+		if field == "" || mapped[field] {
+			report.IgnoredColumns = append(report.IgnoredColumns, name)
+			continue
+		}
+		columns[i] = field
+		mapped[field] = true
+	}
+	if !mapped["company"] || !mapped["role"] {
+		respondError(w, http.StatusBadRequest, "CSV must have company and role columns (use map=<header>:company to map other names)")
+		return
+	}
+
+	pipeline, err := h.store.Pipeline(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to load stages")
+		return
+	}
+
+	var valid []model.CreateRequest
+	var validRows []int
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			respondImportReadError(w, err)
+			return
+		}
+		line, _ := reader.FieldPos(0)
+
+		req, blank, err := importRecord(columns, record)
+		switch {
+		case blank:
+			report.Rows = append(report.Rows, ImportRowResult{Row: line, Status: importSkipped, Error: "empty row"})
+			report.Skipped++
+			continue
+		case err == nil:
+			err = req.Validate(pipeline)
+		}
+		if err != nil {
+			report.Rows = append(report.Rows, ImportRowResult{Row: line, Status: importFailed, Error: err.Error()})
+			report.Failed++
+			continue
+		}
+
+		valid = append(valid, req)
+		validRows = append(validRows, len(report.Rows))
+		report.Rows = append(report.Rows, ImportRowResult{Row: line, Status: importWouldCreate})
+	}
+
+	if !dryRun && len(valid) > 0 {
+		ids, err := h.store.CreateBatch(r.Context(), valid)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "failed to import applications")
+			return
+		}
+		for n, i := range validRows {
+			report.Rows[i].Status = importCreated
+			report.Rows[i].ID = ids[n]
+		}
+	}
+	report.Created = len(valid)
+	respondJSON(w, http.StatusOK, report)
+}
+
+// importRecord converts one CSV record into a CreateRequest. blank is true
+// when every mapped cell is empty.
+func importRecord(columns []string, record []string) (req model.CreateRequest, blank bool, err error) {
+	blank = true
+	for i, field := range columns {
+		if field == "" || i >= len(record) {
+			continue
+		}
+		value := unescapeCell(strings.TrimSpace(record[i]))
+		if value == "" {
+			continue
+		}
+		blank = false
+
+		switch field {
+		case "company":
+			req.Company = value
+		case "role":
+			req.Role = value
+		case "url":
+			req.URL = value
+		case "location":
+			req.Location = value
+		case "status":
+			req.Status = strings.ToLower(value)
+		case "notes":
+			req.Notes = value
+		case "applied_at":
+			req.AppliedAt = value
+		case "tags":
+			req.Tags = strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' })
+		case "salary_min", "salary_max":
+			n, convErr := parseSalary(value)
+			if convErr != nil {
+				err = fmt.Errorf("%s must be a non-negative integer, got %q", field, value)
+				continue
+			}
+			if field == "salary_min" {
+				req.SalaryMin = &n
+			} else {
+				req.SalaryMax = &n
+			}
+		}
+	}
+	return req, blank, err
+}
+
+// parseSalary accepts spreadsheet-style amounts such as "$150,000".
+func parseSalary(s string) (int, error) {
+	s = strings.NewReplacer("$", "", ",", "", "_", "", " ", "").Replace(s)
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, errors.New("invalid salary")
+	}
+	return n, nil
+}
+
+func normalizeHeader(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(s)
+}
+
+func respondImportReadError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		respondError(w, http.StatusRequestEntityTooLarge, "request body too large")
+		return
+	}
+	if err == io.EOF {
+		respondError(w, http.StatusBadRequest, "CSV is empty; expected a header row")
+		return
+	}
+	respondError(w, http.StatusBadRequest, "invalid CSV: "+err.Error())
+}
@@ -0,0 +1,161 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/handler"
+)
+
+func postCSV(t *testing.T, r chi.Router, query, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/applications/import"+query, strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func listTotal(t *testing.T, r chi.Router) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/applications", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var resp handler.PaginatedResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	return resp.Pagination.Total
+}
+
+const importCSV = "\ufeffCompany,Role,Salary Min,Salary Max,Status,Notes,Source\n" +
+	"Acme,Backend Engineer,\"$150,000\",180000,applied,\"Referred by Jane\nfollow up Friday\",LinkedIn\n" +
+	",,,,,,\n" +
+	"Globex,,,,,,\n" +
+	"Initech,SRE,200000,100000,,,\n" +
+	"Umbrella,Platform,abc,,,,\n" +
+	"Hooli,Data Engineer,,,bogus,,\n" +
+	"Pied Piper,Staff Engineer,,,,,\n"
+
+func TestImportApplications_Report(t *testing.T) {
+	_, r := setupTest(t)
+
+	w := postCSV(t, r, "", importCSV)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var report handler.ImportReport
+	json.NewDecoder(w.Body).Decode(&report)
+	if report.DryRun || report.Created != 2 || report.Skipped != 1 || report.Failed != 4 {
+		t.Fatalf("unexpected counts: %+v", report)
+	}
+	if len(report.IgnoredColumns) != 1 || report.IgnoredColumns[0] != "Source" {
+		t.Fatalf("expected Source to be ignored, got %v", report.IgnoredColumns)
+	}
+
+	want := []struct {
+		row    int
+		status string
+		err    string
+	}{
+		{2, "created", ""},
+		{4, "skipped", "empty row"},
+		{5, "failed", "role is required"},
+		{6, "failed", "salary_min cannot be greater than salary_max"},
+		{7, "failed", "salary_min must be a non-negative integer"},
+		{8, "failed", `invalid status "bogus"`},
+		{9, "created", ""},
+	}
+	if len(report.Rows) != len(want) {
+		t.Fatalf("expected %d rows, got %d: %+v", len(want), len(report.Rows), report.Rows)
+	}
+	for i, w := range want {
+		got := report.Rows[i]
+		if got.Row != w.row || got.Status != w.status || !strings.Contains(got.Error, w.err) {
+			t.Errorf("row %d: expected %d/%s/%q, got %+v", i, w.row, w.status, w.err, got)
+		}
+		if w.status == "created" && got.ID == "" {
+			t.Errorf("row %d: expected created row to have an ID", i)
+		}
+	}
+
+	if total := listTotal(t, r); total != 2 {
+		t.Fatalf("expected 2 applications stored, got %d", total)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/applications/"+report.Rows[0].ID, nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	body := rec.Body.String()
+	if !strings.Contains(body, `"salary_min":150000`) || !strings.Contains(body, `"status":"applied"`) {
+		t.Fatalf("imported row has unexpected fields: %s", body)
+	}
+}
+
+func TestImportApplications_DryRun(t *testing.T) {
+	_, r := setupTest(t)
+
+	w := postCSV(t, r, "?dry_run=true", "company,role\nAcme,Eng\nGlobex,SRE\n")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var report handler.ImportReport
+	json.NewDecoder(w.Body).Decode(&report)
+	if !report.DryRun || report.Created != 2 || report.Rows[0].Status != "would_create" || report.Rows[0].ID != "" {
+		t.Fatalf("unexpected dry run report: %+v", report)
+	}
+	if total := listTotal(t, r); total != 0 {
+		t.Fatalf("dry run must not write, found %d applications", total)
+	}
+}
+
+func TestImportApplications_HeaderMapping(t *testing.T) {
+	_, r := setupTest(t)
+
+	csv := "Employer,Job Title,Where\nAcme,Backend Engineer,Remote\n"
+	w := postCSV(t, r, "", csv)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without company/role columns, got %d", w.Code)
+	}
+
+	w = postCSV(t, r, "?map=Employer:company&map=Job%20Title:role&map=Where:location", csv)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var report handler.ImportReport
+	json.NewDecoder(w.Body).Decode(&report)
+	if report.Created != 1 {
+		t.Fatalf("expected 1 created, got %+v", report)
+	}
+
+	w = postCSV(t, r, "?map=Employer:employer", csv)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown target field, got %d", w.Code)
+	}
+}
+
+func TestImportApplications_BadRequests(t *testing.T) {
+	_, r := setupTest(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/applications/import", strings.NewReader("company,role\n"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected 415, got %d", w.Code)
+	}
+
+	if w := postCSV(t, r, "", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for empty body, got %d", w.Code)
+	}
+	if w := postCSV(t, r, "", "company,role\n\"Acme,Eng\n"); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for malformed CSV, got %d", w.Code)
+	}
+	if w := postCSV(t, r, "?dry_run=maybe", "company,role\n"); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for bad dry_run, got %d", w.Code)
+	}
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// applicationFromPath loads the application named by the {id} URL param,
+// writing a 400, 404 or 500 response and returning false if it cannot.
+func (h *Handler) applicationFromPath(w http.ResponseWriter, r *http.Request) (*model.Application, bool) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid application ID format")
+		return nil, false
+	}
+	app, err := h.store.Get(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get application")
+		return nil, false
+	}
+	if app == nil {
+		respondError(w, http.StatusNotFound, "application not found")
+		return nil, false
+	}
+	return app, true
+}
+
+func (h *Handler) ListInterviews(w http.ResponseWriter, r *http.Request) {
+	app, ok := h.applicationFromPath(w, r)
+	if !ok {
+		return
+	}
+	interviews, err := h.store.ListInterviews(r.Context(), app.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list interviews")
+		return
+	}
+	respondJSON(w, http.StatusOK, interviews)
+}
+
+func (h *Handler) GetInterview(w http.ResponseWriter, r *http.Request) {
+	app, ok := h.applicationFromPath(w, r)
+	if !ok {
+		return
+	}
+	interviewID := chi.URLParam(r, "interviewID")
+	if !isValidID(interviewID) {
+		respondError(w, http.StatusBadRequest, "invalid interview ID format")
+		return
+	}
+	iv, err := h.store.GetInterview(r.Context(), app.ID, interviewID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get interview")
+		return
+	}
+	if iv == nil {
+		respondError(w, http.StatusNotFound, "interview not found")
+		return
+	}
+	respondJSON(w, http.StatusOK, iv)
+}
+
+func (h *Handler) CreateInterview(w http.ResponseWriter, r *http.Request) {
+	app, ok := h.applicationFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.InterviewRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	iv := model.Interview{ApplicationID: app.ID}
+	if err := req.Apply(&iv, true); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	created, err := h.store.CreateInterview(r.Context(), iv)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to create interview")
+		return
+	}
+	respondJSON(w, http.StatusCreated, created)
+}
+
+func (h *Handler) UpdateInterview(w http.ResponseWriter, r *http.Request) {
+	app, ok := h.applicationFromPath(w, r)
+	if !ok {
+		return
+	}
+	interviewID := chi.URLParam(r, "interviewID")
+	if !isValidID(interviewID) {
+		respondError(w, http.StatusBadRequest, "invalid interview ID format")
+		return
+	}
+
+	var req model.InterviewRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	iv, err := h.store.GetInterview(r.Context(), app.ID, interviewID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get interview")
+		return
+	}
+	if iv == nil {
+		respondError(w, http.StatusNotFound, "interview not found")
+		return
+	}
+	if err := req.Apply(iv, false); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := h.store.UpdateInterview(r.Context(), *iv)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to update interview")
+		return
+	}
+	if updated == nil {
+		respondError(w, http.StatusNotFound, "interview not found")
+		return
+	}
+	respondJSON(w, http.StatusOK, updated)
+}
+
+func (h *Handler) DeleteInterview(w http.ResponseWriter, r *http.Request) {
+	app, ok := h.applicationFromPath(w, r)
+	if !ok {
+		return
+	}
+	interviewID := chi.URLParam(r, "interviewID")
+	if !isValidID(interviewID) {
+		respondError(w, http.StatusBadRequest, "invalid interview ID format")
+		return
+	}
+
+	deleted, err := h.store.DeleteInterview(r.Context(), app.ID, interviewID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to delete interview")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "interview not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListAgenda returns interviews across all applications in start order. from
+// defaults to now, so the default view is everything upcoming; to is open
+// ended unless given. A date bound covers that whole day in UTC.
+func (h *Handler) ListAgenda(w http.ResponseWriter, r *http.Request) {
+	from := time.Now().UTC().Format(time.RFC3339)
+	if v := r.URL.Query().Get("from"); v != "" {
+		t, err := parseAgendaBound(v, false)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("from %s", err))
+			return
+		}
+		from = t
+	}
+	to := ""
+	if v := r.URL.Query().Get("to"); v != "" {
+		t, err := parseAgendaBound(v, true)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("to %s", err))
+			return
+		}
+		if t <= from {
+			respondError(w, http.StatusBadRequest, "to must be after from")
+			return
+		}
+		to = t
+	}
+
+	agenda, err := h.store.Agenda(r.Context(), from, to)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list interviews")
+		return
+	}
+	respondJSON(w, http.StatusOK, agenda)
+}
+
+// parseAgendaBound accepts RFC3339 or YYYY-MM-DD and returns RFC3339 UTC. An
+// end date is moved to the following midnight so the whole day is included.
+func parseAgendaBound(v string, end bool) (string, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t.UTC().Format(time.RFC3339), nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return "", fmt.Errorf("must be YYYY-MM-DD or RFC3339")
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t.Format(time.RFC3339), nil
+}
@@ -0,0 +1,101 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestInterviews_CRUD(t *testing.T) {
+	_, r := setupTest(t)
+	app := createApp(t, r, `{"company":"Acme","role":"Backend Engineer"}`)
+	base := "/applications/" + app.ID + "/interviews"
+
+	w := doRequest(r, http.MethodPost, base, `{"starts_at":"2026-03-02T10:00","ends_at":"2026-03-02T11:00","timezone":"America/New_York","format":"video","interviewers":["Ana"," ","Ben"],"location":"https://meet.example.com/abc"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var iv model.Interview
+	json.NewDecoder(w.Body).Decode(&iv)
+	if iv.StartsAt != "2026-03-02T15:00:00Z" || iv.Timezone != "America/New_York" || len(iv.Interviewers) != 2 || iv.Outcome != "pending" {
+		t.Fatalf("unexpected interview: %+v", iv)
+	}
+
+	if w := doRequest(r, http.MethodPost, base, `{"starts_at":"2026-03-02T10:00","format":"carrier pigeon"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid format, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodPost, "/applications/deadbeef/interviews", `{"starts_at":"2026-03-02T10:00","format":"phone"}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for missing application, got %d", w.Code)
+	}
+
+	w = doRequest(r, http.MethodPut, base+"/"+iv.ID, `{"outcome":"passed","feedback":"Strong system design"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var updated model.Interview
+	json.NewDecoder(w.Body).Decode(&updated)
+	if updated.Outcome != "passed" || updated.Feedback != "Strong system design" || updated.StartsAt != iv.StartsAt || updated.Format != "video" {
+		t.Fatalf("expected partial update, got %+v", updated)
+	}
+	if w := doRequest(r, http.MethodPut, base+"/"+iv.ID, `{"ends_at":"2026-03-02T09:00"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for end before start, got %d", w.Code)
+	}
+
+	other := createApp(t, r, `{"company":"Globex","role":"SRE"}`)
+	if w := doRequest(r, http.MethodGet, "/applications/"+other.ID+"/interviews/"+iv.ID, ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for interview under another application, got %d", w.Code)
+	}
+
+	w = doRequest(r, http.MethodGet, base, "")
+	var list []model.Interview
+	json.NewDecoder(w.Body).Decode(&list)
+	if len(list) != 1 {
+		t.Fatalf("expected 1 interview, got %+v", list)
+	}
+
+	if w := doRequest(r, http.MethodDelete, base+"/"+iv.ID, ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodGet, base+"/"+iv.ID, ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 after delete, got %d", w.Code)
+	}
+}
+
+func TestInterviews_Agenda(t *testing.T) {
+	_, r := setupTest(t)
+	acme := createApp(t, r, `{"company":"Acme","role":"Backend Engineer"}`)
+	globex := createApp(t, r, `{"company":"Globex","role":"SRE"}`)
+
+	past := time.Now().UTC().Add(-48 * time.Hour).Format(time.RFC3339)
+	soon := time.Now().UTC().Add(24 * time.Hour).Format(time.RFC3339)
+	later := time.Now().UTC().Add(10 * 24 * time.Hour).Format(time.RFC3339)
+	for _, c := range []struct{ app, start string }{{acme.ID, later}, {globex.ID, soon}, {acme.ID, past}} {
+		w := doRequest(r, http.MethodPost, "/applications/"+c.app+"/interviews", `{"starts_at":"`+c.start+`","format":"phone"}`)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	w := doRequest(r, http.MethodGet, "/interviews", "")
+	var agenda []model.ScheduledInterview
+	json.NewDecoder(w.Body).Decode(&agenda)
+	if len(agenda) != 2 || agenda[0].Company != "Globex" || agenda[1].Company != "Acme" {
+		t.Fatalf("expected upcoming Globex then Acme, got %+v", agenda)
+	}
+
+	to := time.Now().UTC().Add(48 * time.Hour).Format("2006-01-02")
+	w = doRequest(r, http.MethodGet, "/interviews?from="+past[:10]+"&to="+to, "")
+	json.NewDecoder(w.Body).Decode(&agenda)
+	if len(agenda) != 2 || agenda[0].ApplicationID != acme.ID || agenda[0].Role != "Backend Engineer" {
+		t.Fatalf("expected past and soon interviews, got %+v", agenda)
+	}
+
+	for _, q := range []string{"?from=tomorrow", "?to=2020-13-01", "?from=2026-03-02&to=2026-03-01"} {
+		if w := doRequest(r, http.MethodGet, "/interviews"+q, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", q, w.Code)
+		}
+	}
+}
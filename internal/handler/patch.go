@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+	"github.com/shakilbd009/job-hunt-platform/internal/jsonpatch"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+const (
+	mergePatchType = "application/merge-patch+json"
+	jsonPatchType  = "application/json-patch+json"
+)
+
+// patchError is returned from inside a patch to pick the response status.
+type patchError struct {
+	status  int
+	message string
+}
+
+func (e *patchError) Error() string { return e.message }
+
+// PatchApplication edits an application with a JSON Merge Patch (RFC 7396)
+// or a JSON Patch (RFC 6902), chosen by Content-Type. The patch is applied
+// to the application's JSON form inside the update's transaction, and the
+// result must still be a valid application; read-only fields cannot change.
+// A failed test operation is a 409, a patch that does not fit the document a
+// 422. If-Match, status_note and override_transition (the last two as query
+// params) work as they do for PUT.
+func (h *Handler) PatchApplication(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid application ID format")
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != mergePatchType && mediaType != jsonPatchType {
+		w.Header().Set("Accept-Patch", mergePatchType+", "+jsonPatchType)
+		respondError(w, http.StatusUnsupportedMediaType, "Content-Type must be "+mergePatchType+" or "+jsonPatchType)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondError(w, http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+		respondError(w, http.StatusBadRequest, "failed to read body")
+		return
+	}
+
+	var apply func(doc any) (any, error)
+	if mediaType == mergePatchType {
+		var patch any
+		if err := json.Unmarshal(body, &patch); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid JSON body")
+			return
+		}
+		apply = func(doc any) (any, error) { return jsonpatch.MergePatch(doc, patch), nil }
+	} else {
+		var ops []jsonpatch.Operation
+		if err := json.Unmarshal(body, &ops); err != nil {
+			respondError(w, http.StatusBadRequest, "JSON Patch must be an array of operations")
+			return
+		}
+		apply = func(doc any) (any, error) { return jsonpatch.Apply(doc, ops) }
+	}
+
+	opts := db.UpdateOptions{
+		IfVersions: ifMatchVersions(r.Header.Get("If-Match")),
+		StatusNote: r.URL.Query().Get("status_note"),
+	}
+	if v := r.URL.Query().Get("override_transition"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "override_transition must be true or false")
+			return
+		}
+		opts.OverrideTransition = b
+	}
+
+	pipeline, err := h.store.Pipeline(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to load stages")
+		return
+	}
+
+	app, err := h.store.PatchWithOptions(r.Context(), id, func(existing model.Application) (model.Application, error) {
+		return patchApplication(existing, apply, pipeline)
+	}, opts)
+	var pe *patchError
+	if errors.As(err, &pe) {
+		respondError(w, pe.status, pe.message)
+		return
+	}
+	if h.respondUpdateError(w, r, id, err) {
+		return
+	}
+	if app == nil {
+		respondError(w, http.StatusNotFound, "application not found")
+		return
+	}
+
+	respondApplication(w, http.StatusOK, app)
+}
+
+// patchApplication runs apply on app's JSON form and decodes and validates
+// the result. PUT uses it too, with apply setting the request's fields.
+func patchApplication(app model.Application, apply func(doc any) (any, error), pipeline model.Pipeline) (model.Application, error) {
+	encoded, err := json.Marshal(app)
+	if err != nil {
+		return model.Application{}, err
+	}
+	var doc any
+	if err := json.Unmarshal(encoded, &doc); err != nil {
+		return model.Application{}, err
+	}
+
+	doc, err = apply(doc)
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return model.Application{}, &patchError{http.StatusConflict, err.Error()}
+	}
+	if err != nil {
+		return model.Application{}, &patchError{http.StatusUnprocessableEntity, err.Error()}
+	}
+
+	encoded, err = json.Marshal(doc)
+	if err != nil {
+		return model.Application{}, err
+	}
+	// Decoding into the typed struct is what type-checks each field.
+	var patched model.Application
+	dec := json.NewDecoder(bytes.NewReader(encoded))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&patched); err != nil {
+		return model.Application{}, &patchError{http.StatusBadRequest, describeDecodeError(err)}
+	}
+
+	for field, unchanged := range map[string]bool{
+		"id":         patched.ID == app.ID,
+		"created_at": patched.CreatedAt == app.CreatedAt,
+		"updated_at": patched.UpdatedAt == app.UpdatedAt,
+		"version":    patched.Version == app.Version,
+		"stale_at":   patched.StaleAt == app.StaleAt,
+		"deleted_at": patched.DeletedAt == app.DeletedAt,
+		"snippet":    patched.Snippet == app.Snippet,
+	} {
+		if !unchanged {
+			return model.Application{}, &patchError{http.StatusBadRequest, field + " is read-only"}
+		}
+	}
+	if patched.Status == "" {
+		return model.Application{}, &patchError{http.StatusBadRequest, "status is required"}
+	}
+
+	// An unset salary is stored as 0, which must not count against the
+	// other bound.
+	req := model.CreateRequest{
+		Company:   patched.Company,
+		Role:      patched.Role,
+		URL:       patched.URL,
+		Location:  patched.Location,
+		Status:    patched.Status,
+		Notes:     patched.Notes,
+		AppliedAt: patched.AppliedAt,
+		Tags:      patched.Tags,
+	}
+	if patched.SalaryMin != 0 {
+		req.SalaryMin = &patched.SalaryMin
+	}
+	if patched.SalaryMax != 0 {
+		req.SalaryMax = &patched.SalaryMax
+	}
+	if err := req.Validate(pipeline); err != nil {
+		return model.Application{}, &patchError{http.StatusBadRequest, err.Error()}
+	}
+	return patched, nil
+}
+
+// describeDecodeError words a typed decode failure in terms of the field.
+func describeDecodeError(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return fmt.Sprintf("%s must be %s, not %s", typeErr.Field, jsonTypeName(typeErr.Type.String()), typeErr.Value)
+	}
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return "unknown field " + field
+	}
+	return "patched application is not valid: " + err.Error()
+}
+
+// jsonTypeName names a Go type the way a JSON client would.
+func jsonTypeName(goType string) string {
+	switch goType {
+	case "string":
+		return "a string"
+	case "int":
+		return "an integer"
+	case "[]string":
+		return "an array of strings"
+	}
+	return "a " + goType
+}
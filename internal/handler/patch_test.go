@@ -0,0 +1,166 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func doPatch(r chi.Router, path, contentType, body, ifMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPatch, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", contentType)
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestPatchApplication_MergePatch(t *testing.T) {
+	_, r := setupTest(t)
+	w := doRequest(r, http.MethodPost, "/applications",
+		`{"company":"Acme","role":"Eng","url":"https://acme.example/jobs/1","salary_min":100,"salary_max":150,"tags":["remote","go"]}`)
+	var app model.Application
+	json.NewDecoder(w.Body).Decode(&app)
+	path := "/applications/" + app.ID
+
+	w = doPatch(r, path, "application/merge-patch+json", `{"url":null,"notes":"Recruiter reached out","tags":["go","Backend"]}`, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var patched model.Application
+	json.NewDecoder(w.Body).Decode(&patched)
+	if patched.URL != "" || patched.Notes != "Recruiter reached out" || patched.Company != "Acme" || patched.SalaryMax != 150 {
+		t.Fatalf("unexpected result: %+v", patched)
+	}
+	if strings.Join(patched.Tags, ",") != "backend,go" {
+		t.Fatalf("expected tags backend,go, got %v", patched.Tags)
+	}
+	if patched.Version != 2 || w.Header().Get("ETag") != `"2"` {
+		t.Fatalf("expected version 2, got %d (ETag %q)", patched.Version, w.Header().Get("ETag"))
+	}
+
+	// Nulling the upper bound leaves the lower one on its own.
+	if w := doPatch(r, path, "application/merge-patch+json", `{"salary_max":null}`, ""); w.Code != http.StatusOK {
+		t.Fatalf("expected clearing salary_max to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for _, tc := range []struct {
+		name string
+		body string
+		want int
+	}{
+		{"wrong type", `{"salary_min":"lots"}`, http.StatusBadRequest},
+		{"unknown field", `{"colour":"blue"}`, http.StatusBadRequest},
+		{"read-only field", `{"version":9}`, http.StatusBadRequest},
+		{"required field removed", `{"company":null}`, http.StatusBadRequest},
+		{"status removed", `{"status":null}`, http.StatusBadRequest},
+		{"unknown status", `{"status":"hired"}`, http.StatusBadRequest},
+		{"bad tag", `{"tags":["no spaces"]}`, http.StatusBadRequest},
+		{"salary range", `{"salary_min":500,"salary_max":400}`, http.StatusBadRequest},
+		{"not an object", `["company"]`, http.StatusBadRequest},
+		{"invalid JSON", `{"company":`, http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if w := doPatch(r, path, "application/merge-patch+json", tc.body, ""); w.Code != tc.want {
+				t.Fatalf("expected %d, got %d: %s", tc.want, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	got := doRequest(r, http.MethodGet, path, "")
+	var after model.Application
+	json.NewDecoder(got.Body).Decode(&after)
+	if after.Version != 3 || after.Company != "Acme" || after.SalaryMin != 100 {
+		t.Fatalf("rejected patches must not change the application, got %+v", after)
+	}
+}
+
+func TestPatchApplication_JSONPatch(t *testing.T) {
+	_, r := setupTest(t)
+	w := doRequest(r, http.MethodPost, "/applications", `{"company":"Acme","role":"Eng","status":"applied","tags":["go"]}`)
+	var app model.Application
+	json.NewDecoder(w.Body).Decode(&app)
+	path := "/applications/" + app.ID
+
+	// A test on the version makes the whole patch conditional.
+	w = doPatch(r, path, "application/json-patch+json", `[
+		{"op":"test","path":"/version","value":1},
+		{"op":"replace","path":"/status","value":"interview"},
+		{"op":"add","path":"/tags/-","value":"remote"},
+		{"op":"copy","from":"/company","path":"/notes"}
+	]`, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var patched model.Application
+	json.NewDecoder(w.Body).Decode(&patched)
+	if patched.Status != "interview" || patched.Notes != "Acme" || strings.Join(patched.Tags, ",") != "go,remote" {
+		t.Fatalf("unexpected result: %+v", patched)
+	}
+
+	var history []model.StatusChange
+	json.NewDecoder(doRequest(r, http.MethodGet, path+"/history", "").Body).Decode(&history)
+	if len(history) == 0 || history[len(history)-1].ToStatus != "interview" {
+		t.Fatalf("expected the status change in the history, got %+v", history)
+	}
+
+	for _, tc := range []struct {
+		name string
+		body string
+		want int
+	}{
+		{"stale test", `[{"op":"test","path":"/version","value":1},{"op":"replace","path":"/notes","value":"x"}]`, http.StatusConflict},
+		{"missing path", `[{"op":"replace","path":"/nope","value":"x"}]`, http.StatusUnprocessableEntity},
+		{"unknown op", `[{"op":"frobnicate","path":"/notes"}]`, http.StatusUnprocessableEntity},
+		{"wrong type", `[{"op":"replace","path":"/tags","value":"go"}]`, http.StatusBadRequest},
+		{"read-only field", `[{"op":"replace","path":"/id","value":"x"}]`, http.StatusBadRequest},
+		{"not an array", `{"op":"remove","path":"/notes"}`, http.StatusBadRequest},
+		{"disallowed transition", `[{"op":"replace","path":"/status","value":"applied"}]`, http.StatusConflict},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if w := doPatch(r, path, "application/json-patch+json", tc.body, ""); w.Code != tc.want {
+				t.Fatalf("expected %d, got %d: %s", tc.want, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	// The first operations of a failed patch are not kept.
+	w = doPatch(r, path, "application/json-patch+json", `[{"op":"replace","path":"/notes","value":"half"},{"op":"remove","path":"/nope"}]`, "")
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", w.Code)
+	}
+	json.NewDecoder(doRequest(r, http.MethodGet, path, "").Body).Decode(&patched)
+	if patched.Notes != "Acme" || patched.Version != 2 {
+		t.Fatalf("expected a failed patch to change nothing, got %+v", patched)
+	}
+}
+
+func TestPatchApplication_Preconditions(t *testing.T) {
+	_, r := setupTest(t)
+	w := doRequest(r, http.MethodPost, "/applications", `{"company":"Acme","role":"Eng"}`)
+	var app model.Application
+	json.NewDecoder(w.Body).Decode(&app)
+	path := "/applications/" + app.ID
+
+	if w := doPatch(r, path, "application/json", `{"notes":"x"}`, ""); w.Code != http.StatusUnsupportedMediaType || w.Header().Get("Accept-Patch") == "" {
+		t.Fatalf("expected 415 with Accept-Patch, got %d %q", w.Code, w.Header().Get("Accept-Patch"))
+	}
+	if w := doPatch(r, path, "application/merge-patch+json", `{"notes":"x"}`, `"7"`); w.Code != http.StatusPreconditionFailed || w.Header().Get("ETag") != `"1"` {
+		t.Fatalf("expected 412 with the current ETag, got %d %q", w.Code, w.Header().Get("ETag"))
+	}
+	if w := doPatch(r, path, "application/merge-patch+json; charset=utf-8", `{"notes":"x"}`, `"1"`); w.Code != http.StatusOK {
+		t.Fatalf("expected a matching If-Match to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doPatch(r, "/applications/deadbeef", "application/merge-patch+json", `{"notes":"x"}`, ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+}
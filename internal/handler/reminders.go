@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func (h *Handler) ListReminders(w http.ResponseWriter, r *http.Request) {
+	app, ok := h.applicationFromPath(w, r)
+	if !ok {
+		return
+	}
+	reminders, err := h.store.ListReminders(r.Context(), app.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list reminders")
+		return
+	}
+	respondJSON(w, http.StatusOK, reminders)
+}
+
+func (h *Handler) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	app, ok := h.applicationFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.ReminderRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	dueAt, err := req.Normalize()
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reminder, err := h.store.CreateReminder(r.Context(), app.ID, dueAt, req.Message)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to create reminder")
+		return
+	}
+	respondJSON(w, http.StatusCreated, reminder)
+}
+
+// ListDueReminders returns open reminders due now, or at or before the
+// RFC3339 or YYYY-MM-DD before param (a date includes the whole day).
+func (h *Handler) ListDueReminders(w http.ResponseWriter, r *http.Request) {
+	before := time.Now().UTC().Format(time.RFC3339)
+	if v := r.URL.Query().Get("before"); v != "" {
+		t, err := parseAgendaBound(v, true)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "before "+err.Error())
+			return
+		}
+		before = t
+	}
+
+	due, err := h.store.DueReminders(r.Context(), before)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list reminders")
+		return
+	}
+	respondJSON(w, http.StatusOK, due)
+}
+
+// CompleteReminder marks a reminder done. It is idempotent.
+func (h *Handler) CompleteReminder(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid reminder ID format")
+		return
+	}
+	reminder, err := h.store.CompleteReminder(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to complete reminder")
+		return
+	}
+	if reminder == nil {
+		respondError(w, http.StatusNotFound, "reminder not found")
+		return
+	}
+	respondJSON(w, http.StatusOK, reminder)
+}
+
+func (h *Handler) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid reminder ID format")
+		return
+	}
+	deleted, err := h.store.DeleteReminder(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to delete reminder")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "reminder not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
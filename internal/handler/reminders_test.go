@@ -0,0 +1,78 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestReminders_DueAndComplete(t *testing.T) {
+	_, r := setupTest(t)
+	app := createApp(t, r, `{"company":"Acme","role":"Backend Engineer","status":"applied"}`)
+
+	w := doRequest(r, http.MethodGet, "/applications/"+app.ID+"/reminders", "")
+	var reminders []model.Reminder
+	json.NewDecoder(w.Body).Decode(&reminders)
+	if len(reminders) != 1 || reminders[0].Kind != "follow_up" {
+		t.Fatalf("expected automatic follow-up, got %+v", reminders)
+	}
+
+	w = doRequest(r, http.MethodPost, "/applications/"+app.ID+"/reminders", `{"due_at":"2026-01-15","message":"Ping recruiter on LinkedIn"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var custom model.Reminder
+	json.NewDecoder(w.Body).Decode(&custom)
+	if custom.DueAt != "2026-01-15T00:00:00Z" || custom.Kind != "custom" {
+		t.Fatalf("unexpected reminder %+v", custom)
+	}
+	for _, body := range []string{`{"due_at":"2026-01-15"}`, `{"message":"x"}`, `{"due_at":"soon","message":"x"}`} {
+		if w := doRequest(r, http.MethodPost, "/applications/"+app.ID+"/reminders", body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, w.Code)
+		}
+	}
+
+	w = doRequest(r, http.MethodGet, "/reminders/due", "")
+	var due []model.DueReminder
+	json.NewDecoder(w.Body).Decode(&due)
+	if len(due) != 1 || due[0].ID != custom.ID || due[0].Company != "Acme" {
+		t.Fatalf("expected only the overdue custom reminder, got %+v", due)
+	}
+	w = doRequest(r, http.MethodGet, "/reminders/due?before=2099-12-31", "")
+	json.NewDecoder(w.Body).Decode(&due)
+	if len(due) != 2 {
+		t.Fatalf("expected both reminders due by 2099, got %+v", due)
+	}
+	if w := doRequest(r, http.MethodGet, "/reminders/due?before=tomorrow", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for bad before, got %d", w.Code)
+	}
+
+	// Complete is a bodiless POST with no Content-Type.
+	req := httptest.NewRequest(http.MethodPost, "/reminders/"+custom.ID+"/complete", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var completed model.Reminder
+	json.NewDecoder(w.Body).Decode(&completed)
+	if !completed.Done || completed.CompletedAt == "" {
+		t.Fatalf("expected completed reminder, got %+v", completed)
+	}
+
+	w = doRequest(r, http.MethodGet, "/reminders/due", "")
+	json.NewDecoder(w.Body).Decode(&due)
+	if len(due) != 0 {
+		t.Fatalf("expected nothing due after completing, got %+v", due)
+	}
+
+	if w := doRequest(r, http.MethodPost, "/reminders/deadbeef/complete", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodDelete, "/reminders/"+custom.ID, ""); w.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", w.Code)
+	}
+}
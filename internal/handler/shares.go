@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// SharedInfo describes the share a public response was served for.
+type SharedInfo struct {
+	Kind      string   `json:"kind"`
+	ExpiresAt string   `json:"expires_at"`
+	Private   []string `json:"private"`
+}
+
+type SharedApplicationResponse struct {
+	SharedInfo
+	Application map[string]any `json:"application"`
+}
+
+type SharedViewResponse struct {
+	SharedInfo
+	Data       []map[string]any `json:"data"`
+	Pagination PaginationMeta   `json:"pagination"`
+}
+
+func (h *Handler) CreateShare(w http.ResponseWriter, r *http.Request) {
+	var req model.ShareRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	kind, err := req.Normalize()
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if kind == model.ShareApplication {
+		if !isValidID(req.ApplicationID) {
+			respondError(w, http.StatusBadRequest, "invalid application ID format")
+			return
+		}
+		app, err := h.store.Get(r.Context(), req.ApplicationID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "failed to get application")
+			return
+		}
+		if app == nil {
+			respondError(w, http.StatusNotFound, "application not found")
+			return
+		}
+	} else {
+		query, err := url.ParseQuery(strings.TrimPrefix(req.Query, "?"))
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "query must be URL query parameters, e.g. status=applied&tag=remote")
+			return
+		}
+		// Whoever opens the link pages through the view themselves.
+		query.Del("limit")
+		query.Del("offset")
+		if _, ok := h.parseListQuery(w, r, query); !ok {
+			return
+		}
+		req.Query = query.Encode()
+	}
+
+	share, err := h.store.CreateShare(r.Context(), kind, req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to create share")
+		return
+	}
+	respondJSON(w, http.StatusCreated, share)
+}
+
+func (h *Handler) ListShares(w http.ResponseWriter, r *http.Request) {
+	shares, err := h.store.ListShares(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list shares")
+		return
+	}
+	respondJSON(w, http.StatusOK, shares)
+}
+
+func (h *Handler) DeleteShare(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid share ID format")
+		return
+	}
+	deleted, err := h.store.DeleteShare(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to delete share")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "share not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetShared serves a share to anyone holding its token: the application, or
+// a page of the saved view (limit and offset may be given; the filter is
+// fixed). Private fields are left out.
+func (h *Handler) GetShared(w http.ResponseWriter, r *http.Request) {
+	share, err := h.store.ResolveShare(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to check share link")
+		return
+	}
+	if share == nil {
+		respondError(w, http.StatusNotFound, "share link is invalid or has expired")
+		return
+	}
+	r = r.WithContext(db.WithOwner(r.Context(), share.OwnerID))
+	info := SharedInfo{Kind: share.Kind, ExpiresAt: share.ExpiresAt, Private: share.Private}
+
+	if share.Kind == model.ShareApplication {
+		app, err := h.store.Get(r.Context(), share.ApplicationID)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "failed to get application")
+			return
+		}
+		if app == nil {
+			respondError(w, http.StatusNotFound, "application not found")
+			return
+		}
+		respondJSON(w, http.StatusOK, SharedApplicationResponse{SharedInfo: info, Application: redact(*app, share.Private)})
+		return
+	}
+
+	query, err := url.ParseQuery(share.Query)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to read saved view")
+		return
+	}
+	for _, param := range []string{"limit", "offset"} {
+		if v := r.URL.Query().Get(param); v != "" {
+			query.Set(param, v)
+		}
+	}
+	opts, ok := h.parseListQuery(w, r, query)
+	if !ok {
+		return
+	}
+	page, ok := h.listPage(w, r, opts)
+	if !ok {
+		return
+	}
+	// Viewers can only page by offset, and a cursor would carry the sort
+	// value of the last row even when that field is private.
+	page.Pagination.NextCursor = ""
+	data := make([]map[string]any, len(page.Data))
+	for i, app := range page.Data {
+		data[i] = redact(app, share.Private)
+	}
+	respondJSON(w, http.StatusOK, SharedViewResponse{SharedInfo: info, Data: data, Pagination: page.Pagination})
+}
+
+// redact returns app's JSON form without the keys of the private fields.
+func redact(app model.Application, private []string) map[string]any {
+	var fields map[string]any
+	b, _ := json.Marshal(app)
+	json.Unmarshal(b, &fields)
+	for _, f := range private {
+		for _, key := range model.PrivateFields[f] {
+			delete(fields, key)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,161 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/handler"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func createShare(t *testing.T, r chi.Router, body, token string) model.Share {
+	t.Helper()
+	w := doAuthRequest(r, http.MethodPost, "/shares", body, token)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create share: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var s model.Share
+	json.NewDecoder(w.Body).Decode(&s)
+	return s
+}
+
+func TestShareApplication(t *testing.T) {
+	_, r := setupTest(t)
+	app := createApp(t, r, `{"company":"Acme","role":"Eng","url":"https://acme.example/jobs/1","salary_min":100000,"notes":"ask about equity","tags":["remote"]}`)
+
+	for _, body := range []string{
+		`{"application_id":"nope"}`,
+		`{"application_id":"` + app.ID + `","expires_in_days":400}`,
+		`{"application_id":"` + app.ID + `","private":["ssn"]}`,
+		`{"application_id":"` + app.ID + `","query":"status=applied"}`,
+	} {
+		if w := doRequest(r, http.MethodPost, "/shares", body); w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", body, w.Code)
+		}
+	}
+	big := `{"application_id":"` + app.ID + `","private":["` + strings.Repeat("x", 2*1024*1024) + `"]}`
+	if w := doRequest(r, http.MethodPost, "/shares", big); w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 for an oversized body, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodPost, "/shares", `{"application_id":"abcd1234"}`); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown application, got %d", w.Code)
+	}
+
+	share := createShare(t, r, `{"application_id":"`+app.ID+`"}`, "")
+	if share.Kind != model.ShareApplication || strings.Join(share.Private, ",") != "notes,salary" || share.Token == "" {
+		t.Fatalf("expected an application share hiding salary and notes by default, got %+v", share)
+	}
+
+	w := doRequest(r, http.MethodGet, "/shared/"+share.Token, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var got handler.SharedApplicationResponse
+	json.NewDecoder(w.Body).Decode(&got)
+	if got.Application["company"] != "Acme" || got.Application["url"] == nil || got.ExpiresAt != share.ExpiresAt {
+		t.Fatalf("expected the application shared, got %+v", got)
+	}
+	for _, key := range []string{"salary_min", "salary_max", "notes"} {
+		if _, ok := got.Application[key]; ok {
+			t.Fatalf("expected %s redacted, got %+v", key, got.Application)
+		}
+	}
+
+	// The listing never repeats the token.
+	w = doRequest(r, http.MethodGet, "/shares", "")
+	var shares []model.Share
+	json.NewDecoder(w.Body).Decode(&shares)
+	if len(shares) != 1 || shares[0].Token != "" {
+		t.Fatalf("expected one share without its token, got %+v", shares)
+	}
+
+	// Trashing the application hides it; deleting the share kills the link.
+	doRequest(r, http.MethodDelete, "/applications/"+app.ID, "")
+	if w := doRequest(r, http.MethodGet, "/shared/"+share.Token, ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected a trashed application hidden, got %d", w.Code)
+	}
+	doRequest(r, http.MethodPost, "/applications/"+app.ID+"/restore", "")
+	if w := doRequest(r, http.MethodDelete, "/shares/"+share.ID, ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected delete 204, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodGet, "/shared/"+share.Token, ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected a deleted share's link dead, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodDelete, "/shares/"+share.ID, ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 deleting twice, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodGet, "/shared/jhr_abcd1234.forged", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected a forged token refused, got %d", w.Code)
+	}
+}
+
+func TestShareView(t *testing.T) {
+	_, r := setupTest(t)
+	createApp(t, r, `{"company":"Acme","role":"Eng","status":"applied","salary_min":100000,"notes":"referral from Sam"}`)
+	createApp(t, r, `{"company":"Initech","role":"Eng","status":"applied","tags":["remote"]}`)
+	createApp(t, r, `{"company":"Wishful","role":"Dream Job"}`)
+
+	if w := doRequest(r, http.MethodPost, "/shares", `{"query":"status=nope"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected an invalid saved filter rejected, got %d", w.Code)
+	}
+	share := createShare(t, r, `{"query":"?status=applied&sort_by=company&sort_order=asc&limit=1","private":["notes"]}`, "")
+	if share.Kind != model.ShareView || strings.Contains(share.Query, "limit") {
+		t.Fatalf("expected a view share without paging, got %+v", share)
+	}
+
+	// The filter is fixed: status and sort in the link's URL are ignored.
+	w := doRequest(r, http.MethodGet, "/shared/"+share.Token+"?status=wishlist&sort_order=desc&limit=1", "")
+	var got handler.SharedViewResponse
+	json.NewDecoder(w.Body).Decode(&got)
+	if w.Code != http.StatusOK || len(got.Data) != 1 || got.Data[0]["company"] != "Acme" || got.Pagination.Total != 2 || !got.Pagination.HasMore || got.Pagination.NextCursor != "" {
+		t.Fatalf("expected the first of two applied applications, got %d %+v", w.Code, got)
+	}
+	if _, ok := got.Data[0]["notes"]; ok || got.Data[0]["salary_min"] != float64(100000) {
+		t.Fatalf("expected only notes redacted, got %+v", got.Data[0])
+	}
+	w = doRequest(r, http.MethodGet, "/shared/"+share.Token+"?offset=1", "")
+	json.NewDecoder(w.Body).Decode(&got)
+	if len(got.Data) != 1 || got.Data[0]["company"] != "Initech" {
+		t.Fatalf("expected to page through the view, got %+v", got.Data)
+	}
+
+	// Search snippets quote notes, so they are hidden with them.
+	search := createShare(t, r, `{"query":"q=referral"}`, "")
+	w = doRequest(r, http.MethodGet, "/shared/"+search.Token, "")
+	if body := w.Body.String(); strings.Contains(body, "Sam") {
+		t.Fatalf("expected notes kept out of snippets, got %s", body)
+	}
+}
+
+func TestShareAccess(t *testing.T) {
+	r, store := setupUsersTest(t)
+	ann := login(t, r, "ann@example.com")
+	bob := login(t, r, "bob@example.com")
+	doAuthRequest(r, http.MethodPost, "/applications", `{"company":"Acme","role":"Eng"}`, ann.Token)
+	doAuthRequest(r, http.MethodPost, "/applications", `{"company":"Globex","role":"Eng"}`, bob.Token)
+
+	// A view share shows only its owner's applications, to anyone.
+	share := createShare(t, r, `{"query":""}`, ann.Token)
+	w := doAuthRequest(r, http.MethodGet, "/shared/"+share.Token, "", "")
+	if body := w.Body.String(); w.Code != http.StatusOK || !strings.Contains(body, "Acme") || strings.Contains(body, "Globex") {
+		t.Fatalf("expected ann's applications only, got %d %s", w.Code, body)
+	}
+
+	// A share token is not a credential for the rest of the API.
+	if w := doAuthRequest(r, http.MethodGet, "/applications", "", share.Token); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected a share token refused by the API, got %d", w.Code)
+	}
+	if w := doAuthRequest(r, http.MethodDelete, "/shares/"+share.ID, "", bob.Token); w.Code != http.StatusNotFound {
+		t.Fatalf("expected bob unable to delete ann's share, got %d", w.Code)
+	}
+
+	user, _ := store.GetUserByEmail(t.Context(), "ann@example.com")
+	_, readKey, _ := store.CreateAPIKey(t.Context(), user.ID, "dashboard", model.ScopeRead)
+	if w := doAuthRequest(r, http.MethodPost, "/shares", `{"query":""}`, readKey); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a read-only key refused, got %d", w.Code)
+	}
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func (h *Handler) ListStages(w http.ResponseWriter, r *http.Request) {
+	pipeline, err := h.store.Pipeline(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list stages")
+		return
+	}
+	if pipeline == nil {
+		pipeline = model.Pipeline{}
+	}
+	respondJSON(w, http.StatusOK, pipeline)
+}
+
+func (h *Handler) GetStage(w http.ResponseWriter, r *http.Request) {
+	stage, err := h.store.GetStage(r.Context(), chi.URLParam(r, "name"))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to get stage")
+		return
+	}
+	if stage == nil {
+		respondError(w, http.StatusNotFound, "stage not found")
+		return
+	}
+	respondJSON(w, http.StatusOK, stage)
+}
+
+func (h *Handler) CreateStage(w http.ResponseWriter, r *http.Request) {
+	var req model.StageRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	pipeline, err := h.store.Pipeline(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to load stages")
+		return
+	}
+	if err := req.Validate(pipeline, true); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stage, err := h.store.CreateStage(r.Context(), req)
+	if errors.Is(err, db.ErrStageExists) {
+		respondError(w, http.StatusConflict, "stage already exists")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to create stage")
+		return
+	}
+	respondJSON(w, http.StatusCreated, stage)
+}
+
+func (h *Handler) UpdateStage(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+
+	var req model.StageRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Name != "" && req.Name != name {
+		respondError(w, http.StatusBadRequest, "stage name cannot be changed")
+		return
+	}
+	req.Name = name
+
+	pipeline, err := h.store.Pipeline(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to load stages")
+		return
+	}
+	if err := req.Validate(pipeline, false); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stage, err := h.store.UpdateStage(r.Context(), name, req)
+	if errors.Is(err, db.ErrLastActiveStage) {
+		respondError(w, http.StatusConflict, "cannot make the last non-terminal stage terminal; new applications need one to start in")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to update stage")
+		return
+	}
+	if stage == nil {
+		respondError(w, http.StatusNotFound, "stage not found")
+		return
+	}
+	respondJSON(w, http.StatusOK, stage)
+}
+
+func (h *Handler) DeleteStage(w http.ResponseWriter, r *http.Request) {
+	deleted, err := h.store.DeleteStage(r.Context(), chi.URLParam(r, "name"))
+	if errors.Is(err, db.ErrStageInUse) {
+		respondError(w, http.StatusConflict, "stage is in use by applications, including any in the trash; move them to another stage or purge them first")
+		return
+	}
+	if errors.Is(err, db.ErrLastActiveStage) {
+		respondError(w, http.StatusConflict, "cannot delete the last non-terminal stage; new applications need one to start in")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to delete stage")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "stage not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func (h *Handler) AddApplicationTags(w http.ResponseWriter, r *http.Request) {
+	h.changeApplicationTags(w, r, true)
+}
+
+func (h *Handler) RemoveApplicationTags(w http.ResponseWriter, r *http.Request) {
+	h.changeApplicationTags(w, r, false)
+}
+
+// changeApplicationTags adds or removes the tags in the request body and
+// responds with the application's resulting tags.
+func (h *Handler) changeApplicationTags(w http.ResponseWriter, r *http.Request, add bool) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid application ID format")
+		return
+	}
+
+	var req model.TagsRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	tags, err := model.NormalizeTags(req.Tags)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(tags) == 0 {
+		respondError(w, http.StatusBadRequest, "tags is required")
+		return
+	}
+
+	var current []string
+	if add {
+		current, err = h.store.AddTags(r.Context(), id, tags)
+	} else {
+		current, err = h.store.RemoveTags(r.Context(), id, tags)
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to update tags")
+		return
+	}
+	if current == nil {
+		respondError(w, http.StatusNotFound, "application not found")
+		return
+	}
+	respondJSON(w, http.StatusOK, model.TagsRequest{Tags: current})
+}
+
+func (h *Handler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.store.ListTags(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to list tags")
+		return
+	}
+	respondJSON(w, http.StatusOK, tags)
+}
+
+func (h *Handler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	tags, err := model.NormalizeTags([]string{chi.URLParam(r, "name")})
+	if err != nil || len(tags) == 0 {
+		respondError(w, http.StatusBadRequest, "invalid tag name")
+		return
+	}
+	deleted, err := h.store.DeleteTag(r.Context(), tags[0])
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to delete tag")
+		return
+	}
+	if !deleted {
+		respondError(w, http.StatusNotFound, "tag not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
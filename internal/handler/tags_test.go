@@ -0,0 +1,79 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/handler"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestApplicationTags(t *testing.T) {
+	_, r := setupTest(t)
+	app := createApp(t, r, `{"company":"Acme","role":"Eng","tags":["Referral"]}`)
+	if len(app.Tags) != 1 || app.Tags[0] != "referral" {
+		t.Fatalf("expected normalized tag on create, got %v", app.Tags)
+	}
+	other := createApp(t, r, `{"company":"Beta","role":"Eng"}`)
+
+	w := doRequest(r, http.MethodPost, "/applications/"+app.ID+"/tags", `{"tags":["visa-sponsor","referral"]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var body model.TagsRequest
+	json.NewDecoder(w.Body).Decode(&body)
+	if strings.Join(body.Tags, ",") != "referral,visa-sponsor" {
+		t.Fatalf("unexpected tags: %v", body.Tags)
+	}
+
+	w = doRequest(r, http.MethodPost, "/applications/"+app.ID+"/tags", `{"tags":["not valid"]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid tag, got %d", w.Code)
+	}
+	w = doRequest(r, http.MethodPost, "/applications/deadbeef/tags", `{"tags":["x"]}`)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+
+	w = doRequest(r, http.MethodGet, "/applications?tag=referral,visa-sponsor", "")
+	var list handler.PaginatedResponse
+	json.NewDecoder(w.Body).Decode(&list)
+	if list.Pagination.Total != 1 || list.Data[0].ID != app.ID {
+		t.Fatalf("expected only tagged application, got %+v", list)
+	}
+	w = doRequest(r, http.MethodGet, "/applications?tag_none=referral", "")
+	list = handler.PaginatedResponse{}
+	json.NewDecoder(w.Body).Decode(&list)
+	if list.Pagination.Total != 1 || list.Data[0].ID != other.ID {
+		t.Fatalf("expected only untagged application, got %+v", list)
+	}
+	w = doRequest(r, http.MethodGet, "/applications?tag_any=Bad!", "")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "tag_any") {
+		t.Fatalf("expected 400 naming tag_any, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(r, http.MethodDelete, "/applications/"+app.ID+"/tags", `{"tags":["referral"]}`)
+	body = model.TagsRequest{}
+	json.NewDecoder(w.Body).Decode(&body)
+	if w.Code != http.StatusOK || strings.Join(body.Tags, ",") != "visa-sponsor" {
+		t.Fatalf("expected [visa-sponsor], got %d: %v", w.Code, body.Tags)
+	}
+
+	w = doRequest(r, http.MethodGet, "/tags", "")
+	var counts []model.TagCount
+	json.NewDecoder(w.Body).Decode(&counts)
+	if len(counts) != 2 || counts[0] != (model.TagCount{Name: "visa-sponsor", Count: 1}) {
+		t.Fatalf("unexpected tag counts: %+v", counts)
+	}
+
+	w = doRequest(r, http.MethodDelete, "/tags/visa-sponsor", "")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	w = doRequest(r, http.MethodDelete, "/tags/visa-sponsor", "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ListTrash lists soft-deleted applications, most recently deleted first
+// unless sort_by is given. It accepts the same params as GET /applications.
+func (h *Handler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	opts, ok := h.listOptions(w, r)
+	if !ok {
+		return
+	}
+	opts.Trashed = true
+	if r.URL.Query().Get("sort_by") == "" {
+		opts.SortBy = "deleted_at"
+	}
+	h.respondList(w, r, opts)
+}
+
+func (h *Handler) RestoreApplication(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid application ID format")
+		return
+	}
+	app, err := h.store.Restore(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to restore application")
+		return
+	}
+	if app == nil {
+		respondError(w, http.StatusNotFound, "application not found in trash")
+		return
+	}
+	respondJSON(w, http.StatusOK, app)
+}
+
+// PurgeApplication permanently deletes one trashed application.
+func (h *Handler) PurgeApplication(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if !isValidID(id) {
+		respondError(w, http.StatusBadRequest, "invalid application ID format")
+		return
+	}
+	purged, err := h.store.Purge(r.Context(), id)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to purge application")
+		return
+	}
+	if !purged {
+		respondError(w, http.StatusNotFound, "application not found in trash")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EmptyTrash permanently deletes everything in the trash, or with
+// older_than_days only what was trashed at least that many days ago.
+func (h *Handler) EmptyTrash(w http.ResponseWriter, r *http.Request) {
+	before := ""
+	if v := r.URL.Query().Get("older_than_days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			respondError(w, http.StatusBadRequest, "older_than_days must be a non-negative integer")
+			return
+		}
+		before = time.Now().UTC().AddDate(0, 0, -days).Format(time.RFC3339)
+	}
+	n, err := h.store.PurgeTrash(r.Context(), before)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to empty trash")
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]int{"purged": n})
+}
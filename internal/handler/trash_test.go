@@ -0,0 +1,59 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/handler"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestTrash(t *testing.T) {
+	_, r := setupTest(t)
+	app := createApp(t, r, `{"company":"Acme","role":"Eng"}`)
+	createApp(t, r, `{"company":"Beta","role":"Eng"}`)
+
+	if w := doRequest(r, http.MethodDelete, "/applications/"+app.ID, ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodGet, "/applications/"+app.ID, ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected trashed application to 404, got %d", w.Code)
+	}
+
+	w := doRequest(r, http.MethodGet, "/trash", "")
+	var trash handler.PaginatedResponse
+	json.NewDecoder(w.Body).Decode(&trash)
+	if w.Code != http.StatusOK || trash.Pagination.Total != 1 || trash.Data[0].ID != app.ID || trash.Data[0].DeletedAt == "" {
+		t.Fatalf("expected the application in the trash, got %d: %+v", w.Code, trash)
+	}
+
+	w = doRequest(r, http.MethodPost, "/applications/"+app.ID+"/restore", "")
+	var restored model.Application
+	json.NewDecoder(w.Body).Decode(&restored)
+	if w.Code != http.StatusOK || restored.ID != app.ID || restored.DeletedAt != "" {
+		t.Fatalf("expected restore, got %d: %+v", w.Code, restored)
+	}
+	if w := doRequest(r, http.MethodPost, "/applications/"+app.ID+"/restore", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 restoring a live application, got %d", w.Code)
+	}
+
+	if w := doRequest(r, http.MethodDelete, "/trash/"+app.ID, ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected purge of a live application to 404, got %d", w.Code)
+	}
+	doRequest(r, http.MethodDelete, "/applications/"+app.ID, "")
+	if w := doRequest(r, http.MethodDelete, "/trash/"+app.ID, ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	if w := doRequest(r, http.MethodPost, "/applications/"+app.ID+"/restore", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected purged application to be gone, got %d", w.Code)
+	}
+
+	if w := doRequest(r, http.MethodDelete, "/trash?older_than_days=-1", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	w = doRequest(r, http.MethodDelete, "/trash", "")
+	if w.Code != http.StatusOK || w.Body.String() != "{\"purged\":0}\n" {
+		t.Fatalf("expected nothing left to purge, got %d: %s", w.Code, w.Body.String())
+	}
+}
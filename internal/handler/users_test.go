@@ -0,0 +1,336 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+	"github.com/shakilbd009/job-hunt-platform/internal/handler"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+// setupUsersTest returns a router with credentials required and a store
+// holding ann@example.com and bob@example.com, both with password
+// "correct horse".
+func setupUsersTest(t *testing.T) (chi.Router, *db.Store) {
+	t.Helper()
+	store, err := db.NewStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	for _, email := range []string{"ann@example.com", "bob@example.com"} {
+		if _, err := store.CreateUser(t.Context(), email, "correct horse"); err != nil {
+			t.Fatalf("CreateUser failed: %v", err)
+		}
+	}
+
+	h := handler.New(store)
+	r := chi.NewRouter()
+	h.Routes(r)
+	return r, store
+}
+
+func login(t *testing.T, r chi.Router, email string) model.Session {
+	t.Helper()
+	w := doAuthRequest(r, http.MethodPost, "/auth/login", `{"email":"`+email+`","password":"correct horse"}`, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("login: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var s model.Session
+	json.NewDecoder(w.Body).Decode(&s)
+	return s
+}
+
+func TestLoginLogout(t *testing.T) {
+	r, _ := setupUsersTest(t)
+
+	for _, body := range []string{
+		`{"email":"ann@example.com","password":"wrong password"}`,
+		`{"email":"nobody@example.com","password":"correct horse"}`,
+		`{"email":"ann@example.com"}`,
+	} {
+		if w := doAuthRequest(r, http.MethodPost, "/auth/login", body, ""); w.Code != http.StatusUnauthorized {
+			t.Fatalf("%s: expected 401, got %d", body, w.Code)
+		}
+	}
+
+	big := `{"email":"` + strings.Repeat("x", 2*1024*1024) + `"}`
+	if w := doAuthRequest(r, http.MethodPost, "/auth/login", big, ""); w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413 for an oversized login, got %d", w.Code)
+	}
+
+	// Addresses are matched case-insensitively.
+	session := login(t, r, " Ann@Example.com")
+	if session.Token == "" || session.User.Email != "ann@example.com" || session.ExpiresAt == "" {
+		t.Fatalf("unexpected session %+v", session)
+	}
+	w := doAuthRequest(r, http.MethodGet, "/auth/me", "", session.Token)
+	var me model.User
+	json.NewDecoder(w.Body).Decode(&me)
+	if w.Code != http.StatusOK || me.ID != session.User.ID {
+		t.Fatalf("expected /auth/me to return ann, got %d %+v", w.Code, me)
+	}
+
+	// Sessions can write, and the audit log names the user.
+	w = doAuthRequest(r, http.MethodPost, "/applications", `{"company":"Acme","role":"Eng"}`, session.Token)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected a session to create, got %d", w.Code)
+	}
+	w = doAuthRequest(r, http.MethodGet, "/audit", "", session.Token)
+	var entries []model.AuditEntry
+	json.NewDecoder(w.Body).Decode(&entries)
+	if len(entries) != 1 || entries[0].Actor != "ann@example.com" {
+		t.Fatalf("expected the create attributed to ann, got %+v", entries)
+	}
+
+	if w := doAuthRequest(r, http.MethodPost, "/auth/logout", "", session.Token); w.Code != http.StatusNoContent {
+		t.Fatalf("expected logout 204, got %d", w.Code)
+	}
+	if w := doAuthRequest(r, http.MethodGet, "/applications", "", session.Token); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected the logged-out token refused, got %d", w.Code)
+	}
+}
+
+func TestAPIKeyWithoutUserRefused(t *testing.T) {
+	r, store := setupUsersTest(t)
+	_, secret, err := store.CreateAPIKey(t.Context(), "", "orphan", model.ScopeReadWrite)
+	if err != nil {
+		t.Fatalf("CreateAPIKey failed: %v", err)
+	}
+	if w := doAuthRequest(r, http.MethodGet, "/applications", "", secret); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected a key with no user refused, got %d", w.Code)
+	}
+}
+
+// TestUserIsolation fills ann's account with one of everything and checks
+// that bob, with a valid session, can neither see nor change any of it.
+func TestUserIsolation(t *testing.T) {
+	r, store := setupUsersTest(t)
+	ann := login(t, r, "ann@example.com")
+	bob := login(t, r, "bob@example.com")
+
+	w := doAuthRequest(r, http.MethodPost, "/applications",
+		`{"company":"Acme","role":"Eng","status":"applied","salary_min":100000,"tags":["remote"]}`, ann.Token)
+	var app model.Application
+	json.NewDecoder(w.Body).Decode(&app)
+	w = doAuthRequest(r, http.MethodPost, "/applications", `{"company":"Acme Trash","role":"Eng"}`, ann.Token)
+	var trashed model.Application
+	json.NewDecoder(w.Body).Decode(&trashed)
+	doAuthRequest(r, http.MethodDelete, "/applications/"+trashed.ID, "", ann.Token)
+
+	annCtx := db.WithOwner(t.Context(), ann.User.ID)
+	name := "Rita Recruiter"
+	contact, _ := store.CreateContact(annCtx, model.ContactRequest{Name: &name})
+	store.LinkContact(annCtx, app.ID, contact.ID, "recruiter")
+	iv, _ := store.CreateInterview(annCtx, model.Interview{
+		ApplicationID: app.ID, StartsAt: "2030-01-01T10:00:00Z", EndsAt: "2030-01-01T11:00:00Z",
+		Timezone: "UTC", Format: "video", Interviewers: []string{},
+	})
+	reminder, _ := store.CreateReminder(annCtx, app.ID, "2000-01-01T00:00:00Z", "ping Acme")
+	att, _, _ := store.CreateAttachment(annCtx, model.Attachment{
+		ApplicationID: app.ID, Kind: "resume", Filename: "cv.pdf", ContentType: "application/pdf",
+	}, []byte("%PDF ann"))
+	if contact == nil || iv == nil || reminder == nil || att == nil {
+		t.Fatal("failed to create ann's data")
+	}
+	doAuthRequest(r, http.MethodPost, "/stages", `{"name":"take_home","label":"Take Home"}`, ann.Token)
+	w = doAuthRequest(r, http.MethodPost, "/shares", `{"application_id":"`+app.ID+`"}`, ann.Token)
+	var share model.Share
+	json.NewDecoder(w.Body).Decode(&share)
+	if share.ID == "" {
+		t.Fatalf("failed to create ann's share: %d %s", w.Code, w.Body.String())
+	}
+
+	// Bob's own data must not leak to ann either.
+	w = doAuthRequest(r, http.MethodPost, "/applications", `{"company":"Globex","role":"Eng","tags":["onsite"]}`, bob.Token)
+	var bobApp model.Application
+	json.NewDecoder(w.Body).Decode(&bobApp)
+
+	appPath := "/applications/" + app.ID
+	for _, req := range []struct{ method, path, body string }{
+		{http.MethodGet, appPath, ""},
+		{http.MethodPut, appPath, `{"notes":"mine now"}`},
+		{http.MethodDelete, appPath, ""},
+		{http.MethodGet, appPath + "/history", ""},
+		{http.MethodGet, appPath + "/contacts", ""},
+		{http.MethodPost, appPath + "/contacts", `{"contact_id":"` + contact.ID + `","relationship":"referrer"}`},
+		{http.MethodDelete, appPath + "/contacts/" + contact.ID, ""},
+		{http.MethodGet, appPath + "/interviews", ""},
+		{http.MethodPost, appPath + "/interviews", `{"starts_at":"2030-02-01T10:00:00Z","ends_at":"2030-02-01T11:00:00Z"}`},
+		{http.MethodGet, appPath + "/interviews/" + iv.ID, ""},
+		{http.MethodPut, appPath + "/interviews/" + iv.ID, `{"outcome":"passed"}`},
+		{http.MethodDelete, appPath + "/interviews/" + iv.ID, ""},
+		{http.MethodGet, appPath + "/reminders", ""},
+		{http.MethodPost, appPath + "/reminders", `{"due_at":"2030-01-01T00:00:00Z","message":"x"}`},
+		{http.MethodGet, appPath + "/attachments", ""},
+		{http.MethodGet, appPath + "/attachments/" + att.ID, ""},
+		{http.MethodDelete, appPath + "/attachments/" + att.ID, ""},
+		{http.MethodPost, appPath + "/tags", `{"tags":["mine"]}`},
+		{http.MethodDelete, appPath + "/tags", `{"tags":["remote"]}`},
+		{http.MethodPost, "/applications/" + trashed.ID + "/restore", ""},
+		{http.MethodDelete, "/trash/" + trashed.ID, ""},
+		{http.MethodGet, "/contacts/" + contact.ID, ""},
+		{http.MethodPut, "/contacts/" + contact.ID, `{"name":"Bob's now"}`},
+		{http.MethodDelete, "/contacts/" + contact.ID, ""},
+		{http.MethodGet, "/contacts/" + contact.ID + "/applications", ""},
+		{http.MethodPost, "/reminders/" + reminder.ID + "/complete", ""},
+		{http.MethodDelete, "/reminders/" + reminder.ID, ""},
+		{http.MethodDelete, "/tags/remote", ""},
+		{http.MethodPost, appPath + "/merge", `{"source_id":"` + bobApp.ID + `"}`},
+		{http.MethodPost, "/applications/" + bobApp.ID + "/merge", `{"source_id":"` + app.ID + `"}`},
+		{http.MethodPost, "/applications/" + bobApp.ID + "/merge", `{"source_id":"` + trashed.ID + `"}`},
+		{http.MethodPost, "/shares", `{"application_id":"` + app.ID + `"}`},
+		{http.MethodDelete, "/shares/" + share.ID, ""},
+		{http.MethodGet, "/stages/take_home", ""},
+		{http.MethodPut, "/stages/take_home", `{"label":"Bob's now"}`},
+		{http.MethodDelete, "/stages/take_home", ""},
+	} {
+		if w := doAuthRequest(r, req.method, req.path, req.body, bob.Token); w.Code != http.StatusNotFound {
+			t.Errorf("%s %s: expected 404 for bob, got %d: %s", req.method, req.path, w.Code, w.Body.String())
+		}
+	}
+
+	patch := httptest.NewRequest(http.MethodPatch, appPath, strings.NewReader(`{"notes":"mine now"}`))
+	patch.Header.Set("Content-Type", "application/merge-patch+json")
+	patch.Header.Set("Authorization", "Bearer "+bob.Token)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, patch)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("PATCH %s: expected 404 for bob, got %d: %s", appPath, w.Code, w.Body.String())
+	}
+
+	// Bulk actions skip ann's rows, whether named by ID or matched by query.
+	for _, body := range []string{
+		`{"ids":["` + app.ID + `","` + trashed.ID + `"],"action":"delete"}`,
+		`{"ids":["` + app.ID + `"],"action":"set_status","status":"rejected"}`,
+		`{"query":"q=Acme","action":"delete"}`,
+	} {
+		w := doAuthRequest(r, http.MethodPost, "/applications/bulk", body, bob.Token)
+		var report handler.BulkReport
+		json.NewDecoder(w.Body).Decode(&report)
+		if w.Code != http.StatusOK || report.Changed != 0 {
+			t.Errorf("bulk %s: expected nothing changed for bob, got %d %+v", body, w.Code, report)
+		}
+		for _, item := range report.Items {
+			if item.Status != "not_found" {
+				t.Errorf("bulk %s: expected ann's %s not found, got %+v", body, item.ID, item)
+			}
+		}
+	}
+
+	// A view bob shares resolves against his own applications only.
+	w = doAuthRequest(r, http.MethodPost, "/shares", `{"query":"q=Eng"}`, bob.Token)
+	var view model.Share
+	json.NewDecoder(w.Body).Decode(&view)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected bob to share a view, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doAuthRequest(r, http.MethodGet, "/shared/"+view.Token, "", "")
+	if body := w.Body.String(); w.Code != http.StatusOK || !strings.Contains(body, "Globex") || strings.Contains(body, "Acme") {
+		t.Errorf("expected bob's shared view to hold his application and none of ann's, got %d %s", w.Code, w.Body.String())
+	}
+
+	// Collection endpoints return bob's data and nothing of ann's.
+	for _, path := range []string{
+		"/applications",
+		"/applications?q=Acme",
+		"/applications/stats",
+		"/applications/export?format=csv",
+		"/trash",
+		"/tags",
+		"/contacts",
+		"/interviews?from=2000-01-01",
+		"/calendar.ics",
+		"/reminders/due?before=2100-01-01",
+		"/attachments?sha256=" + att.SHA256,
+		"/audit",
+		"/shares",
+		"/stages",
+	} {
+		w := doAuthRequest(r, http.MethodGet, path, "", bob.Token)
+		if w.Code != http.StatusOK {
+			t.Errorf("GET %s: expected 200 for bob, got %d: %s", path, w.Code, w.Body.String())
+			continue
+		}
+		body := w.Body.String()
+		for _, leak := range []string{"Acme", "remote", "Rita", "take_home", app.ID, trashed.ID, att.SHA256, share.ID} {
+			if strings.Contains(body, leak) {
+				t.Errorf("GET %s: bob sees %q: %s", path, leak, body)
+			}
+		}
+	}
+	w = doAuthRequest(r, http.MethodGet, "/applications/stats", "", bob.Token)
+	var stats model.StatsResponse
+	json.NewDecoder(w.Body).Decode(&stats)
+	if stats.Total != 1 {
+		t.Errorf("expected bob's stats to count his one application, got %+v", stats)
+	}
+
+	// Emptying the trash only empties bob's.
+	doAuthRequest(r, http.MethodDelete, "/trash", "", bob.Token)
+
+	// Ann still has everything, untouched, and none of bob's.
+	w = doAuthRequest(r, http.MethodGet, appPath, "", ann.Token)
+	var got model.Application
+	json.NewDecoder(w.Body).Decode(&got)
+	if w.Code != http.StatusOK || got.Notes != "" || len(got.Tags) != 1 {
+		t.Fatalf("expected ann's application untouched, got %d %+v", w.Code, got)
+	}
+	for path, want := range map[string]int{
+		appPath + "/contacts":    1,
+		appPath + "/interviews":  1,
+		appPath + "/reminders":   2,
+		appPath + "/attachments": 1,
+		"/shares":                1,
+		"/trash":                 -1,
+	} {
+		w := doAuthRequest(r, http.MethodGet, path, "", ann.Token)
+		if path == "/trash" {
+			if !strings.Contains(w.Body.String(), trashed.ID) {
+				t.Errorf("expected ann's trash untouched, got %s", w.Body.String())
+			}
+			continue
+		}
+		var items []json.RawMessage
+		json.NewDecoder(w.Body).Decode(&items)
+		if len(items) != want {
+			t.Errorf("GET %s: expected %d items for ann, got %d", path, want, len(items))
+		}
+	}
+	w = doAuthRequest(r, http.MethodGet, "/applications", "", ann.Token)
+	if body := w.Body.String(); strings.Contains(body, "Globex") {
+		t.Errorf("expected ann not to see bob's application, got %s", body)
+	}
+}
+
+func TestStagesPerUser(t *testing.T) {
+	r, _ := setupUsersTest(t)
+	ann := login(t, r, "ann@example.com")
+	bob := login(t, r, "bob@example.com")
+
+	if w := doAuthRequest(r, http.MethodPost, "/stages", `{"name":"onsite","label":"Onsite"}`, ann.Token); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	doAuthRequest(r, http.MethodPost, "/applications", `{"company":"Acme","role":"Eng","status":"applied"}`, ann.Token)
+
+	if w := doAuthRequest(r, http.MethodGet, "/stages/onsite", "", bob.Token); w.Code != http.StatusNotFound {
+		t.Fatalf("expected bob not to see ann's stage, got %d", w.Code)
+	}
+	// Ann's application does not hold the stage in bob's pipeline.
+	if w := doAuthRequest(r, http.MethodDelete, "/stages/applied", "", bob.Token); w.Code != http.StatusNoContent {
+		t.Fatalf("expected bob to delete his applied stage, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doAuthRequest(r, http.MethodGet, "/stages/applied", "", ann.Token); w.Code != http.StatusOK {
+		t.Fatalf("expected ann's applied stage kept, got %d", w.Code)
+	}
+	if w := doAuthRequest(r, http.MethodDelete, "/stages/applied", "", ann.Token); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for ann's stage in use, got %d", w.Code)
+	}
+}
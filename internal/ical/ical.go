@@ -0,0 +1,163 @@
+// Package ical writes RFC 5545 iCalendar feeds.
+//
+// Only the subset needed for a read-only subscription feed is supported: a
+// VCALENDAR of VEVENTs with UTC or all-day times. Text values are escaped and
+// lines folded at 75 octets as the RFC requires.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	utcLayout  = "20060102T150405Z"
+	dateLayout = "20060102"
+	maxLineLen = 75 // octets, excluding CRLF
+)
+
+// Event statuses (RFC 5545 §3.8.1.11).
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusTentative = "TENTATIVE"
+	StatusCancelled = "CANCELLED"
+)
+
+// Event is a single VEVENT. UID must stay the same across feed refreshes so
+// calendar clients update the event instead of adding a copy; Stamp should be
+// the time the event's source data last changed.
+//
+// For all-day events only the date of Start is used and End is ignored. For
+// timed events a zero End leaves the duration to the client.
+type Event struct {
+	UID         string
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Status      string
+	Categories  []string
+}
+
+// Calendar is a VCALENDAR. Name is shown by most clients as the subscription
+// title.
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+// Encode writes c to w.
+func (c Calendar) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	lw := &lineWriter{w: bw}
+
+	lw.line("BEGIN", "VCALENDAR")
+	lw.line("VERSION", "2.0")
+	lw.line("PRODID", c.ProdID)
+	lw.line("CALSCALE", "GREGORIAN")
+	if c.Name != "" {
+		lw.line("X-WR-CALNAME", escapeText(c.Name))
+	}
+	for _, e := range c.Events {
+		e.encode(lw)
+	}
+	lw.line("END", "VCALENDAR")
+
+	if lw.err != nil {
+		return lw.err
+	}
+	return bw.Flush()
+}
+
+func (e Event) encode(lw *lineWriter) {
+	lw.line("BEGIN", "VEVENT")
+	lw.line("UID", e.UID)
+	lw.line("DTSTAMP", e.Stamp.UTC().Format(utcLayout))
+	if e.AllDay {
+		start := e.Start.Format(dateLayout)
+		lw.line("DTSTART;VALUE=DATE", start)
+		lw.line("DTEND;VALUE=DATE", e.Start.AddDate(0, 0, 1).Format(dateLayout))
+	} else {
+		lw.line("DTSTART", e.Start.UTC().Format(utcLayout))
+		if !e.End.IsZero() {
+			lw.line("DTEND", e.End.UTC().Format(utcLayout))
+		}
+	}
+	lw.line("SUMMARY", escapeText(e.Summary))
+	if e.Description != "" {
+		lw.line("DESCRIPTION", escapeText(e.Description))
+	}
+	if e.Location != "" {
+		lw.line("LOCATION", escapeText(e.Location))
+	}
+	if e.URL != "" {
+		lw.line("URL", e.URL)
+	}
+	if e.Status != "" {
+		lw.line("STATUS", e.Status)
+	}
+	if len(e.Categories) > 0 {
+		escaped := make([]string, len(e.Categories))
+		for i, c := range e.Categories {
+			escaped[i] = escapeText(c)
+		}
+		lw.line("CATEGORIES", strings.Join(escaped, ","))
+	}
+	lw.line("END", "VEVENT")
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// escapeText escapes a TEXT value (RFC 5545 §3.3.11).
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// lineWriter writes content lines, folding them and remembering the first
+// error so callers can check once at the end.
+type lineWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (lw *lineWriter) line(name, value string) {
+	if lw.err != nil {
+		return
+	}
+	_, lw.err = io.WriteString(lw.w, fold(name+":"+value))
+}
+
+// fold splits a content line into 75-octet chunks joined by CRLF and a
+// space, never splitting a UTF-8 sequence, and terminates it with CRLF.
+func fold(line string) string {
+	var b strings.Builder
+	limit := maxLineLen
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineLen - 1 // the leading space counts toward the limit
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	stamp := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	cal := Calendar{
+		ProdID: "-//test//EN",
+		Name:   "Job hunt",
+		Events: []Event{
+			{
+				UID:         "interview-1@test",
+				Stamp:       stamp,
+				Start:       time.Date(2026, 3, 2, 10, 0, 0, 0, time.FixedZone("EST", -5*3600)),
+				End:         time.Date(2026, 3, 2, 16, 0, 0, 0, time.UTC),
+				Summary:     "Interview: Acme, Inc; Backend",
+				Description: "line one\nline two \\ end",
+				Status:      StatusConfirmed,
+				Categories:  []string{"interview", "video"},
+			},
+			{UID: "follow-up-1@test", Stamp: stamp, Start: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), AllDay: true, Summary: "Follow up"},
+		},
+	}
+
+	var b strings.Builder
+	if err := cal.Encode(&b); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//test//EN\r\n",
+		"X-WR-CALNAME:Job hunt\r\n",
+		"DTSTAMP:20260201T120000Z\r\n",
+		"DTSTART:20260302T150000Z\r\nDTEND:20260302T160000Z\r\n",
+		`SUMMARY:Interview: Acme\, Inc\; Backend` + "\r\n",
+		`DESCRIPTION:line one\nline two \\ end` + "\r\n",
+		"CATEGORIES:interview,video\r\n",
+		"DTSTART;VALUE=DATE:20260309\r\nDTEND;VALUE=DATE:20260310\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(out, "END:VEVENT\r\nEND:VCALENDAR\r\n") {
+		t.Errorf("unexpected ending:\n%s", out)
+	}
+	if strings.Count(out, "\n") != strings.Count(out, "\r\n") {
+		t.Error("expected every line to end in CRLF")
+	}
+}
+
+func TestFold(t *testing.T) {
+	long := "DESCRIPTION:" + strings.Repeat("é", 100) // 2 octets per rune
+	folded := fold(long)
+
+	lines := strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n")
+	if len(lines) < 3 {
+		t.Fatalf("expected several folded lines, got %d", len(lines))
+	}
+	var rebuilt strings.Builder
+	for i, l := range lines {
+		if len(l) > maxLineLen {
+			t.Errorf("line %d is %d octets", i, len(l))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(l, " ") {
+				t.Fatalf("continuation line %d must start with a space: %q", i, l)
+			}
+			l = l[1:]
+		}
+		rebuilt.WriteString(l)
+	}
+	if rebuilt.String() != long {
+		t.Error("unfolding did not restore the original line")
+	}
+	if fold("SHORT:x") != "SHORT:x\r\n" {
+		t.Error("short lines must not be folded")
+	}
+}
@@ -0,0 +1,95 @@
+// Package jobs holds background work that runs alongside the HTTP server.
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+)
+
+// Ghost detector modes.
+const (
+	GhostModeFlag  = "flag"  // set stale_at and leave the status alone
+	GhostModeGhost = "ghost" // move the application to ghosted
+)
+
+// GhostConfig controls the ghost detector. Applications in Statuses with no
+// updates for After are acted on according to Mode, checked every Interval.
+type GhostConfig struct {
+	After    time.Duration
+	Interval time.Duration
+	Mode     string
+	Statuses []string
+}
+
+// DefaultGhostConfig flags applications that have been waiting on the other
+// side for 30 days, checking hourly.
+func DefaultGhostConfig() GhostConfig {
+	return GhostConfig{
+		After:    30 * 24 * time.Hour,
+		Interval: time.Hour,
+		Mode:     GhostModeFlag,
+		Statuses: []string{"applied", "phone_screen", "interview"},
+	}
+}
+
+// GhostDetector periodically finds applications that have gone quiet.
+type GhostDetector struct {
+	store *db.Store
+	cfg   GhostConfig
+	now   func() time.Time
+}
+
+func NewGhostDetector(store *db.Store, cfg GhostConfig) (*GhostDetector, error) {
+	if cfg.Mode != GhostModeFlag && cfg.Mode != GhostModeGhost {
+		return nil, fmt.Errorf("invalid ghost mode %q, valid values: %s, %s", cfg.Mode, GhostModeFlag, GhostModeGhost)
+	}
+	if cfg.After <= 0 || cfg.Interval <= 0 {
+		return nil, fmt.Errorf("ghost detector after and interval must be positive")
+	}
+	return &GhostDetector{store: store, cfg: cfg, now: time.Now}, nil
+}
+
+// Run sweeps once immediately and then every Interval until ctx is
+// cancelled. A sweep in progress when ctx is cancelled is rolled back.
+func (d *GhostDetector) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("ghost detector sweep failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce performs a single sweep and returns how many applications changed.
+func (d *GhostDetector) RunOnce(ctx context.Context) (int, error) {
+	days := int(d.cfg.After / (24 * time.Hour))
+	sweep := db.StaleSweep{
+		Statuses: d.cfg.Statuses,
+		Before:   d.now().UTC().Add(-d.cfg.After).Format(time.RFC3339),
+		Note:     fmt.Sprintf("Flagged stale automatically: no updates for %d days", days),
+	}
+	if d.cfg.Mode == GhostModeGhost {
+		sweep.GhostStatus = "ghosted"
+		sweep.Note = fmt.Sprintf("Marked ghosted automatically: no updates for %d days", days)
+	}
+
+	changed, err := d.store.SweepStale(ctx, sweep)
+	if err != nil {
+		return 0, err
+	}
+	for _, a := range changed {
+		slog.Info("ghost detector updated application", "id", a.ID, "company", a.Company, "mode", d.cfg.Mode)
+	}
+	return len(changed), nil
+}
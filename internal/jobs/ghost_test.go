@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func setupStore(t *testing.T) *db.Store {
+	t.Helper()
+	store, err := db.NewStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestNewGhostDetectorValidation(t *testing.T) {
+	cfg := DefaultGhostConfig()
+	cfg.Mode = "delete"
+	if _, err := NewGhostDetector(nil, cfg); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+	cfg = DefaultGhostConfig()
+	cfg.Interval = 0
+	if _, err := NewGhostDetector(nil, cfg); err == nil {
+		t.Error("expected error for zero interval")
+	}
+}
+
+func TestGhostDetectorRunOnce(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(t)
+	app, err := store.Create(ctx, model.CreateRequest{Company: "Quiet", Role: "Engineer", Status: "applied"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	cfg := DefaultGhostConfig()
+	cfg.Mode = GhostModeGhost
+	d, err := NewGhostDetector(store, cfg)
+	if err != nil {
+		t.Fatalf("NewGhostDetector failed: %v", err)
+	}
+
+	if n, err := d.RunOnce(ctx); err != nil || n != 0 {
+		t.Fatalf("expected nothing to do for a fresh application, got %d, %v", n, err)
+	}
+
+	d.now = func() time.Time { return time.Now().Add(31 * 24 * time.Hour) }
+	if n, err := d.RunOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("expected 1 application ghosted, got %d, %v", n, err)
+	}
+	got, _ := store.Get(ctx, app.ID)
+	if got.Status != "ghosted" {
+		t.Fatalf("expected ghosted, got %s", got.Status)
+	}
+	history, _ := store.History(ctx, app.ID)
+	if last := history[len(history)-1]; last.Note != "Marked ghosted automatically: no updates for 30 days" {
+		t.Errorf("unexpected history note %q", last.Note)
+	}
+}
+
+func TestGhostDetectorRunStopsOnCancel(t *testing.T) {
+	store := setupStore(t)
+	cfg := DefaultGhostConfig()
+	cfg.Interval = time.Millisecond
+	d, err := NewGhostDetector(store, cfg)
+	if err != nil {
+		t.Fatalf("NewGhostDetector failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
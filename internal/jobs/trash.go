@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/db"
+)
+
+// TrashConfig controls the trash purger. Applications trashed longer than
+// Retention ago are permanently deleted, checked every Interval.
+type TrashConfig struct {
+	Retention time.Duration
+	Interval  time.Duration
+}
+
+// DefaultTrashConfig keeps trashed applications for 30 days, checking hourly.
+func DefaultTrashConfig() TrashConfig {
+	return TrashConfig{
+		Retention: 30 * 24 * time.Hour,
+		Interval:  time.Hour,
+	}
+}
+
+// TrashPurger periodically empties the trash of expired applications.
+type TrashPurger struct {
+	store *db.Store
+	cfg   TrashConfig
+	now   func() time.Time
+}
+
+func NewTrashPurger(store *db.Store, cfg TrashConfig) (*TrashPurger, error) {
+	if cfg.Retention <= 0 || cfg.Interval <= 0 {
+		return nil, fmt.Errorf("trash purger retention and interval must be positive")
+	}
+	return &TrashPurger{store: store, cfg: cfg, now: time.Now}, nil
+}
+
+// Run purges once immediately and then every Interval until ctx is cancelled.
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("trash purge failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce purges expired applications and returns how many were removed.
+func (p *TrashPurger) RunOnce(ctx context.Context) (int, error) {
+	before := p.now().UTC().Add(-p.cfg.Retention).Format(time.RFC3339)
+	n, err := p.store.PurgeTrash(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		slog.Info("trash purger removed applications", "count", n)
+	}
+	return n, nil
+}
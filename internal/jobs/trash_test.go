@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shakilbd009/job-hunt-platform/internal/model"
+)
+
+func TestTrashPurgerRunOnce(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(t)
+	app, err := store.Create(ctx, model.CreateRequest{Company: "Oops", Role: "Engineer"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	store.Delete(ctx, app.ID)
+
+	if _, err := NewTrashPurger(store, TrashConfig{Interval: time.Hour}); err == nil {
+		t.Error("expected error for zero retention")
+	}
+	p, err := NewTrashPurger(store, DefaultTrashConfig())
+	if err != nil {
+		t.Fatalf("NewTrashPurger failed: %v", err)
+	}
+	if n, err := p.RunOnce(ctx); err != nil || n != 0 {
+		t.Fatalf("expected freshly trashed application kept, got %d, %v", n, err)
+	}
+
+	p.now = func() time.Time { return time.Now().Add(31 * 24 * time.Hour) }
+	if n, err := p.RunOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("expected 1 application purged, got %d, %v", n, err)
+	}
+	if restored, _ := store.Restore(ctx, app.ID); restored != nil {
+		t.Fatal("expected purged application to be gone")
+	}
+}
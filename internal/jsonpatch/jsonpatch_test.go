@@ -0,0 +1,119 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func decode(t *testing.T, s string) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("decoding %s: %v", s, err)
+	}
+	return v
+}
+
+func TestMergePatch(t *testing.T) {
+	// Cases from RFC 7396 Appendix A.
+	cases := []struct{ target, patch, want string }{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, c := range cases {
+		target := decode(t, c.target)
+		got := MergePatch(target, decode(t, c.patch))
+		if !reflect.DeepEqual(got, decode(t, c.want)) {
+			t.Errorf("MergePatch(%s, %s) = %v, want %s", c.target, c.patch, got, c.want)
+		}
+		if !reflect.DeepEqual(target, decode(t, c.target)) {
+			t.Errorf("MergePatch modified its target %s", c.target)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	// Cases adapted from RFC 6902 Appendix A.
+	cases := []struct{ doc, patch, want string }{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			`{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{`{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+		{`{"foo":null}`, `[{"op":"test","path":"/foo","value":null}]`, `{"foo":null}`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/foo","value":null}]`, `{"foo":null}`},
+		{`{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10},{"op":"copy","from":"/~1","path":"/a"}]`, `{"/":9,"~1":10,"a":9}`},
+		{`{"foo":1}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+	}
+	for _, c := range cases {
+		var ops []Operation
+		if err := json.Unmarshal([]byte(c.patch), &ops); err != nil {
+			t.Fatalf("decoding %s: %v", c.patch, err)
+		}
+		doc := decode(t, c.doc)
+		got, err := Apply(doc, ops)
+		if err != nil {
+			t.Errorf("Apply(%s, %s) failed: %v", c.doc, c.patch, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, decode(t, c.want)) {
+			t.Errorf("Apply(%s, %s) = %v, want %s", c.doc, c.patch, got, c.want)
+		}
+		if !reflect.DeepEqual(doc, decode(t, c.doc)) {
+			t.Errorf("Apply modified its document %s", c.doc)
+		}
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	cases := []struct{ doc, patch string }{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`},
+		{`{"foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`},
+		{`{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":1}]`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz"}]`},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/2","value":1}]`},
+		{`{"foo":["bar"]}`, `[{"op":"remove","path":"/foo/01"}]`},
+		{`{"foo":["bar"]}`, `[{"op":"remove","path":"/foo/-"}]`},
+		{`{"foo":{"bar":1}}`, `[{"op":"move","from":"/foo","path":"/foo/bar/baz"}]`},
+		{`{"foo":"bar"}`, `[{"op":"frobnicate","path":"/foo"}]`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"foo","value":1}]`},
+	}
+	for _, c := range cases {
+		var ops []Operation
+		json.Unmarshal([]byte(c.patch), &ops)
+		if _, err := Apply(decode(t, c.doc), ops); err == nil || errors.Is(err, ErrTestFailed) {
+			t.Errorf("Apply(%s, %s): expected an error, got %v", c.doc, c.patch, err)
+		}
+	}
+
+	// A failed test aborts the whole patch.
+	var ops []Operation
+	json.Unmarshal([]byte(`[{"op":"replace","path":"/n","value":2},{"op":"test","path":"/s","value":"x"}]`), &ops)
+	doc := decode(t, `{"n":1,"s":"y"}`)
+	if got, err := Apply(doc, ops); !errors.Is(err, ErrTestFailed) || got != nil {
+		t.Fatalf("expected ErrTestFailed and no result, got %v (%v)", got, err)
+	}
+	if !reflect.DeepEqual(doc, decode(t, `{"n":1,"s":"y"}`)) {
+		t.Fatal("expected a failed patch to leave the document alone")
+	}
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// API key scopes. A read-write key can do everything a read-only one can.
+const (
+	ScopeRead      = "read"
+	ScopeReadWrite = "read-write"
+)
+
+var ValidScopes = []string{ScopeRead, ScopeReadWrite}
+
+func ValidateScope(scope string) error {
+	if slices.Contains(ValidScopes, scope) {
+		return nil
+	}
+	return fmt.Errorf("invalid scope %q, valid values: %s", scope, strings.Join(ValidScopes, ", "))
+}
+
+// APIKey describes a key without its secret, which is only shown once when
+// the key is created. Prefix is the start of the secret, to tell keys apart.
+type APIKey struct {
+	ID         string `json:"id"`
+	UserID     string `json:"user_id"`
+	Name       string `json:"name"`
+	Prefix     string `json:"prefix"`
+	Scope      string `json:"scope"`
+	CreatedAt  string `json:"created_at"`
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	RevokedAt  string `json:"revoked_at,omitempty"`
+}
+
+// Allows reports whether the key grants scope.
+func (k APIKey) Allows(scope string) bool {
+	return ScopeAllows(k.Scope, scope)
+}
+
+// ScopeAllows reports whether a caller holding scope have may do something
+// that needs want.
+func ScopeAllows(have, want string) bool {
+	return have == want || have == ScopeReadWrite
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ValidAttachmentKinds are the document types an attachment can be filed as.
+var ValidAttachmentKinds = []string{"resume", "cover_letter", "offer_letter"}
+
+func ValidateAttachmentKind(kind string) error {
+	if slices.Contains(ValidAttachmentKinds, kind) {
+		return nil
+	}
+	return fmt.Errorf("invalid kind %q, valid values: %s", kind, strings.Join(ValidAttachmentKinds, ", "))
+}
+
+// Attachment is a file's metadata. The content is stored once per SHA256, so
+// uploading the same resume to several applications shares one copy.
+type Attachment struct {
+	ID            string `json:"id"`
+	ApplicationID string `json:"application_id"`
+	Kind          string `json:"kind"`
+	Filename      string `json:"filename"`
+	ContentType   string `json:"content_type"`
+	Size          int64  `json:"size"`
+	SHA256        string `json:"sha256"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// AttachmentUse is an attachment plus the application it was sent with, for
+// answering "which applications got this version?".
+type AttachmentUse struct {
+	Attachment
+	Company string `json:"company"`
+	Role    string `json:"role"`
+	Status  string `json:"status"`
+}
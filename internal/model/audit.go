@@ -0,0 +1,28 @@
+package model
+
+import "encoding/json"
+
+// Audit actions.
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	AuditPurge   = "purge"
+	AuditLink    = "link"
+	AuditUnlink  = "unlink"
+)
+
+// AuditEntry is one recorded mutation. Before and After hold only the fields
+// that changed: After alone for a create, Before alone for a delete.
+type AuditEntry struct {
+	ID         int64           `json:"id"`
+	At         string          `json:"at"`
+	Actor      string          `json:"actor"`
+	RequestID  string          `json:"request_id"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+}
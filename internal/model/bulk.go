@@ -0,0 +1,143 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Bulk actions.
+const (
+	BulkSetStatus = "set_status"
+	BulkSetField  = "set_field"
+	BulkDelete    = "delete"
+	BulkAddTags   = "add_tags"
+)
+
+// MaxBulkItems caps how many applications one bulk request may touch.
+const MaxBulkItems = 1000
+
+// bulkFields are the fields set_field can change; true marks the numeric
+// ones.
+var bulkFields = map[string]bool{
+	"company":    false,
+	"role":       false,
+	"url":        false,
+	"location":   false,
+	"notes":      false,
+	"applied_at": false,
+	"salary_min": true,
+	"salary_max": true,
+}
+
+// BulkRequest applies one action to the applications listed in IDs or
+// matching Query, which takes the same parameters as GET /applications
+// (without limit and offset).
+type BulkRequest struct {
+	IDs    []string `json:"ids"`
+	Query  string   `json:"query"`
+	Action string   `json:"action"`
+
+	// Status, StatusNote and OverrideTransition are for set_status.
+	Status             string `json:"status"`
+	StatusNote         string `json:"status_note"`
+	OverrideTransition bool   `json:"override_transition"`
+
+	// Field and Value are for set_field. A null Value clears the field.
+	Field string          `json:"field"`
+	Value json.RawMessage `json:"value"`
+
+	// Tags are for add_tags.
+	Tags []string `json:"tags"`
+}
+
+// Normalize checks r's shape, drops duplicate IDs and normalizes tags. For
+// set_status and set_field it returns the columns to set. Whether Status is a
+// stage is left to the caller.
+func (r *BulkRequest) Normalize() (map[string]interface{}, error) {
+	switch {
+	case len(r.IDs) > 0 && r.Query != "":
+		return nil, fmt.Errorf("set ids or query, not both")
+	case len(r.IDs) == 0 && r.Query == "":
+		return nil, fmt.Errorf("ids or query is required")
+	}
+	seen := make(map[string]bool, len(r.IDs))
+	ids := r.IDs[:0]
+	for _, id := range r.IDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	r.IDs = ids
+	if len(r.IDs) > MaxBulkItems {
+		return nil, fmt.Errorf("at most %d ids are allowed", MaxBulkItems)
+	}
+
+	switch r.Action {
+	case BulkSetStatus:
+		if r.Status == "" {
+			return nil, fmt.Errorf("status is required for %s", BulkSetStatus)
+		}
+		return map[string]interface{}{"status": r.Status}, nil
+
+	case BulkSetField:
+		numeric, ok := bulkFields[r.Field]
+		if !ok {
+			return nil, fmt.Errorf("invalid field %q, valid values: %s", r.Field, strings.Join(bulkFieldNames(), ", "))
+		}
+		if len(r.Value) == 0 {
+			return nil, fmt.Errorf("value is required for %s", BulkSetField)
+		}
+		var value interface{}
+		if numeric {
+			var n *int
+			if err := json.Unmarshal(r.Value, &n); err != nil || (n != nil && *n < 0) {
+				return nil, fmt.Errorf("value for %s must be a non-negative integer or null", r.Field)
+			}
+			value = 0
+			if n != nil {
+				value = *n
+			}
+		} else {
+			var s *string
+			if err := json.Unmarshal(r.Value, &s); err != nil {
+				return nil, fmt.Errorf("value for %s must be a string or null", r.Field)
+			}
+			value = ""
+			if s != nil {
+				value = *s
+			}
+			if value == "" && (r.Field == "company" || r.Field == "role") {
+				return nil, fmt.Errorf("%s cannot be empty", r.Field)
+			}
+		}
+		return map[string]interface{}{r.Field: value}, nil
+
+	case BulkDelete:
+		return nil, nil
+
+	case BulkAddTags:
+		tags, err := NormalizeTags(r.Tags)
+		if err != nil {
+			return nil, err
+		}
+		if len(tags) == 0 {
+			return nil, fmt.Errorf("tags is required for %s", BulkAddTags)
+		}
+		r.Tags = tags
+		return nil, nil
+	}
+	return nil, fmt.Errorf("invalid action %q, valid values: %s", r.Action,
+		strings.Join([]string{BulkSetStatus, BulkSetField, BulkDelete, BulkAddTags}, ", "))
+}
+
+func bulkFieldNames() []string {
+	names := make([]string, 0, len(bulkFields))
+	for name := range bulkFields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
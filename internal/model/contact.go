@@ -0,0 +1,96 @@
+package model
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Contact is a person met during the search: a recruiter, hiring manager,
+// referrer or interviewer. Contacts are linked to applications through
+// ApplicationContact.
+type Contact struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+	LinkedInURL string `json:"linkedin_url"`
+	Company     string `json:"company"`
+	Role        string `json:"role"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// ValidRelationships are the ways a contact can be linked to an application.
+var ValidRelationships = []string{"recruiter", "hiring_manager", "referrer", "interviewer"}
+
+func ValidateRelationship(rel string) error {
+	if slices.Contains(ValidRelationships, rel) {
+		return nil
+	}
+	return fmt.Errorf("invalid relationship %q, valid values: %s", rel, strings.Join(ValidRelationships, ", "))
+}
+
+// ApplicationContact is a contact as seen from one application.
+type ApplicationContact struct {
+	Contact
+	Relationship string `json:"relationship"`
+	LinkedAt     string `json:"linked_at"`
+}
+
+// ContactApplication is an application as seen from one contact.
+type ContactApplication struct {
+	Application
+	Relationship string `json:"relationship"`
+	LinkedAt     string `json:"linked_at"`
+}
+
+// ContactRequest is the body for creating or updating a contact. On update,
+// nil fields are left unchanged.
+type ContactRequest struct {
+	Name        *string `json:"name"`
+	Email       *string `json:"email"`
+	Phone       *string `json:"phone"`
+	LinkedInURL *string `json:"linkedin_url"`
+	Company     *string `json:"company"`
+	Role        *string `json:"role"`
+}
+
+// Validate checks the request. creating selects the rules for POST (name
+// required) versus PUT.
+func (r ContactRequest) Validate(creating bool) error {
+	if creating && (r.Name == nil || strings.TrimSpace(*r.Name) == "") {
+		return fmt.Errorf("name is required")
+	}
+	if !creating && r.Name != nil && strings.TrimSpace(*r.Name) == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if r.Email != nil && *r.Email != "" {
+		addr, err := mail.ParseAddress(*r.Email)
+		if err != nil || addr.Address != *r.Email {
+			return fmt.Errorf("email must be a plain address like name@example.com")
+		}
+	}
+	if r.LinkedInURL != nil && *r.LinkedInURL != "" {
+		u, err := url.Parse(*r.LinkedInURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("linkedin_url must be an http(s) URL")
+		}
+	}
+	return nil
+}
+
+// ContactLinkRequest is the body for linking a contact to an application.
+type ContactLinkRequest struct {
+	ContactID    string `json:"contact_id"`
+	Relationship string `json:"relationship"`
+}
+
+func (r ContactLinkRequest) Validate() error {
+	if r.ContactID == "" {
+		return fmt.Errorf("contact_id is required")
+	}
+	return ValidateRelationship(r.Relationship)
+}
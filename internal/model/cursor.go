@@ -0,0 +1,86 @@
+package model
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor is returned by DecodeCursor for a token it did not issue.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is a keyset position in a sorted list: the sort column's value and
+// ID of the last application on a page. The next page starts after it.
+type Cursor struct {
+	SortBy    string `json:"s"`
+	SortOrder string `json:"o"`
+	// Value is a string, or an int64 for the salary columns.
+	Value interface{} `json:"v"`
+	ID    string      `json:"id"`
+}
+
+// NewCursor returns the position just after app in a list sorted by sortBy,
+// which must be one of ValidSortColumns.
+func NewCursor(app Application, sortBy, sortOrder string) Cursor {
+	return Cursor{SortBy: sortBy, SortOrder: sortOrder, Value: sortValue(app, sortBy), ID: app.ID}
+}
+
+// sortValue returns app's value in the sort column col.
+func sortValue(app Application, col string) interface{} {
+	switch col {
+	case "company":
+		return app.Company
+	case "role":
+		return app.Role
+	case "status":
+		return app.Status
+	case "salary_min":
+		return int64(app.SalaryMin)
+	case "salary_max":
+		return int64(app.SalaryMax)
+	case "location":
+		return app.Location
+	case "created_at":
+		return app.CreatedAt
+	default:
+		return app.UpdatedAt
+	}
+}
+
+// Encode returns c as an opaque, URL-safe token.
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a token from Cursor.Encode. The value is checked
+// against the type of its sort column.
+func DecodeCursor(token string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var c Cursor
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&c); err != nil || !ValidSortColumns[c.SortBy] ||
+		(c.SortOrder != "asc" && c.SortOrder != "desc") || c.ID == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+	switch v := c.Value.(type) {
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil || (c.SortBy != "salary_min" && c.SortBy != "salary_max") {
+			return Cursor{}, ErrInvalidCursor
+		}
+		c.Value = n
+	case string:
+		if c.SortBy == "salary_min" || c.SortBy == "salary_max" {
+			return Cursor{}, ErrInvalidCursor
+		}
+	default:
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// Reasons an application is reported as a likely duplicate.
+const (
+	DuplicateSameURL = "same_url"
+	DuplicateSimilar = "similar_company_and_role"
+)
+
+// Similarity thresholds, as a fraction of the longer name, for
+// DuplicateSimilar.
+const (
+	companySimilarity = 0.8
+	roleSimilarity    = 0.8
+)
+
+// Duplicate is an existing application that looks like the same posting.
+type Duplicate struct {
+	ID      string `json:"id"`
+	Company string `json:"company"`
+	Role    string `json:"role"`
+	URL     string `json:"url,omitempty"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+}
+
+// MergeRequest folds SourceID into the application named in the path.
+type MergeRequest struct {
+	SourceID string `json:"source_id"`
+}
+
+// companySuffixes are dropped from the end of company names before comparing.
+var companySuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "ltd": true, "limited": true,
+	"corp": true, "corporation": true, "co": true, "company": true, "gmbh": true,
+	"plc": true, "ag": true, "sa": true, "bv": true, "pty": true,
+}
+
+// roleWords expands common abbreviations in job titles.
+var roleWords = map[string]string{
+	"sr": "senior", "jr": "junior", "eng": "engineer", "engr": "engineer",
+	"dev": "developer", "swe": "software engineer", "mgr": "manager",
+}
+
+// nameWords lowercases s and splits it into words of letters and digits,
+// treating everything else as a separator.
+func nameWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// NormalizeCompany reduces a company name to what tells it apart: "The Acme
+// Corp., Inc." and "acme" both become "acme".
+func NormalizeCompany(company string) string {
+	words := nameWords(company)
+	if len(words) > 1 && words[0] == "the" {
+		words = words[1:]
+	}
+	for len(words) > 1 && companySuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, "")
+}
+
+// NormalizeRole lowercases a job title, drops punctuation and expands common
+// abbreviations, so "Sr. Backend Eng" matches "Senior Backend Engineer".
+func NormalizeRole(role string) string {
+	words := nameWords(role)
+	for i, w := range words {
+		if long, ok := roleWords[w]; ok {
+			words[i] = long
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// NormalizeURL makes URLs that point at the same posting compare equal: the
+// scheme and host are lowercased and a leading www., the fragment and a
+// trailing slash are dropped. It returns "" for an empty or unparsable URL.
+func NormalizeURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	path := strings.TrimSuffix(u.EscapedPath(), "/")
+	normalized := host + path
+	if u.RawQuery != "" {
+		normalized += "?" + u.RawQuery
+	}
+	return normalized
+}
+
+// DuplicateReason reports whether b looks like the same posting as a and
+// why. A shared URL is enough; otherwise both the company and the role must
+// be close after normalizing.
+func DuplicateReason(a, b Application) (string, bool) {
+	if u := NormalizeURL(a.URL); u != "" && u == NormalizeURL(b.URL) {
+		return DuplicateSameURL, true
+	}
+	companyA, companyB := NormalizeCompany(a.Company), NormalizeCompany(b.Company)
+	if companyA == "" || companyB == "" || similarity(companyA, companyB) < companySimilarity {
+		return "", false
+	}
+	if similarity(NormalizeRole(a.Role), NormalizeRole(b.Role)) < roleSimilarity {
+		return "", false
+	}
+	return DuplicateSimilar, true
+}
+
+// similarity is 1 minus the edit distance between a and b over the length
+// of the longer one: 1 for equal strings, 0 for nothing in common.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longer := max(len(ra), len(rb))
+	if longer == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(longer)
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,161 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Interview is one scheduled conversation in an application's loop. StartsAt
+// and EndsAt are RFC3339 in UTC; Timezone is the IANA zone it was scheduled
+// in, for display. EndsAt is empty when the length is unknown.
+type Interview struct {
+	ID            string   `json:"id"`
+	ApplicationID string   `json:"application_id"`
+	StartsAt      string   `json:"starts_at"`
+	EndsAt        string   `json:"ends_at"`
+	Timezone      string   `json:"timezone"`
+	Format        string   `json:"format"`
+	Interviewers  []string `json:"interviewers"`
+	Location      string   `json:"location"`
+	Outcome       string   `json:"outcome"`
+	Feedback      string   `json:"feedback"`
+	CreatedAt     string   `json:"created_at"`
+	UpdatedAt     string   `json:"updated_at"`
+}
+
+// ScheduledInterview is an agenda entry: an interview plus enough of its
+// application to know which loop it belongs to.
+type ScheduledInterview struct {
+	Interview
+	Company string `json:"company"`
+	Role    string `json:"role"`
+	Status  string `json:"status"`
+}
+
+var (
+	ValidInterviewFormats  = []string{"phone", "video", "onsite", "take_home"}
+	ValidInterviewOutcomes = []string{"pending", "passed", "failed", "cancelled"}
+)
+
+// InterviewRequest is the body for creating or updating an interview. On
+// update, nil fields are left unchanged.
+//
+// Times may carry an offset ("2026-03-02T15:00:00+01:00") or be local
+// wall-clock times ("2026-03-02T15:00") in Timezone. Changing only Timezone
+// keeps the stored instants.
+type InterviewRequest struct {
+	StartsAt     *string   `json:"starts_at"`
+	EndsAt       *string   `json:"ends_at"`
+	Timezone     *string   `json:"timezone"`
+	Format       *string   `json:"format"`
+	Interviewers *[]string `json:"interviewers"`
+	Location     *string   `json:"location"`
+	Outcome      *string   `json:"outcome"`
+	Feedback     *string   `json:"feedback"`
+}
+
+// Apply validates the request and writes it onto iv. creating selects the
+// rules for POST (starts_at and format required) and fills defaults.
+func (r InterviewRequest) Apply(iv *Interview, creating bool) error {
+	if creating {
+		if r.StartsAt == nil || *r.StartsAt == "" {
+			return fmt.Errorf("starts_at is required")
+		}
+		if r.Format == nil {
+			return fmt.Errorf("format is required")
+		}
+		iv.Timezone = "UTC"
+		iv.Outcome = "pending"
+		iv.Interviewers = []string{}
+	}
+
+	if r.Timezone != nil {
+		if _, err := loadTimezone(*r.Timezone); err != nil {
+			return err
+		}
+		iv.Timezone = *r.Timezone
+	}
+	loc, err := loadTimezone(iv.Timezone)
+	if err != nil {
+		return err
+	}
+
+	if r.StartsAt != nil {
+		if *r.StartsAt == "" {
+			return fmt.Errorf("starts_at cannot be empty")
+		}
+		if iv.StartsAt, err = parseInterviewTime("starts_at", *r.StartsAt, loc); err != nil {
+			return err
+		}
+	}
+	if r.EndsAt != nil {
+		iv.EndsAt = ""
+		if *r.EndsAt != "" {
+			if iv.EndsAt, err = parseInterviewTime("ends_at", *r.EndsAt, loc); err != nil {
+				return err
+			}
+		}
+	}
+	if iv.EndsAt != "" && iv.EndsAt <= iv.StartsAt {
+		return fmt.Errorf("ends_at must be after starts_at")
+	}
+
+	if r.Format != nil {
+		if !slices.Contains(ValidInterviewFormats, *r.Format) {
+			return fmt.Errorf("invalid format %q, valid values: %s", *r.Format, strings.Join(ValidInterviewFormats, ", "))
+		}
+		iv.Format = *r.Format
+	}
+	if r.Outcome != nil {
+		if !slices.Contains(ValidInterviewOutcomes, *r.Outcome) {
+			return fmt.Errorf("invalid outcome %q, valid values: %s", *r.Outcome, strings.Join(ValidInterviewOutcomes, ", "))
+		}
+		iv.Outcome = *r.Outcome
+	}
+	if r.Interviewers != nil {
+		iv.Interviewers = []string{}
+		for _, name := range *r.Interviewers {
+			if name = strings.TrimSpace(name); name != "" {
+				iv.Interviewers = append(iv.Interviewers, name)
+			}
+		}
+	}
+	if r.Location != nil {
+		iv.Location = strings.TrimSpace(*r.Location)
+	}
+	if r.Feedback != nil {
+		iv.Feedback = *r.Feedback
+	}
+	return nil
+}
+
+// loadTimezone accepts IANA zone names such as "Europe/Berlin" and "UTC".
+// "Local" is rejected because it depends on the server.
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("timezone must be an IANA zone name like America/New_York")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+	return loc, nil
+}
+
+var localTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04"}
+
+// parseInterviewTime returns value as RFC3339 UTC. Values without an offset
+// are read as wall-clock time in loc.
+func parseInterviewTime(field, value string, loc *time.Location) (string, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC().Format(time.RFC3339), nil
+	}
+	for _, layout := range localTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.UTC().Format(time.RFC3339), nil
+		}
+	}
+	return "", fmt.Errorf("%s must be RFC3339 (2026-03-02T15:00:00Z) or local time (2026-03-02T15:00)", field)
+}
@@ -2,18 +2,6 @@ package model
 
 import "fmt"
 
-var ValidStatuses = map[string]bool{
-	"wishlist":     true,
-	"applied":      true,
-	"phone_screen": true,
-	"interview":    true,
-	"offer":        true,
-	"accepted":     true,
-	"rejected":     true,
-	"withdrawn":    true,
-	"ghosted":      true,
-}
-
 type Application struct {
 	ID        string `json:"id"`
 	Company   string `json:"company"`
@@ -27,38 +15,68 @@ type Application struct {
 	AppliedAt string `json:"applied_at"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
+	// Version goes up by one with every change and is served as the
+	// application's ETag.
+	Version int `json:"version"`
+	// StaleAt is when the stale sweep flagged the application for having no
+	// updates; it is cleared by the next update.
+	StaleAt string `json:"stale_at,omitempty"`
+	// DeletedAt is set while the application is in the trash.
+	DeletedAt string   `json:"deleted_at,omitempty"`
+	Tags      []string `json:"tags"`
+	// Snippet is the best-matching excerpt, with matches wrapped in <mark>,
+	// when the application was found by a full-text search.
+	Snippet string `json:"snippet,omitempty"`
+}
+
+// StatusChange is one entry in an application's status timeline. FromStatus
+// is empty for the entry recorded when the application was created.
+type StatusChange struct {
+	ID            int64  `json:"id"`
+	ApplicationID string `json:"application_id"`
+	FromStatus    string `json:"from_status"`
+	ToStatus      string `json:"to_status"`
+	Note          string `json:"note"`
+	ChangedAt     string `json:"changed_at"`
 }
 
 type CreateRequest struct {
-	Company   string `json:"company"`
-	Role      string `json:"role"`
-	URL       string `json:"url"`
-	SalaryMin *int   `json:"salary_min"`
-	SalaryMax *int   `json:"salary_max"`
-	Location  string `json:"location"`
-	Status    string `json:"status"`
-	Notes     string `json:"notes"`
-	AppliedAt string `json:"applied_at"`
+	Company   string   `json:"company"`
+	Role      string   `json:"role"`
+	URL       string   `json:"url"`
+	SalaryMin *int     `json:"salary_min"`
+	SalaryMax *int     `json:"salary_max"`
+	Location  string   `json:"location"`
+	Status    string   `json:"status"`
+	Notes     string   `json:"notes"`
+	AppliedAt string   `json:"applied_at"`
+	Tags      []string `json:"tags"`
 }
 
-func (r CreateRequest) Validate() error {
+// Validate checks required fields, tag format, and that Status, if set, is a
+// stage in p.
+func (r CreateRequest) Validate(p Pipeline) error {
 	if r.Company == "" {
 		return fmt.Errorf("company is required")
 	}
 	if r.Role == "" {
 		return fmt.Errorf("role is required")
 	}
-	if r.Status != "" && !ValidStatuses[r.Status] {
-		return fmt.Errorf("invalid status %q, valid values: wishlist, applied, phone_screen, interview, offer, accepted, rejected, withdrawn, ghosted", r.Status)
+	if err := p.ValidateStatus(r.Status); err != nil {
+		return err
 	}
 	if r.SalaryMin != nil && r.SalaryMax != nil && *r.SalaryMin > *r.SalaryMax {
 		return fmt.Errorf("salary_min cannot be greater than salary_max")
 	}
+	if _, err := NormalizeTags(r.Tags); err != nil {
+		return err
+	}
 	return nil
 }
 
 type StatsResponse struct {
 	ByStatus       map[string]int `json:"by_status"`
+	ByTag          map[string]int `json:"by_tag"`
 	Total          int            `json:"total"`
 	SalaryRange    SalaryRange    `json:"salary_range"`
 	RecentActivity RecentActivity `json:"recent_activity"`
@@ -76,6 +94,8 @@ type RecentActivity struct {
 }
 
 type ListOptions struct {
+	// Query is an FTS5 match expression over company, role, location and notes.
+	Query           string
 	Status          string
 	Limit           int
 	Offset          int
@@ -90,8 +110,23 @@ type ListOptions struct {
 	SalaryMaxLTE    int
 	HasSalaryMinGTE bool
 	HasSalaryMaxLTE bool
+	Stale           bool
+	HasStale        bool
+	// Tags must all be present, TagsAny needs at least one, TagsNone excludes.
+	Tags     []string
+	TagsAny  []string
+	TagsNone []string
+	// Trashed lists soft-deleted applications instead of live ones.
+	Trashed bool
+	// After, when set, starts the list just past this position instead of
+	// at Offset. Its sort must match SortBy and SortOrder.
+	After *Cursor
 }
 
+// SortByRelevance orders full-text search results by bm25 rank. It is only
+// valid together with ListOptions.Query.
+const SortByRelevance = "relevance"
+
 var ValidSortColumns = map[string]bool{
 	"company":    true,
 	"role":       true,
@@ -102,10 +137,3 @@ var ValidSortColumns = map[string]bool{
 	"created_at": true,
 	"updated_at": true,
 }
-
-func ValidateStatus(status string) error {
-	if status != "" && !ValidStatuses[status] {
-		return fmt.Errorf("invalid status %q, valid values: wishlist, applied, phone_screen, interview, offer, accepted, rejected, withdrawn, ghosted", status)
-	}
-	return nil
-}
@@ -1,6 +1,24 @@
 package model
 
-import "testing"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// testPipeline mirrors the stages seeded by the create_stages migration.
+var testPipeline = Pipeline{
+	{Name: "wishlist", Position: 10, Next: []string{"applied", "withdrawn"}},
+	{Name: "applied", Position: 20, Next: []string{"phone_screen", "interview", "offer", "rejected", "withdrawn", "ghosted"}},
+	{Name: "phone_screen", Position: 30, Next: []string{"interview", "offer", "rejected", "withdrawn", "ghosted"}},
+	{Name: "interview", Position: 40, Next: []string{"offer", "rejected", "withdrawn", "ghosted"}},
+	{Name: "offer", Position: 50, Next: []string{"accepted", "rejected", "withdrawn"}},
+	{Name: "accepted", Position: 60, Terminal: true, Next: []string{}},
+	{Name: "rejected", Position: 70, Terminal: true, Next: []string{}},
+	{Name: "withdrawn", Position: 80, Terminal: true, Next: []string{}},
+	{Name: "ghosted", Position: 90, Next: []string{"phone_screen", "interview", "offer", "rejected", "withdrawn"}},
+}
 
 func TestCreateRequestValidate(t *testing.T) {
 	tests := []struct {
@@ -62,7 +80,7 @@ func TestCreateRequestValidate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.req.Validate()
+			err := tt.req.Validate(testPipeline)
 			if tt.wantErr == "" {
 				if err != nil {
 					t.Fatalf("expected no error, got %v", err)
@@ -92,7 +110,7 @@ func TestValidateStatus(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateStatus(tt.status)
+			err := testPipeline.ValidateStatus(tt.status)
 			if tt.wantErr && err == nil {
 				t.Fatal("expected error, got nil")
 			}
@@ -103,6 +121,100 @@ func TestValidateStatus(t *testing.T) {
 	}
 }
 
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{name: "forward move", from: "applied", to: "phone_screen"},
+		{name: "skip ahead", from: "applied", to: "offer"},
+		{name: "same status", from: "accepted", to: "accepted"},
+		{name: "ghosted revived", from: "ghosted", to: "interview"},
+		{name: "accepted back to wishlist", from: "accepted", to: "wishlist", wantErr: true},
+		{name: "rejected to offer", from: "rejected", to: "offer", wantErr: true},
+		{name: "backwards", from: "interview", to: "applied", wantErr: true},
+		{name: "unknown target", from: "applied", to: "bogus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testPipeline.ValidateTransition(tt.from, tt.to)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			te, ok := err.(*TransitionError)
+			if !ok {
+				t.Fatalf("expected *TransitionError, got %v", err)
+			}
+			if te.From != tt.from || te.To != tt.to {
+				t.Fatalf("unexpected error fields: %+v", te)
+			}
+		})
+	}
+}
+
+func TestPipelineDefaultAndErrorMessage(t *testing.T) {
+	if got := testPipeline.Default(); got != "wishlist" {
+		t.Fatalf("expected default wishlist, got %q", got)
+	}
+
+	custom := Pipeline{
+		{Name: "sourced", Position: 1},
+		{Name: "take_home", Position: 2},
+		{Name: "onsite", Position: 3},
+	}
+	err := custom.ValidateStatus("offer")
+	if err == nil {
+		t.Fatal("expected error for status outside custom pipeline")
+	}
+	if !strings.Contains(err.Error(), "sourced, take_home, onsite") {
+		t.Fatalf("expected error to list configured stages, got %q", err.Error())
+	}
+	if custom.Default() != "sourced" {
+		t.Fatalf("expected first non-terminal stage as default, got %q", custom.Default())
+	}
+}
+
+func TestStageRequestValidate(t *testing.T) {
+	str := func(s string) *string { return &s }
+	yes := true
+	tests := []struct {
+		name     string
+		req      StageRequest
+		creating bool
+		wantErr  string
+	}{
+		{name: "valid create", req: StageRequest{Name: "take_home", Label: str("Take-home"), Next: &[]string{"interview"}}, creating: true},
+		{name: "bad name", req: StageRequest{Name: "Take Home", Label: str("x")}, creating: true, wantErr: "name must be"},
+		{name: "missing label", req: StageRequest{Name: "onsite"}, creating: true, wantErr: "label is required"},
+		{name: "unknown next", req: StageRequest{Name: "onsite", Label: str("Onsite"), Next: &[]string{"nope"}}, creating: true, wantErr: `unknown next stage "nope"`},
+		{name: "terminal with next", req: StageRequest{Name: "hired", Label: str("Hired"), Terminal: &yes, Next: &[]string{"applied"}}, creating: true, wantErr: "terminal stages"},
+		{name: "self reference", req: StageRequest{Name: "applied", Next: &[]string{"applied"}}, wantErr: "itself"},
+		{name: "empty label on update", req: StageRequest{Name: "applied", Label: str(" ")}, wantErr: "label cannot be empty"},
+		{name: "partial update", req: StageRequest{Name: "applied", Position: intPtr(25)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate(testPipeline, tt.creating)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
 func intPtr(v int) *int { return &v }
 
 func contains(s, substr string) bool {
@@ -117,3 +229,219 @@ func containsAt(s, substr string) bool {
 	}
 	return false
 }
+
+func TestContactRequestValidate(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		name     string
+		req      ContactRequest
+		creating bool
+		wantErr  string
+	}{
+		{name: "valid create", req: ContactRequest{Name: str("Jane"), Email: str("jane@example.com"), LinkedInURL: str("https://linkedin.com/in/jane")}, creating: true},
+		{name: "missing name", req: ContactRequest{Email: str("jane@example.com")}, creating: true, wantErr: "name is required"},
+		{name: "display name email", req: ContactRequest{Name: str("Jane"), Email: str("Jane <jane@example.com>")}, creating: true, wantErr: "email"},
+		{name: "bad linkedin", req: ContactRequest{Name: str("Jane"), LinkedInURL: str("ftp://x")}, creating: true, wantErr: "linkedin_url"},
+		{name: "clear email on update", req: ContactRequest{Email: str("")}},
+		{name: "empty name on update", req: ContactRequest{Name: str("")}, wantErr: "name cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate(tt.creating)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestInterviewRequestApply(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	var iv Interview
+	err := InterviewRequest{StartsAt: str("2026-03-02T15:00"), EndsAt: str("2026-03-02T16:00"), Timezone: str("Europe/Berlin"), Format: str("video")}.Apply(&iv, true)
+	if err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if iv.StartsAt != "2026-03-02T14:00:00Z" || iv.EndsAt != "2026-03-02T15:00:00Z" {
+		t.Fatalf("expected local times converted from Europe/Berlin, got %s - %s", iv.StartsAt, iv.EndsAt)
+	}
+	if iv.Outcome != "pending" || iv.Interviewers == nil {
+		t.Fatalf("expected defaults, got %+v", iv)
+	}
+
+	// Changing only the timezone keeps the instant.
+	if err := (InterviewRequest{Timezone: str("America/New_York")}).Apply(&iv, false); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if iv.StartsAt != "2026-03-02T14:00:00Z" || iv.Timezone != "America/New_York" {
+		t.Fatalf("unexpected update result %+v", iv)
+	}
+
+	tests := []struct {
+		name    string
+		req     InterviewRequest
+		wantErr string
+	}{
+		{name: "missing start", req: InterviewRequest{Format: str("phone")}, wantErr: "starts_at is required"},
+		{name: "missing format", req: InterviewRequest{StartsAt: str("2026-03-02T15:00:00Z")}, wantErr: "format is required"},
+		{name: "bad format", req: InterviewRequest{StartsAt: str("2026-03-02T15:00:00Z"), Format: str("zoom")}, wantErr: "invalid format"},
+		{name: "bad timezone", req: InterviewRequest{StartsAt: str("2026-03-02T15:00:00Z"), Format: str("phone"), Timezone: str("Mars/Olympus")}, wantErr: "unknown timezone"},
+		{name: "local timezone", req: InterviewRequest{StartsAt: str("2026-03-02T15:00:00Z"), Format: str("phone"), Timezone: str("Local")}, wantErr: "IANA"},
+		{name: "bad time", req: InterviewRequest{StartsAt: str("next tuesday"), Format: str("phone")}, wantErr: "starts_at must be"},
+		{name: "end before start", req: InterviewRequest{StartsAt: str("2026-03-02T15:00:00Z"), EndsAt: str("2026-03-02T14:00:00Z"), Format: str("phone")}, wantErr: "ends_at must be after"},
+		{name: "bad outcome", req: InterviewRequest{StartsAt: str("2026-03-02T15:00:00Z"), Format: str("onsite"), Outcome: str("great")}, wantErr: "invalid outcome"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var iv Interview
+			err := tt.req.Apply(&iv, true)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := NormalizeTags([]string{" Referral", "dream-company", "referral", "", "visa_sponsor"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if strings.Join(tags, ",") != "referral,dream-company,visa_sponsor" {
+		t.Fatalf("unexpected tags: %v", tags)
+	}
+
+	for _, bad := range []string{"two words", "-leading", "emoji🙂", strings.Repeat("x", 33)} {
+		if _, err := NormalizeTags([]string{bad}); err == nil || !strings.Contains(err.Error(), "invalid tag") {
+			t.Fatalf("expected invalid tag error for %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestAPIKeyAllows(t *testing.T) {
+	read := APIKey{Scope: ScopeRead}
+	rw := APIKey{Scope: ScopeReadWrite}
+	if !read.Allows(ScopeRead) || read.Allows(ScopeReadWrite) {
+		t.Fatal("expected read key to allow reads only")
+	}
+	if !rw.Allows(ScopeRead) || !rw.Allows(ScopeReadWrite) {
+		t.Fatal("expected read-write key to allow both")
+	}
+	if err := ValidateScope("admin"); err == nil {
+		t.Fatal("expected unknown scope rejected")
+	}
+}
+
+func TestShareRequestNormalize(t *testing.T) {
+	req := ShareRequest{Query: "status=applied"}
+	kind, err := req.Normalize()
+	if err != nil || kind != ShareView || req.ExpiresInDays != DefaultShareDays || strings.Join(req.Private, ",") != "notes,salary" {
+		t.Fatalf("expected a view share with defaults, got %q %+v (%v)", kind, req, err)
+	}
+
+	req = ShareRequest{ApplicationID: "abcd1234", Private: []string{"url", "tags", "url"}}
+	if kind, err := req.Normalize(); err != nil || kind != ShareApplication || strings.Join(req.Private, ",") != "tags,url" {
+		t.Fatalf("expected an application share hiding tags and url, got %q %+v (%v)", kind, req, err)
+	}
+	req = ShareRequest{Private: []string{}}
+	if req.Normalize(); len(req.Private) != 0 {
+		t.Fatalf("expected an empty private list kept empty, got %v", req.Private)
+	}
+
+	for _, bad := range []ShareRequest{
+		{ExpiresInDays: -1},
+		{ExpiresInDays: MaxShareDays + 1},
+		{Private: []string{"salary", "password"}},
+		{ApplicationID: "abcd1234", Query: "status=applied"},
+	} {
+		if _, err := bad.Normalize(); err == nil {
+			t.Fatalf("expected %+v rejected", bad)
+		}
+	}
+}
+
+func TestBulkRequestNormalize(t *testing.T) {
+	req := BulkRequest{IDs: []string{"a", "b", "a"}, Action: BulkSetField, Field: "salary_max", Value: json.RawMessage("null")}
+	fields, err := req.Normalize()
+	if err != nil || strings.Join(req.IDs, ",") != "a,b" || fields["salary_max"] != 0 {
+		t.Fatalf("expected deduplicated ids and salary_max cleared, got %+v %v (%v)", req, fields, err)
+	}
+	req = BulkRequest{Query: "status=applied", Action: BulkAddTags, Tags: []string{" Remote", "remote"}}
+	if _, err := req.Normalize(); err != nil || strings.Join(req.Tags, ",") != "remote" {
+		t.Fatalf("expected normalized tags, got %v (%v)", req.Tags, err)
+	}
+	req = BulkRequest{Query: "status=applied", Action: BulkSetStatus, Status: "withdrawn"}
+	if fields, err := req.Normalize(); err != nil || fields["status"] != "withdrawn" {
+		t.Fatalf("expected a status field, got %v (%v)", fields, err)
+	}
+
+	for _, bad := range []BulkRequest{
+		{Action: BulkDelete},
+		{IDs: []string{"a"}, Query: "status=applied", Action: BulkDelete},
+		{IDs: []string{"a"}, Action: "archive"},
+		{IDs: []string{"a"}, Action: BulkSetStatus},
+		{IDs: []string{"a"}, Action: BulkSetField, Field: "status", Value: json.RawMessage(`"offer"`)},
+		{IDs: []string{"a"}, Action: BulkSetField, Field: "notes"},
+		{IDs: []string{"a"}, Action: BulkSetField, Field: "notes", Value: json.RawMessage("3")},
+		{IDs: []string{"a"}, Action: BulkSetField, Field: "salary_min", Value: json.RawMessage(`"lots"`)},
+		{IDs: []string{"a"}, Action: BulkSetField, Field: "salary_min", Value: json.RawMessage("-1")},
+		{IDs: []string{"a"}, Action: BulkSetField, Field: "company", Value: json.RawMessage("null")},
+		{IDs: []string{"a"}, Action: BulkAddTags},
+		{IDs: []string{"a"}, Action: BulkAddTags, Tags: []string{"no spaces"}},
+	} {
+		if _, err := bad.Normalize(); err == nil {
+			t.Fatalf("expected %+v rejected", bad)
+		}
+	}
+}
+
+func TestDuplicateReason(t *testing.T) {
+	for _, tc := range []struct {
+		a, b Application
+		want string
+	}{
+		{Application{Company: "Acme", Role: "Eng", URL: "https://www.Example.com/jobs/1/"}, Application{Company: "Globex", Role: "Designer", URL: "http://example.com/jobs/1#apply"}, DuplicateSameURL},
+		{Application{Company: "The Acme Corp., Inc.", Role: "Sr. Backend Eng"}, Application{Company: "acme", Role: "Senior Backend Engineer"}, DuplicateSimilar},
+		{Application{Company: "Gogle", Role: "SWE", URL: "https://gogle.example/1"}, Application{Company: "Google LLC", Role: "Software Engineer", URL: "https://google.example/2"}, DuplicateSimilar},
+		{Application{Company: "Acme", Role: "Backend Engineer"}, Application{Company: "Acme", Role: "Frontend Engineer"}, ""},
+		{Application{Company: "Acme", Role: "Engineer"}, Application{Company: "Globex", Role: "Engineer"}, ""},
+		{Application{Company: "Acme", Role: "Eng", URL: "https://example.com/jobs?id=1"}, Application{Company: "Globex", Role: "Eng", URL: "https://example.com/jobs?id=2"}, ""},
+		{Application{Company: "", Role: "Eng"}, Application{Company: "", Role: "Eng"}, ""},
+	} {
+		if got, _ := DuplicateReason(tc.a, tc.b); got != tc.want {
+			t.Errorf("DuplicateReason(%+v, %+v) = %q, want %q", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestCursor(t *testing.T) {
+	app := Application{ID: "deadbeef", Company: "Acme", SalaryMin: 120000}
+	for sortBy, want := range map[string]interface{}{"company": "Acme", "salary_min": int64(120000)} {
+		c, err := DecodeCursor(NewCursor(app, sortBy, "asc").Encode())
+		if err != nil || c.SortBy != sortBy || c.SortOrder != "asc" || c.ID != "deadbeef" || c.Value != want {
+			t.Fatalf("expected the cursor back, got %+v (%v)", c, err)
+		}
+	}
+
+	for _, bad := range []string{
+		"not base64!",
+		base64.RawURLEncoding.EncodeToString([]byte(`{"s":"notes","o":"asc","v":"x","id":"deadbeef"}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"s":"company","o":"up","v":"x","id":"deadbeef"}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"s":"company","o":"asc","v":1,"id":"deadbeef"}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"s":"salary_min","o":"asc","v":"1","id":"deadbeef"}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"s":"salary_min","o":"asc","v":1.5,"id":"deadbeef"}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"s":"company","o":"asc","v":"x"}`)),
+	} {
+		if _, err := DecodeCursor(bad); err != ErrInvalidCursor {
+			t.Fatalf("expected %q rejected, got %v", bad, err)
+		}
+	}
+}
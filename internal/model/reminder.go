@@ -0,0 +1,58 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Reminder kinds.
+const (
+	ReminderFollowUp = "follow_up" // created automatically on entering applied
+	ReminderCustom   = "custom"
+)
+
+// Reminder is a dated nudge attached to an application. DueAt and
+// CompletedAt are RFC3339 UTC; CompletedAt is empty until Done.
+type Reminder struct {
+	ID            string `json:"id"`
+	ApplicationID string `json:"application_id"`
+	Kind          string `json:"kind"`
+	DueAt         string `json:"due_at"`
+	Message       string `json:"message"`
+	Done          bool   `json:"done"`
+	CompletedAt   string `json:"completed_at"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+// DueReminder is a reminder plus enough of its application to act on it.
+type DueReminder struct {
+	Reminder
+	Company string `json:"company"`
+	Role    string `json:"role"`
+	Status  string `json:"status"`
+}
+
+type ReminderRequest struct {
+	DueAt   string `json:"due_at"`
+	Message string `json:"message"`
+}
+
+// Normalize validates the request and returns due_at as RFC3339 UTC. A bare
+// date (YYYY-MM-DD) means the start of that day in UTC.
+func (r ReminderRequest) Normalize() (dueAt string, err error) {
+	if strings.TrimSpace(r.Message) == "" {
+		return "", fmt.Errorf("message is required")
+	}
+	if r.DueAt == "" {
+		return "", fmt.Errorf("due_at is required")
+	}
+	if t, err := time.Parse(time.RFC3339, r.DueAt); err == nil {
+		return t.UTC().Format(time.RFC3339), nil
+	}
+	if t, err := time.Parse("2006-01-02", r.DueAt); err == nil {
+		return t.Format(time.RFC3339), nil
+	}
+	return "", fmt.Errorf("due_at must be RFC3339 (2026-03-02T09:00:00Z) or a date (2026-03-02)")
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Share kinds: a single application, or a saved list filter.
+const (
+	ShareApplication = "application"
+	ShareView        = "view"
+)
+
+const (
+	DefaultShareDays = 7
+	MaxShareDays     = 365
+)
+
+// PrivateFields maps each field that can be hidden from a share to the JSON
+// keys it removes. Hiding notes also hides search snippets, which quote them.
+var PrivateFields = map[string][]string{
+	"salary":     {"salary_min", "salary_max"},
+	"notes":      {"notes", "snippet"},
+	"url":        {"url"},
+	"location":   {"location"},
+	"applied_at": {"applied_at"},
+	"tags":       {"tags"},
+}
+
+// DefaultPrivateFields are hidden when a share does not say otherwise.
+var DefaultPrivateFields = []string{"salary", "notes"}
+
+// Share grants read-only access to an application or a filtered list without
+// an account. Token is only set when the share is created.
+type Share struct {
+	ID            string `json:"id"`
+	Kind          string `json:"kind"`
+	ApplicationID string `json:"application_id,omitempty"`
+	// Query is the saved filter of a view share, as list query parameters
+	// without limit and offset.
+	Query     string   `json:"query,omitempty"`
+	Private   []string `json:"private"`
+	CreatedAt string   `json:"created_at"`
+	ExpiresAt string   `json:"expires_at"`
+	Token     string   `json:"token,omitempty"`
+	// OwnerID is whose data the share exposes.
+	OwnerID string `json:"-"`
+}
+
+// ShareRequest creates an application share when ApplicationID is set and a
+// view share otherwise. A nil Private means DefaultPrivateFields; an empty
+// one hides nothing.
+type ShareRequest struct {
+	ApplicationID string   `json:"application_id"`
+	Query         string   `json:"query"`
+	ExpiresInDays int      `json:"expires_in_days"`
+	Private       []string `json:"private"`
+}
+
+// Normalize checks r and fills in defaults, returning the share's kind.
+func (r *ShareRequest) Normalize() (string, error) {
+	if r.ExpiresInDays == 0 {
+		r.ExpiresInDays = DefaultShareDays
+	}
+	if r.ExpiresInDays < 1 || r.ExpiresInDays > MaxShareDays {
+		return "", fmt.Errorf("expires_in_days must be between 1 and %d", MaxShareDays)
+	}
+	if r.Private == nil {
+		r.Private = slices.Clone(DefaultPrivateFields)
+	}
+	for _, f := range r.Private {
+		if _, ok := PrivateFields[f]; !ok {
+			return "", fmt.Errorf("invalid private field %q, valid values: %s", f, strings.Join(privateFieldNames(), ", "))
+		}
+	}
+	slices.Sort(r.Private)
+	r.Private = slices.Compact(r.Private)
+
+	if r.ApplicationID == "" {
+		return ShareView, nil
+	}
+	if r.Query != "" {
+		return "", fmt.Errorf("set application_id or query, not both")
+	}
+	return ShareApplication, nil
+}
+
+func privateFieldNames() []string {
+	names := make([]string, 0, len(PrivateFields))
+	for name := range PrivateFields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,137 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var validStageNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// Stage is one step of the application pipeline. Next lists the stages an
+// application may move to from this one without an override; terminal stages
+// have no next stages.
+type Stage struct {
+	Name     string   `json:"name"`
+	Label    string   `json:"label"`
+	Position int      `json:"position"`
+	Terminal bool     `json:"terminal"`
+	Next     []string `json:"next"`
+}
+
+// Pipeline is the set of configured stages ordered by position.
+type Pipeline []Stage
+
+// Stage returns the stage with the given name.
+func (p Pipeline) Stage(name string) (Stage, bool) {
+	for _, s := range p {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Stage{}, false
+}
+
+func (p Pipeline) Has(name string) bool {
+	_, ok := p.Stage(name)
+	return ok
+}
+
+// Names returns the stage names in pipeline order.
+func (p Pipeline) Names() []string {
+	names := make([]string, len(p))
+	for i, s := range p {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// Default returns the status given to applications created without one: the
+// first non-terminal stage.
+func (p Pipeline) Default() string {
+	for _, s := range p {
+		if !s.Terminal {
+			return s.Name
+		}
+	}
+	return ""
+}
+
+// ValidateStatus accepts an empty status (meaning "unset") or any stage name.
+func (p Pipeline) ValidateStatus(status string) error {
+	if status != "" && !p.Has(status) {
+		return fmt.Errorf("invalid status %q, valid values: %s", status, strings.Join(p.Names(), ", "))
+	}
+	return nil
+}
+
+// ValidateTransition returns a *TransitionError unless to is one of from's
+// next stages. Staying on the same status is always allowed.
+func (p Pipeline) ValidateTransition(from, to string) error {
+	if from == to {
+		return nil
+	}
+	stage, _ := p.Stage(from)
+	for _, n := range stage.Next {
+		if n == to {
+			return nil
+		}
+	}
+	return &TransitionError{From: from, To: to, Allowed: append([]string{}, stage.Next...)}
+}
+
+// TransitionError reports a status change that the pipeline does not allow.
+type TransitionError struct {
+	From    string
+	To      string
+	Allowed []string
+}
+
+func (e *TransitionError) Error() string {
+	if len(e.Allowed) == 0 {
+		return fmt.Sprintf("cannot change status from %q to %q: %q has no next stages", e.From, e.To, e.From)
+	}
+	return fmt.Sprintf("cannot change status from %q to %q, allowed next statuses: %s", e.From, e.To, strings.Join(e.Allowed, ", "))
+}
+
+// StageRequest is the body for creating or updating a stage. On update, nil
+// fields are left unchanged; Name cannot be changed.
+type StageRequest struct {
+	Name     string    `json:"name"`
+	Label    *string   `json:"label"`
+	Position *int      `json:"position"`
+	Terminal *bool     `json:"terminal"`
+	Next     *[]string `json:"next"`
+}
+
+// Validate checks the request against the current pipeline. creating selects
+// the rules for POST (name and label required) versus PUT.
+func (r StageRequest) Validate(p Pipeline, creating bool) error {
+	if creating {
+		if !validStageNameRegex.MatchString(r.Name) {
+			return fmt.Errorf("name must be 1-32 lowercase letters, digits or underscores, starting with a letter")
+		}
+		if r.Label == nil || strings.TrimSpace(*r.Label) == "" {
+			return fmt.Errorf("label is required")
+		}
+	} else if r.Label != nil && strings.TrimSpace(*r.Label) == "" {
+		return fmt.Errorf("label cannot be empty")
+	}
+	if r.Position != nil && *r.Position < 0 {
+		return fmt.Errorf("position must be non-negative")
+	}
+	if r.Next != nil {
+		if r.Terminal != nil && *r.Terminal && len(*r.Next) > 0 {
+			return fmt.Errorf("terminal stages cannot have next stages")
+		}
+		for _, n := range *r.Next {
+			if n == r.Name {
+				return fmt.Errorf("next cannot include the stage itself")
+			}
+			if !p.Has(n) {
+				return fmt.Errorf("unknown next stage %q, valid values: %s", n, strings.Join(p.Names(), ", "))
+			}
+		}
+	}
+	return nil
+}
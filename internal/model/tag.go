@@ -0,0 +1,40 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var validTagRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// TagCount is a tag and the number of applications carrying it.
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// TagsRequest is the body for adding tags to or removing them from an
+// application.
+type TagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// NormalizeTags lowercases and trims tags, drops duplicates and empty
+// entries, and checks each against the tag format.
+func NormalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		if !validTagRegex.MatchString(t) {
+			return nil, fmt.Errorf("invalid tag %q: use 1-32 lowercase letters, digits, '-' or '_'", t)
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out, nil
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+// MinPasswordLength is the shortest password an account can have.
+const MinPasswordLength = 8
+
+// User is an account. Every application, contact and tag belongs to exactly
+// one user and is invisible to the others.
+type User struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Session is a login. Token is only set when the session is created; the
+// store keeps just its hash.
+type Session struct {
+	Token     string `json:"token,omitempty"`
+	User      User   `json:"user"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// NormalizeEmail trims and lowercases an address and checks it has the shape
+// local@domain.
+func NormalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" || domain == "" || strings.ContainsAny(email, " \t\r\n") {
+		return "", errors.New("email must look like name@example.com")
+	}
+	return email, nil
+}
+
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return errors.New("password must be at least 8 characters")
+	}
+	return nil
+}